@@ -0,0 +1,149 @@
+// Package bimap implements a bidirectional map.
+package bimap
+
+import (
+	"errors"
+	"iter"
+	"maps"
+)
+
+var (
+	// ErrKeyExists is returned by Insert when the key is already mapped
+	// to a different value.
+	ErrKeyExists = errors.New("bimap: key already mapped")
+	// ErrValueExists is returned by Insert when the value is already
+	// mapped from a different key.
+	ErrValueExists = errors.New("bimap: value already mapped")
+)
+
+// Map is a one-to-one map between keys and values: each key maps to at
+// most one value and each value to at most one key, so lookups by value
+// are as cheap as lookups by key. All operations except iteration are
+// O(1).
+//
+// The zero value for Map is an empty map ready to use.
+type Map[K, V comparable] struct {
+	fwd map[K]V
+	rev map[V]K
+}
+
+// New returns an empty Map.
+func New[K, V comparable]() *Map[K, V] {
+	return &Map[K, V]{fwd: make(map[K]V), rev: make(map[V]K)}
+}
+
+// Len returns the number of pairs in m.
+func (m *Map[K, V]) Len() int {
+	return len(m.fwd)
+}
+
+// Get returns the value for k. The boolean is false if k is not in m.
+func (m *Map[K, V]) Get(k K) (V, bool) {
+	v, ok := m.fwd[k]
+	return v, ok
+}
+
+// GetOr returns the value for k, or def if k is not in m.
+func (m *Map[K, V]) GetOr(k K, def V) V {
+	if v, ok := m.fwd[k]; ok {
+		return v
+	}
+	return def
+}
+
+// MustGet returns the value for k. It panics if k is not in m.
+func (m *Map[K, V]) MustGet(k K) V {
+	v, ok := m.fwd[k]
+	if !ok {
+		panic("bimap: MustGet of missing key")
+	}
+	return v
+}
+
+// GetByValue returns the key for v. The boolean is false if v is not in
+// m.
+func (m *Map[K, V]) GetByValue(v V) (K, bool) {
+	k, ok := m.rev[v]
+	return k, ok
+}
+
+// ContainsKey reports whether k is in m.
+func (m *Map[K, V]) ContainsKey(k K) bool {
+	_, ok := m.fwd[k]
+	return ok
+}
+
+// ContainsValue reports whether v is in m.
+func (m *Map[K, V]) ContainsValue(v V) bool {
+	_, ok := m.rev[v]
+	return ok
+}
+
+// Put maps k to v, removing any existing pair that has key k or value v
+// so that the map stays one-to-one.
+func (m *Map[K, V]) Put(k K, v V) {
+	m.DeleteKey(k)
+	m.DeleteValue(v)
+	if m.fwd == nil {
+		m.fwd = make(map[K]V)
+		m.rev = make(map[V]K)
+	}
+	m.fwd[k] = v
+	m.rev[v] = k
+}
+
+// Insert maps k to v only if neither is already mapped to something
+// else. It returns ErrKeyExists or ErrValueExists on conflict, and nil
+// if the pair was added or was already present.
+func (m *Map[K, V]) Insert(k K, v V) error {
+	if old, ok := m.fwd[k]; ok {
+		if old == v {
+			return nil
+		}
+		return ErrKeyExists
+	}
+	if _, ok := m.rev[v]; ok {
+		return ErrValueExists
+	}
+	m.Put(k, v)
+	return nil
+}
+
+// DeleteKey removes the pair with key k. It reports whether k was in m.
+func (m *Map[K, V]) DeleteKey(k K) bool {
+	v, ok := m.fwd[k]
+	if !ok {
+		return false
+	}
+	delete(m.fwd, k)
+	delete(m.rev, v)
+	return true
+}
+
+// DeleteValue removes the pair with value v. It reports whether v was in
+// m.
+func (m *Map[K, V]) DeleteValue(v V) bool {
+	k, ok := m.rev[v]
+	if !ok {
+		return false
+	}
+	delete(m.rev, v)
+	delete(m.fwd, k)
+	return true
+}
+
+// Clear removes all pairs from m.
+func (m *Map[K, V]) Clear() {
+	clear(m.fwd)
+	clear(m.rev)
+}
+
+// All returns an iterator over the pairs of m in unspecified order.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return maps.All(m.fwd)
+}
+
+// Inverse returns a new Map with the keys and values of m swapped.
+func (m *Map[K, V]) Inverse() *Map[V, K] {
+	return &Map[V, K]{fwd: maps.Clone(m.rev), rev: maps.Clone(m.fwd)}
+}
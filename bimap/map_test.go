@@ -0,0 +1,74 @@
+package bimap
+
+import (
+	"errors"
+	"maps"
+	"testing"
+)
+
+func checkConsistent[K, V comparable](t *testing.T, m *Map[K, V], want map[K]V) {
+	t.Helper()
+	if got := maps.Collect(m.All()); !maps.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	if m.Len() != len(want) || len(m.rev) != len(want) {
+		t.Errorf("Len() = %d, reverse len %d; want %d", m.Len(), len(m.rev), len(want))
+	}
+	for k, v := range want {
+		if got, ok := m.GetByValue(v); !ok || got != k {
+			t.Errorf("GetByValue(%v) = %v, %v; want %v, true", v, got, ok, k)
+		}
+	}
+}
+
+func TestMapPut(t *testing.T) {
+	var m Map[int, string]
+	m.Put(1, "one")
+	m.Put(2, "two")
+	checkConsistent(t, &m, map[int]string{1: "one", 2: "two"})
+
+	m.Put(1, "uno") // rebinds key 1
+	checkConsistent(t, &m, map[int]string{1: "uno", 2: "two"})
+	if m.ContainsValue("one") {
+		t.Errorf("stale value one still mapped")
+	}
+
+	m.Put(3, "two") // steals value two from key 2
+	checkConsistent(t, &m, map[int]string{1: "uno", 3: "two"})
+	if m.ContainsKey(2) {
+		t.Errorf("stale key 2 still mapped")
+	}
+
+	if m.MustGet(3) != "two" || m.GetOr(2, "none") != "none" {
+		t.Errorf("MustGet/GetOr returned wrong values")
+	}
+	if !m.DeleteValue("uno") || m.DeleteKey(1) || !m.DeleteKey(3) {
+		t.Errorf("Delete did not report removals correctly")
+	}
+	checkConsistent(t, &m, map[int]string{})
+}
+
+func TestMapInsert(t *testing.T) {
+	m := New[string, int]()
+	if err := m.Insert("a", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Insert("a", 1); err != nil {
+		t.Errorf("Insert of existing pair = %v, want nil", err)
+	}
+	if err := m.Insert("a", 2); !errors.Is(err, ErrKeyExists) {
+		t.Errorf("Insert with taken key = %v, want ErrKeyExists", err)
+	}
+	if err := m.Insert("b", 1); !errors.Is(err, ErrValueExists) {
+		t.Errorf("Insert with taken value = %v, want ErrValueExists", err)
+	}
+	checkConsistent(t, m, map[string]int{"a": 1})
+
+	inv := m.Inverse()
+	inv.Put(2, "b")
+	if k, _ := inv.Get(1); k != "a" || m.ContainsKey("b") {
+		t.Errorf("Inverse is not an independent copy")
+	}
+	m.Clear()
+	checkConsistent(t, m, map[string]int{})
+}
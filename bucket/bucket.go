@@ -0,0 +1,242 @@
+// Package bucket implements token-bucket and leaky-bucket rate limiters.
+package bucket
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrExceedsCapacity is returned by Wait when the request can never be
+// satisfied because it is larger than the bucket's capacity.
+var ErrExceedsCapacity = errors.New("bucket: request exceeds capacity")
+
+// checkN panics if n is not a valid request size.
+func checkN(n int) {
+	if n <= 0 {
+		panic("bucket: request of non-positive size")
+	}
+}
+
+// Token is a token bucket. The bucket holds up to capacity tokens and
+// refills at a constant rate; an event of size n consumes n tokens, so
+// bursts of up to capacity are allowed after a quiet period.
+//
+// Methods that take a size n panic if n is not positive.
+//
+// A Token is safe for concurrent use by multiple goroutines.
+type Token struct {
+	rate     float64 // tokens per second
+	capacity float64
+	now      func() time.Time
+
+	mu     sync.Mutex
+	tokens float64 // negative while reservations are outstanding
+	last   time.Time
+}
+
+// NewToken returns a full Token bucket that refills at rate tokens per
+// second up to capacity. It panics if rate or capacity is not positive.
+func NewToken(rate float64, capacity int) *Token {
+	if rate <= 0 || capacity <= 0 {
+		panic("bucket: NewToken with non-positive rate or capacity")
+	}
+	return &Token{rate: rate, capacity: float64(capacity), tokens: float64(capacity), now: time.Now}
+}
+
+// SetClock sets the function Wait uses to read the current time, which is
+// time.Now by default, so that Wait agrees with the times passed to Allow
+// and Reserve. Wait still sleeps in real time. SetClock must be called
+// before b is used.
+func (b *Token) SetClock(now func() time.Time) {
+	b.now = now
+}
+
+// advance refills b for the time elapsed up to now. b.mu must be held.
+func (b *Token) advance(now time.Time) {
+	if b.last.IsZero() {
+		b.last = now
+		return
+	}
+	if d := now.Sub(b.last); d > 0 {
+		b.tokens = min(b.capacity, b.tokens+d.Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// Tokens returns the number of tokens available at now. The result is
+// negative while reserved tokens are still owed.
+func (b *Token) Tokens(now time.Time) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(now)
+	return b.tokens
+}
+
+// Allow reports whether n tokens are available at now, and if so consumes
+// them. It always reports false if n exceeds the capacity. Times passed to
+// b should be non-decreasing.
+func (b *Token) Allow(now time.Time, n int) bool {
+	checkN(n)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(now)
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// Reserve consumes n tokens at now, going into debt if necessary, and
+// returns how long the caller must wait before acting. The boolean is
+// false, and nothing is consumed, if n exceeds the capacity.
+func (b *Token) Reserve(now time.Time, n int) (time.Duration, bool) {
+	checkN(n)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if float64(n) > b.capacity {
+		return 0, false
+	}
+	b.advance(now)
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0, true
+	}
+	return seconds(-b.tokens / b.rate), true
+}
+
+// Wait blocks until n tokens are available, consuming them, or until ctx
+// is done. It returns ErrExceedsCapacity at once if n exceeds the
+// capacity. If ctx is done first the reserved tokens are returned to the
+// bucket.
+func (b *Token) Wait(ctx context.Context, n int) error {
+	d, ok := b.Reserve(b.now(), n)
+	if !ok {
+		return ErrExceedsCapacity
+	}
+	if err := sleep(ctx, d); err != nil {
+		b.mu.Lock()
+		b.tokens = min(b.capacity, b.tokens+float64(n))
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Leaky is a leaky bucket used as a queue. Units drain from the bucket at
+// a constant rate, so admitted events are spaced evenly and never burst;
+// capacity bounds how many units may be waiting in the bucket.
+//
+// Methods that take a size n panic if n is not positive.
+//
+// A Leaky is safe for concurrent use by multiple goroutines.
+type Leaky struct {
+	interval time.Duration // drain time per unit
+	capacity int
+	now      func() time.Time
+
+	mu   sync.Mutex
+	next time.Time // when the bucket will next be empty
+}
+
+// NewLeaky returns an empty Leaky bucket that drains rate units per second
+// and holds up to capacity units. It panics if rate or capacity is not
+// positive.
+func NewLeaky(rate float64, capacity int) *Leaky {
+	if rate <= 0 || capacity <= 0 {
+		panic("bucket: NewLeaky with non-positive rate or capacity")
+	}
+	return &Leaky{interval: seconds(1 / rate), capacity: capacity, now: time.Now}
+}
+
+// SetClock sets the function Wait uses to read the current time, as for
+// Token.SetClock.
+func (b *Leaky) SetClock(now func() time.Time) {
+	b.now = now
+}
+
+// Allow reports whether n units can leave the bucket immediately at now,
+// which requires the bucket to be empty, and if so admits them. It always
+// reports false if n exceeds the capacity. Times passed to b should be
+// non-decreasing.
+func (b *Leaky) Allow(now time.Time, n int) bool {
+	checkN(n)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if n > b.capacity || b.next.After(now) {
+		return false
+	}
+	b.next = now.Add(time.Duration(n) * b.interval)
+	return true
+}
+
+// Reserve adds n units to the bucket at now and returns how long the
+// caller must wait for them to reach the front. The boolean is false, and
+// nothing is added, if the units do not fit in the bucket.
+func (b *Leaky) Reserve(now time.Time, n int) (time.Duration, bool) {
+	checkN(n)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	start := now
+	if b.next.After(now) {
+		start = b.next
+	}
+	wait := start.Sub(now)
+	queued := int((wait + b.interval - 1) / b.interval)
+	if queued+n > b.capacity {
+		return 0, false
+	}
+	b.next = start.Add(time.Duration(n) * b.interval)
+	return wait, true
+}
+
+// Wait blocks until n units can leave the bucket, or until ctx is done.
+// It returns ErrExceedsCapacity if n exceeds the capacity, and otherwise
+// waits for space in the bucket as needed.
+func (b *Leaky) Wait(ctx context.Context, n int) error {
+	checkN(n)
+	if n > b.capacity {
+		return ErrExceedsCapacity
+	}
+	for {
+		d, ok := b.Reserve(b.now(), n)
+		if ok {
+			if err := sleep(ctx, d); err != nil {
+				b.cancel(n)
+				return err
+			}
+			return nil
+		}
+		// The bucket is full; wait for n units to drain and try again.
+		if err := sleep(ctx, time.Duration(n)*b.interval); err != nil {
+			return err
+		}
+	}
+}
+
+// cancel withdraws n units from the back of the bucket.
+func (b *Leaky) cancel(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.next = b.next.Add(-time.Duration(n) * b.interval)
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
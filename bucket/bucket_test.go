@@ -0,0 +1,188 @@
+package bucket
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestTokenAllow(t *testing.T) {
+	b := NewToken(2, 4) // 2 tokens/s, burst 4
+	for i := 0; i < 4; i++ {
+		if !b.Allow(t0, 1) {
+			t.Fatalf("Allow #%d in burst = false", i)
+		}
+	}
+	if b.Allow(t0, 1) {
+		t.Errorf("Allow on empty bucket = true")
+	}
+	if !b.Allow(t0.Add(500*time.Millisecond), 1) {
+		t.Errorf("Allow after refilling one token = false")
+	}
+	if b.Allow(t0.Add(500*time.Millisecond), 1) {
+		t.Errorf("Allow beyond refill = true")
+	}
+	if got := b.Tokens(t0.Add(time.Hour)); got != 4 {
+		t.Errorf("Tokens after long idle = %v, want 4", got)
+	}
+	if b.Allow(t0.Add(time.Hour), 5) {
+		t.Errorf("Allow(5) on capacity 4 = true")
+	}
+}
+
+func TestTokenReserve(t *testing.T) {
+	b := NewToken(10, 5)
+	if d, ok := b.Reserve(t0, 5); !ok || d != 0 {
+		t.Errorf("Reserve(5) on full bucket = %v, %v; want 0, true", d, ok)
+	}
+	if d, ok := b.Reserve(t0, 2); !ok || d != 200*time.Millisecond {
+		t.Errorf("Reserve(2) on empty bucket = %v, %v; want 200ms, true", d, ok)
+	}
+	if d, ok := b.Reserve(t0, 1); !ok || d != 300*time.Millisecond {
+		t.Errorf("Reserve(1) behind reservation = %v, %v; want 300ms, true", d, ok)
+	}
+	if b.Allow(t0.Add(200*time.Millisecond), 1) {
+		t.Errorf("Allow while in debt = true")
+	}
+	if _, ok := b.Reserve(t0, 6); ok {
+		t.Errorf("Reserve beyond capacity = true")
+	}
+}
+
+func TestTokenWait(t *testing.T) {
+	b := NewToken(1000, 1)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if err := b.Wait(ctx, 1); err != nil {
+			t.Fatalf("Wait = %v", err)
+		}
+	}
+	if err := b.Wait(ctx, 2); !errors.Is(err, ErrExceedsCapacity) {
+		t.Errorf("Wait(2) = %v, want ErrExceedsCapacity", err)
+	}
+
+	slow := NewToken(0.001, 1)
+	slow.Allow(time.Now(), 1)
+	cctx, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+	defer cancel()
+	if err := slow.Wait(cctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait on slow bucket = %v, want DeadlineExceeded", err)
+	}
+	if got := slow.Tokens(time.Now()); got < 0 {
+		t.Errorf("Tokens after cancelled Wait = %v, want reservation returned", got)
+	}
+}
+
+func TestLeaky(t *testing.T) {
+	b := NewLeaky(10, 3) // one unit per 100ms, up to 3 queued
+	if !b.Allow(t0, 1) {
+		t.Errorf("Allow on empty bucket = false")
+	}
+	if b.Allow(t0.Add(50*time.Millisecond), 1) {
+		t.Errorf("Allow before drain = true")
+	}
+	if !b.Allow(t0.Add(100*time.Millisecond), 1) {
+		t.Errorf("Allow after drain = false")
+	}
+
+	now := t0.Add(time.Second)
+	for i, want := range []time.Duration{0, 100, 200} {
+		if d, ok := b.Reserve(now, 1); !ok || d != want*time.Millisecond {
+			t.Errorf("Reserve #%d = %v, %v; want %vms, true", i, d, ok, want)
+		}
+	}
+	if _, ok := b.Reserve(now, 1); ok {
+		t.Errorf("Reserve on full bucket = true")
+	}
+	if d, ok := b.Reserve(now.Add(150*time.Millisecond), 1); !ok || d != 150*time.Millisecond {
+		t.Errorf("Reserve after partial drain = %v, %v; want 150ms, true", d, ok)
+	}
+	if b.Allow(now, 4) {
+		t.Errorf("Allow(4) on capacity 3 = true")
+	}
+}
+
+func TestLeakyWait(t *testing.T) {
+	b := NewLeaky(1000, 2)
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := b.Wait(ctx, 1); err != nil {
+			t.Fatalf("Wait = %v", err)
+		}
+	}
+	if d := time.Since(start); d < 3*time.Millisecond {
+		t.Errorf("5 units at 1000/s took %v, want at least 3ms", d)
+	}
+	if err := b.Wait(ctx, 3); !errors.Is(err, ErrExceedsCapacity) {
+		t.Errorf("Wait(3) = %v, want ErrExceedsCapacity", err)
+	}
+}
+
+func TestNewPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"NewToken": func() { NewToken(0, 1) },
+		"NewLeaky": func() { NewLeaky(1, 0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s with bad arguments did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestSizePanics(t *testing.T) {
+	tb, lb := NewToken(1, 5), NewLeaky(1, 5)
+	ctx := context.Background()
+	for name, f := range map[string]func(){
+		"Token.Allow(0)":    func() { tb.Allow(t0, 0) },
+		"Token.Reserve(-1)": func() { tb.Reserve(t0, -1) },
+		"Token.Wait(-1)":    func() { tb.Wait(ctx, -1) },
+		"Leaky.Allow(-1)":   func() { lb.Allow(t0, -1) },
+		"Leaky.Reserve(0)":  func() { lb.Reserve(t0, 0) },
+		"Leaky.Wait(0)":     func() { lb.Wait(ctx, 0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+	if got := tb.Tokens(t0); got != 5 {
+		t.Errorf("Tokens() after rejected requests = %v, want 5", got)
+	}
+	if tb.Allow(t0, 6) {
+		t.Errorf("Token.Allow(6) on capacity 5 = true")
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	// The bucket's clock is far in the future, so by time.Now the bucket
+	// would stay empty for another 1000s.
+	future := time.Now().Add(time.Hour)
+	tb := NewToken(0.001, 1)
+	tb.Allow(future, 1)
+	tb.SetClock(func() time.Time { return future.Add(1000 * time.Second) })
+	cctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := tb.Wait(cctx, 1); err != nil {
+		t.Errorf("Token.Wait with clock past refill = %v, want nil", err)
+	}
+
+	lb := NewLeaky(0.001, 1)
+	lb.Allow(future, 1)
+	lb.SetClock(func() time.Time { return future.Add(1000 * time.Second) })
+	if err := lb.Wait(cctx, 1); err != nil {
+		t.Errorf("Leaky.Wait with clock past drain = %v, want nil", err)
+	}
+}
@@ -0,0 +1,67 @@
+// Package codec defines a typed encoding interface for persisting
+// container elements, along with implementations backed by the standard
+// library's encoding/json and encoding/gob packages.
+package codec
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// A Codec converts values of type T to and from bytes.
+type Codec[T any] interface {
+	Encode(v T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+// Func adapts a pair of encode and decode functions to a Codec.
+type Func[T any] struct {
+	EncodeFunc func(v T) ([]byte, error)
+	DecodeFunc func(data []byte) (T, error)
+}
+
+// Encode calls f.EncodeFunc(v).
+func (f Func[T]) Encode(v T) ([]byte, error) { return f.EncodeFunc(v) }
+
+// Decode calls f.DecodeFunc(data).
+func (f Func[T]) Decode(data []byte) (T, error) { return f.DecodeFunc(data) }
+
+// JSON returns a Codec that uses encoding/json.
+func JSON[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) Encode(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec[T]) Decode(data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+// Gob returns a Codec that uses encoding/gob. Each encoded value is
+// self-describing, so values can be decoded independently of one another.
+func Gob[T any]() Codec[T] {
+	return gobCodec[T]{}
+}
+
+type gobCodec[T any] struct{}
+
+func (gobCodec[T]) Encode(v T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec[T]) Decode(data []byte) (T, error) {
+	var v T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v)
+	return v, err
+}
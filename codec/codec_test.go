@@ -0,0 +1,58 @@
+package codec
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type record struct {
+	Name string
+	Tags []string
+	N    int
+}
+
+func testRoundTrip[T any](t *testing.T, c Codec[T], v T) {
+	t.Helper()
+
+	b, err := c.Encode(v)
+	if err != nil {
+		t.Fatalf("Encode(%v) error: %v", v, err)
+	}
+	got, err := c.Decode(b)
+	if err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+	if !reflect.DeepEqual(got, v) {
+		t.Errorf("round trip = %v, want %v", got, v)
+	}
+}
+
+func TestCodecs(t *testing.T) {
+	r := record{Name: "x", Tags: []string{"a", "b"}, N: 3}
+	testRoundTrip(t, JSON[record](), r)
+	testRoundTrip(t, Gob[record](), r)
+	testRoundTrip(t, JSON[[]int](), []int{1, 2, 3})
+	testRoundTrip(t, Gob[map[string]int](), map[string]int{"a": 1})
+}
+
+func TestDecodeError(t *testing.T) {
+	if _, err := JSON[int]().Decode([]byte("nope")); err == nil {
+		t.Errorf("JSON Decode of malformed input returned nil error")
+	}
+	if _, err := Gob[int]().Decode([]byte("nope")); err == nil {
+		t.Errorf("Gob Decode of malformed input returned nil error")
+	}
+}
+
+func TestFunc(t *testing.T) {
+	var c Codec[int] = Func[int]{
+		EncodeFunc: func(v int) ([]byte, error) { return strconv.AppendInt(nil, int64(v), 10), nil },
+		DecodeFunc: func(b []byte) (int, error) { return strconv.Atoi(string(b)) },
+	}
+	testRoundTrip(t, c, 42)
+	if _, err := c.Decode([]byte("x")); !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Decode(x) error = %v, want ErrSyntax", err)
+	}
+}
@@ -0,0 +1,163 @@
+// Package concurrent implements containers that are safe for concurrent
+// use by multiple goroutines.
+package concurrent
+
+import (
+	"hash/maphash"
+	"math/bits"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	counterShards     = 64
+	maxCounterStripes = 32
+)
+
+type counterShard[K comparable] struct {
+	mu sync.RWMutex
+	m  map[K]counter
+	_  [32]byte // keep shards on separate cache lines
+}
+
+// stripe is one part of a counter, padded to fill a cache line.
+type stripe struct {
+	n atomic.Int64
+	_ [56]byte
+}
+
+// counter is a striped int64: its value is the sum of its stripes.
+type counter []stripe
+
+func (s counter) load() int64 {
+	var total int64
+	for i := range s {
+		total += s[i].n.Load()
+	}
+	return total
+}
+
+// CounterMap is a map from keys to int64 counters, optimized for workloads
+// dominated by increments. Keys are spread across independently locked
+// shards, and each counter is split into cache-line-sized stripes, one
+// per processor up to a limit. An increment updates a randomly chosen
+// stripe under a shared lock, so goroutines incrementing the same key
+// rarely touch the same cache line; reads sum the stripes.
+//
+// Each counter costs 64 bytes per stripe, so CounterMap suits a moderate
+// number of hot keys rather than millions of cold ones.
+//
+// Use NewCounterMap to create a CounterMap.
+type CounterMap[K comparable] struct {
+	seed    maphash.Seed
+	stripes int // power of two
+	shards  [counterShards]counterShard[K]
+}
+
+// NewCounterMap returns an empty CounterMap.
+func NewCounterMap[K comparable]() *CounterMap[K] {
+	n := 1 << bits.Len(uint(runtime.GOMAXPROCS(0)-1))
+	c := &CounterMap[K]{seed: maphash.MakeSeed(), stripes: min(n, maxCounterStripes)}
+	for i := range c.shards {
+		c.shards[i].m = make(map[K]counter)
+	}
+	return c
+}
+
+func (c *CounterMap[K]) shard(k K) *counterShard[K] {
+	return &c.shards[maphash.Comparable(c.seed, k)%counterShards]
+}
+
+// Inc increments the counter for k by one.
+func (c *CounterMap[K]) Inc(k K) {
+	c.Add(k, 1)
+}
+
+// Add adds delta to the counter for k, creating it if needed. It does not
+// return the new value, since summing the stripes would reintroduce the
+// contention they avoid; use Load.
+func (c *CounterMap[K]) Add(k K, delta int64) {
+	var i uint32
+	if c.stripes > 1 {
+		i = rand.Uint32() & uint32(c.stripes-1)
+	}
+	s := c.shard(k)
+	s.mu.RLock()
+	n, ok := s.m[k]
+	if ok {
+		n[i].n.Add(delta)
+		s.mu.RUnlock()
+		return
+	}
+	s.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok = s.m[k]
+	if !ok {
+		n = make(counter, c.stripes)
+		s.m[k] = n
+	}
+	n[i].n.Add(delta)
+}
+
+// Load returns the counter for k. The boolean is false if k has no
+// counter.
+func (c *CounterMap[K]) Load(k K) (int64, bool) {
+	s := c.shard(k)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	n, ok := s.m[k]
+	if !ok {
+		return 0, false
+	}
+	return n.load(), true
+}
+
+// Delete removes the counter for k and returns its last value. Delete is
+// linearizable with concurrent calls to Add: an Add updates its stripe
+// while holding the shard's read lock, and Delete retires the counter and
+// sums its stripes under the write lock, so every Add is counted either
+// in the returned value or in a new counter created after Delete.
+func (c *CounterMap[K]) Delete(k K) (int64, bool) {
+	s := c.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := s.m[k]
+	if !ok {
+		return 0, false
+	}
+	delete(s.m, k)
+	return n.load(), true
+}
+
+// Len returns the number of counters in c.
+func (c *CounterMap[K]) Len() int {
+	total := 0
+	for i := range c.shards {
+		s := &c.shards[i]
+		s.mu.RLock()
+		total += len(s.m)
+		s.mu.RUnlock()
+	}
+	return total
+}
+
+// Snapshot returns a copy of all counters. Shards are copied one at a
+// time and stripes are summed without stopping writers, so under
+// concurrent updates the result is not a single atomic view, but it
+// includes every increment that finished before the call.
+func (c *CounterMap[K]) Snapshot() map[K]int64 {
+	out := make(map[K]int64)
+	for i := range c.shards {
+		s := &c.shards[i]
+		s.mu.RLock()
+		for k, n := range s.m {
+			out[k] = n.load()
+		}
+		s.mu.RUnlock()
+	}
+	return out
+}
@@ -0,0 +1,140 @@
+package concurrent
+
+import (
+	"maps"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestCounterMap(t *testing.T) {
+	c := NewCounterMap[string]()
+	if _, ok := c.Load("a"); ok {
+		t.Errorf("Load on empty map reported true")
+	}
+	c.Inc("a")
+	if v, _ := c.Load("a"); v != 1 {
+		t.Errorf("Load(a) after Inc = %d, want 1", v)
+	}
+	c.Add("a", 10)
+	if v, _ := c.Load("a"); v != 11 {
+		t.Errorf("Load(a) after Add(a, 10) = %d, want 11", v)
+	}
+	c.Add("b", -3)
+	if v, ok := c.Load("b"); v != -3 || !ok {
+		t.Errorf("Load(b) = %d, %v; want -3, true", v, ok)
+	}
+	if n := c.Len(); n != 2 {
+		t.Errorf("Len() = %d, want 2", n)
+	}
+	if got, want := c.Snapshot(), map[string]int64{"a": 11, "b": -3}; !maps.Equal(got, want) {
+		t.Errorf("Snapshot() = %v, want %v", got, want)
+	}
+	if v, ok := c.Delete("a"); v != 11 || !ok {
+		t.Errorf("Delete(a) = %d, %v; want 11, true", v, ok)
+	}
+	if _, ok := c.Delete("a"); ok {
+		t.Errorf("second Delete(a) reported true")
+	}
+	if n := c.Len(); n != 1 {
+		t.Errorf("Len() after Delete = %d, want 1", n)
+	}
+}
+
+func TestCounterMapConcurrent(t *testing.T) {
+	const goroutines, perG, keys = 8, 1000, 10
+	c := NewCounterMap[int]()
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perG; i++ {
+				c.Inc(i % keys)
+			}
+		}()
+	}
+	wg.Wait()
+	for k := 0; k < keys; k++ {
+		if v, _ := c.Load(k); v != goroutines*perG/keys {
+			t.Errorf("Load(%d) = %d, want %d", k, v, goroutines*perG/keys)
+		}
+	}
+}
+
+func TestCounterMapHotKey(t *testing.T) {
+	const goroutines, perG = 8, 1000
+	c := NewCounterMap[string]()
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perG; i++ {
+				c.Add("hot", 2)
+				c.Add("hot", -1)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := c.Snapshot()["hot"]; got != goroutines*perG {
+		t.Errorf("Snapshot()[hot] = %d, want %d", got, goroutines*perG)
+	}
+}
+
+func TestCounterMapDeleteConcurrent(t *testing.T) {
+	c := NewCounterMap[string]()
+	const writers, n = 8, 5000
+	var wg sync.WaitGroup
+	for range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range n {
+				c.Inc("k")
+			}
+		}()
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	var deleted int64
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			v, _ := c.Delete("k")
+			deleted += v
+			runtime.Gosched()
+		}
+	}()
+	wg.Wait()
+	close(stop)
+	<-done
+	rest, _ := c.Load("k")
+	if total := deleted + rest; total != writers*n {
+		t.Errorf("deleted %d + remaining %d = %d, want %d", deleted, rest, total, writers*n)
+	}
+}
+
+func BenchmarkCounterMapInc(b *testing.B) {
+	c := NewCounterMap[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			c.Inc(i % 128)
+			i++
+		}
+	})
+}
+
+func BenchmarkCounterMapIncHotKey(b *testing.B) {
+	c := NewCounterMap[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			c.Inc(0)
+		}
+	})
+}
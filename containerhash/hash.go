@@ -0,0 +1,146 @@
+// Package containerhash implements structural hashing and equality over
+// iterators, so that the contents of any container that can produce an
+// iter.Seq or iter.Seq2, such as a list, a deque, a set, or a map, can be
+// fingerprinted or compared with another container of a different kind.
+//
+// Use the ordered functions for sequences whose order is part of their
+// meaning, such as lists, deques, and sorted maps, and the Unordered
+// functions for sets and hash maps, whose iteration order is arbitrary.
+//
+// Hashes are not cryptographic, and are only meaningful within a process
+// unless hashElem is stable across processes.
+package containerhash
+
+import (
+	"hash/maphash"
+	"iter"
+)
+
+// mix is the splitmix64 finalizer. It spreads every input bit across the
+// output so that sums and sequences of element hashes do not cancel.
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+// Hash returns a hash of the elements of seq, in order, given a hash for
+// each element. Sequences with equal elements in the same order hash
+// equally; reordering the elements changes the hash.
+func Hash[T any](seq iter.Seq[T], hashElem func(T) uint64) uint64 {
+	var h, n uint64
+	for v := range seq {
+		h = mix(h ^ mix(hashElem(v)))
+		n++
+	}
+	return mix(h ^ n)
+}
+
+// HashUnordered returns a hash of the elements of seq that does not
+// depend on their order. Each element's hash contributes once per
+// occurrence, so sequences that are equal as multisets hash equally.
+func HashUnordered[T any](seq iter.Seq[T], hashElem func(T) uint64) uint64 {
+	var sum, n uint64
+	for v := range seq {
+		sum += mix(hashElem(v))
+		n++
+	}
+	return mix(sum ^ mix(n))
+}
+
+// Hash2 is like Hash for a sequence of pairs, such as the entries of a
+// sorted or insertion-ordered map.
+func Hash2[K, V any](seq iter.Seq2[K, V], hashPair func(K, V) uint64) uint64 {
+	return Hash(pairs(seq), func(p pair[K, V]) uint64 { return hashPair(p.k, p.v) })
+}
+
+// HashUnordered2 is like HashUnordered for a sequence of pairs, such as
+// the entries of a hash map.
+func HashUnordered2[K, V any](seq iter.Seq2[K, V], hashPair func(K, V) uint64) uint64 {
+	return HashUnordered(pairs(seq), func(p pair[K, V]) uint64 { return hashPair(p.k, p.v) })
+}
+
+// Comparable returns an element hash for comparable values, computed with
+// maphash.Comparable and seed. Hashes made with different seeds are
+// unrelated, so use a single seed for values that are to be compared.
+func Comparable[T comparable](seed maphash.Seed) func(T) uint64 {
+	return func(v T) uint64 { return maphash.Comparable(seed, v) }
+}
+
+type pair[K, V any] struct {
+	k K
+	v V
+}
+
+func pairs[K, V any](seq iter.Seq2[K, V]) iter.Seq[pair[K, V]] {
+	return func(yield func(pair[K, V]) bool) {
+		for k, v := range seq {
+			if !yield(pair[K, V]{k, v}) {
+				return
+			}
+		}
+	}
+}
+
+// Equal reports whether a and b yield the same number of elements and eq
+// reports true for each pair of elements at the same position. It stops
+// reading both sequences at the first difference.
+func Equal[T any](a, b iter.Seq[T], eq func(T, T) bool) bool {
+	nextB, stopB := iter.Pull(b)
+	defer stopB()
+	for va := range a {
+		vb, ok := nextB()
+		if !ok || !eq(va, vb) {
+			return false
+		}
+	}
+	_, ok := nextB()
+	return !ok
+}
+
+// Equal2 is like Equal for sequences of pairs.
+func Equal2[K, V any](a, b iter.Seq2[K, V], eq func(K, V, K, V) bool) bool {
+	return Equal(pairs(a), pairs(b), func(x, y pair[K, V]) bool { return eq(x.k, x.v, y.k, y.v) })
+}
+
+// EqualUnordered reports whether a and b yield the same elements the same
+// number of times, in any order.
+func EqualUnordered[T comparable](a, b iter.Seq[T]) bool {
+	counts := make(map[T]int)
+	for v := range a {
+		counts[v]++
+	}
+	for v := range b {
+		c := counts[v]
+		if c == 0 {
+			return false
+		}
+		if c == 1 {
+			delete(counts, v)
+		} else {
+			counts[v] = c - 1
+		}
+	}
+	return len(counts) == 0
+}
+
+// EqualUnordered2 reports whether a and b yield the same set of keys with
+// equal values, in any order, as for two hash maps. Each sequence must
+// yield each key at most once.
+func EqualUnordered2[K, V comparable](a, b iter.Seq2[K, V]) bool {
+	m := make(map[K]V)
+	for k, v := range a {
+		m[k] = v
+	}
+	n := 0
+	for k, v := range b {
+		if w, ok := m[k]; !ok || w != v {
+			return false
+		}
+		n++
+	}
+	return n == len(m)
+}
@@ -0,0 +1,125 @@
+package containerhash
+
+import (
+	"hash/maphash"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/nishanths/typedcontainer/deque"
+	"github.com/nishanths/typedcontainer/list"
+	"github.com/nishanths/typedcontainer/orderedmap"
+	"github.com/nishanths/typedcontainer/psortedmap"
+	"github.com/nishanths/typedcontainer/set"
+)
+
+func TestHash(t *testing.T) {
+	h := Comparable[int](maphash.MakeSeed())
+	l := list.FromSlice([]int{1, 2, 3})
+	d := deque.New[int]()
+	for _, v := range []int{1, 2, 3} {
+		d.PushBack(v)
+	}
+	if Hash(l.All(), h) != Hash(d.All(), h) {
+		t.Errorf("Hash differs for list and deque with equal elements")
+	}
+	v, _ := d.PopBack()
+	d.PushFront(v)
+	if Hash(l.All(), h) == Hash(d.All(), h) {
+		t.Errorf("Hash equal after reordering")
+	}
+	if HashUnordered(l.All(), h) != HashUnordered(d.All(), h) {
+		t.Errorf("HashUnordered differs after reordering")
+	}
+	if Hash(slices.Values([]int{0}), h) == Hash(slices.Values([]int{0, 0}), h) {
+		t.Errorf("Hash ignores length")
+	}
+	if HashUnordered(slices.Values([]int{1, 1, 2}), h) == HashUnordered(slices.Values([]int{1, 2, 2}), h) {
+		t.Errorf("HashUnordered ignores multiplicity")
+	}
+	if Hash(slices.Values([]int(nil)), h) == Hash(slices.Values([]int{0}), h) {
+		t.Errorf("Hash of empty equals Hash of one element")
+	}
+}
+
+func TestHash2(t *testing.T) {
+	seed := maphash.MakeSeed()
+	hp := func(k string, v int) uint64 {
+		return maphash.Comparable(seed, k) ^ maphash.Comparable(seed, v)*31
+	}
+	om := orderedmap.New[string, int]()
+	sm := psortedmap.New[string, int]()
+	m := make(map[string]int)
+	for i, k := range []string{"a", "b", "c"} {
+		om.Set(k, i)
+		sm = sm.Put(k, i)
+		m[k] = i
+	}
+	if Hash2(om.All(), hp) != Hash2(sm.All(), hp) {
+		t.Errorf("Hash2 differs for orderedmap and psortedmap with equal entries")
+	}
+	if HashUnordered2(maps.All(m), hp) != HashUnordered2(om.All(), hp) {
+		t.Errorf("HashUnordered2 differs for map and orderedmap with equal entries")
+	}
+	om.MoveToFront("c")
+	if Hash2(om.All(), hp) == Hash2(sm.All(), hp) {
+		t.Errorf("Hash2 equal after reordering")
+	}
+	m["a"] = 9
+	if HashUnordered2(maps.All(m), hp) == HashUnordered2(sm.All(), hp) {
+		t.Errorf("HashUnordered2 equal after changing a value")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+	l := list.FromSlice([]int{1, 2, 3})
+	tests := []struct {
+		b         []int
+		ordered   bool
+		unordered bool
+	}{
+		{[]int{1, 2, 3}, true, true},
+		{[]int{3, 1, 2}, false, true},
+		{[]int{1, 2}, false, false},
+		{[]int{1, 2, 3, 4}, false, false},
+		{[]int{1, 2, 2}, false, false},
+		{nil, false, false},
+	}
+	for _, tt := range tests {
+		if got := Equal(l.All(), slices.Values(tt.b), eq); got != tt.ordered {
+			t.Errorf("Equal(%v, %v) = %v, want %v", l.ToSlice(), tt.b, got, tt.ordered)
+		}
+		if got := EqualUnordered(l.All(), slices.Values(tt.b)); got != tt.unordered {
+			t.Errorf("EqualUnordered(%v, %v) = %v, want %v", l.ToSlice(), tt.b, got, tt.unordered)
+		}
+	}
+	s := set.New(3, 2, 1)
+	if !EqualUnordered(l.All(), s.All()) {
+		t.Errorf("EqualUnordered(list, set) = false, want true")
+	}
+}
+
+func TestEqual2(t *testing.T) {
+	om := orderedmap.New[string, int]()
+	sm := psortedmap.New[string, int]()
+	for i, k := range []string{"a", "b", "c"} {
+		om.Set(k, i)
+		sm = sm.Put(k, i)
+	}
+	eq := func(k1 string, v1 int, k2 string, v2 int) bool { return k1 == k2 && v1 == v2 }
+	if !Equal2(om.All(), sm.All(), eq) {
+		t.Errorf("Equal2(orderedmap, psortedmap) = false, want true")
+	}
+	om.MoveToBack("a")
+	if Equal2(om.All(), sm.All(), eq) {
+		t.Errorf("Equal2 after reordering = true, want false")
+	}
+	if !EqualUnordered2(om.All(), sm.All()) {
+		t.Errorf("EqualUnordered2 after reordering = false, want true")
+	}
+	om.Delete("b")
+	if EqualUnordered2(om.All(), sm.All()) || EqualUnordered2(sm.All(), om.All()) {
+		t.Errorf("EqualUnordered2 with a missing key = true, want false")
+	}
+}
@@ -0,0 +1,147 @@
+// Package dedupqueue implements a FIFO queue that coalesces entries with
+// the same key.
+package dedupqueue
+
+import "github.com/nishanths/typedcontainer/list"
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Queue is a FIFO queue of key-value entries in which each key appears at
+// most once. Pushing a key that is already queued updates its value in
+// place instead of adding a second entry, so bursts of events for the same
+// key are coalesced into one. All operations are O(1).
+//
+// A Queue may be bounded, in which case pushing a new key onto a full
+// queue evicts the oldest entry.
+//
+// The zero value for Queue is an empty, unbounded queue ready to use.
+type Queue[K comparable, V any] struct {
+	m       map[K]*list.Element[entry[K, V]]
+	l       list.List[entry[K, V]]
+	cap     int // 0 means unbounded
+	onEvict func(K, V)
+}
+
+// New returns an empty, unbounded Queue.
+func New[K comparable, V any]() *Queue[K, V] {
+	return &Queue[K, V]{m: make(map[K]*list.Element[entry[K, V]])}
+}
+
+// NewBounded returns an empty Queue that holds at most capacity entries.
+// If onEvict is not nil, it is called with each entry evicted to make
+// room. NewBounded panics if capacity is not positive.
+func NewBounded[K comparable, V any](capacity int, onEvict func(K, V)) *Queue[K, V] {
+	if capacity <= 0 {
+		panic("dedupqueue: NewBounded with non-positive capacity")
+	}
+	q := New[K, V]()
+	q.cap = capacity
+	q.onEvict = onEvict
+	return q
+}
+
+// Len returns the number of entries in q.
+func (q *Queue[K, V]) Len() int {
+	return len(q.m)
+}
+
+// Contains reports whether k is queued.
+func (q *Queue[K, V]) Contains(k K) bool {
+	_, ok := q.m[k]
+	return ok
+}
+
+// Get returns the value queued for k. The boolean is false if k is not
+// queued.
+func (q *Queue[K, V]) Get(k K) (V, bool) {
+	e, ok := q.m[k]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.value, true
+}
+
+// GetOr returns the value for k, or def if k is not queued.
+func (q *Queue[K, V]) GetOr(k K, def V) V {
+	if v, ok := q.Get(k); ok {
+		return v
+	}
+	return def
+}
+
+// MustGet returns the value for k. It panics if k is not queued.
+func (q *Queue[K, V]) MustGet(k K) V {
+	v, ok := q.Get(k)
+	if !ok {
+		panic("dedupqueue: MustGet of missing key")
+	}
+	return v
+}
+
+// Push enqueues v for k. If k is already queued, its value is replaced and
+// it keeps its position. Push reports whether k was newly enqueued.
+func (q *Queue[K, V]) Push(k K, v V) bool {
+	return q.PushFunc(k, v, nil)
+}
+
+// PushFunc is like Push, but if k is already queued its value is replaced
+// with merge(old, v). A nil merge keeps v.
+func (q *Queue[K, V]) PushFunc(k K, v V, merge func(old, new V) V) bool {
+	if e, ok := q.m[k]; ok {
+		if merge != nil {
+			v = merge(e.Value.value, v)
+		}
+		e.Value.value = v
+		return false
+	}
+	if q.m == nil {
+		q.m = make(map[K]*list.Element[entry[K, V]])
+	}
+	if q.cap > 0 && len(q.m) >= q.cap {
+		old := q.l.Front().Value
+		q.Remove(old.key)
+		if q.onEvict != nil {
+			q.onEvict(old.key, old.value)
+		}
+	}
+	q.m[k] = q.l.PushBack(entry[K, V]{k, v})
+	return true
+}
+
+// Peek returns the oldest entry without removing it. The boolean is false
+// if q is empty.
+func (q *Queue[K, V]) Peek() (K, V, bool) {
+	e := q.l.Front()
+	if e == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return e.Value.key, e.Value.value, true
+}
+
+// Pop removes and returns the oldest entry. The boolean is false if q is
+// empty.
+func (q *Queue[K, V]) Pop() (K, V, bool) {
+	k, v, ok := q.Peek()
+	if ok {
+		q.Remove(k)
+	}
+	return k, v, ok
+}
+
+// Remove removes the entry for k, returning its value. The boolean is
+// false if k was not queued.
+func (q *Queue[K, V]) Remove(k K) (V, bool) {
+	e, ok := q.m[k]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	delete(q.m, k)
+	return q.l.Remove(e).value, true
+}
@@ -0,0 +1,110 @@
+package dedupqueue
+
+import (
+	"slices"
+	"testing"
+)
+
+func drain[K comparable, V any](q *Queue[K, V]) (keys []K, values []V) {
+	for q.Len() > 0 {
+		k, v, _ := q.Pop()
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	return keys, values
+}
+
+func TestQueue(t *testing.T) {
+	q := New[string, int]()
+	if _, _, ok := q.Pop(); ok {
+		t.Errorf("Pop on empty queue reported true")
+	}
+
+	if !q.Push("a", 1) {
+		t.Errorf("Push(a) = false, want true")
+	}
+	q.Push("b", 2)
+	if q.Push("a", 3) {
+		t.Errorf("Push(a) of queued key = true, want false")
+	}
+	q.Push("c", 4)
+	if q.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", q.Len())
+	}
+	if v, ok := q.Get("a"); v != 3 || !ok {
+		t.Errorf("Get(a) = %d, %v; want 3, true", v, ok)
+	}
+	if k, v, _ := q.Peek(); k != "a" || v != 3 {
+		t.Errorf("Peek() = %q, %d; want a, 3", k, v)
+	}
+
+	if v, ok := q.Remove("b"); v != 2 || !ok {
+		t.Errorf("Remove(b) = %d, %v; want 2, true", v, ok)
+	}
+	if q.Contains("b") {
+		t.Errorf("Contains(b) after Remove = true")
+	}
+
+	keys, values := drain(q)
+	if !slices.Equal(keys, []string{"a", "c"}) || !slices.Equal(values, []int{3, 4}) {
+		t.Errorf("drained %v %v, want [a c] [3 4]", keys, values)
+	}
+
+	// A popped key can be enqueued again.
+	if !q.Push("a", 5) {
+		t.Errorf("Push(a) after Pop = false, want true")
+	}
+}
+
+func TestQueuePushFunc(t *testing.T) {
+	var q Queue[string, []int]
+	add := func(old, new []int) []int { return append(old, new...) }
+	q.PushFunc("x", []int{1}, add)
+	q.PushFunc("y", []int{2}, add)
+	q.PushFunc("x", []int{3}, add)
+	keys, values := drain(&q)
+	if !slices.Equal(keys, []string{"x", "y"}) {
+		t.Errorf("keys = %v, want [x y]", keys)
+	}
+	if !slices.Equal(values[0], []int{1, 3}) {
+		t.Errorf("coalesced value = %v, want [1 3]", values[0])
+	}
+}
+
+func TestQueueBounded(t *testing.T) {
+	var evicted []string
+	q := NewBounded(2, func(k string, _ int) { evicted = append(evicted, k) })
+	q.Push("a", 1)
+	q.Push("b", 2)
+	q.Push("a", 3) // coalesces; no eviction
+	if len(evicted) != 0 {
+		t.Errorf("evicted %v on coalesce", evicted)
+	}
+	q.Push("c", 4)
+	if !slices.Equal(evicted, []string{"a"}) {
+		t.Errorf("evicted = %v, want [a]", evicted)
+	}
+	if keys, _ := drain(q); !slices.Equal(keys, []string{"b", "c"}) {
+		t.Errorf("keys = %v, want [b c]", keys)
+	}
+}
+
+func TestQueueGetOr(t *testing.T) {
+	q := New[string, int]()
+	q.Push("a", 1)
+	if v := q.GetOr("a", -1); v != 1 {
+		t.Errorf("GetOr(a) = %d, want 1", v)
+	}
+	if v := q.GetOr("b", -1); v != -1 {
+		t.Errorf("GetOr(b) = %d, want -1", v)
+	}
+	if v := q.MustGet("a"); v != 1 {
+		t.Errorf("MustGet(a) = %d, want 1", v)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustGet(b) did not panic")
+		}
+	}()
+	q.MustGet("b")
+}
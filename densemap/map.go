@@ -0,0 +1,97 @@
+// Package densemap implements a compact map from small integer keys to
+// small unsigned values, stored bit-packed.
+package densemap
+
+import "iter"
+
+// Unsigned is the set of value types a Map can hold.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Map maps the integer keys [0, n) to values that fit in a fixed number of
+// bits, packing the values contiguously so that a Map with b bits per value
+// uses about n*b/8 bytes. Keys that were never set hold zero. It is suited
+// to large, memory-critical lookup tables such as character property maps.
+//
+// Use New to create a Map.
+type Map[V Unsigned] struct {
+	words []uint64
+	n     int
+	bits  uint
+	mask  uint64
+}
+
+// New returns a Map for keys in [0, n) whose values each occupy bits bits.
+// It panics if n is negative or bits is not in [1, 64].
+func New[V Unsigned](n, bits int) *Map[V] {
+	if n < 0 {
+		panic("densemap: New with negative size")
+	}
+	if bits < 1 || bits > 64 {
+		panic("densemap: bits per value must be in [1, 64]")
+	}
+	m := &Map[V]{n: n, bits: uint(bits), mask: 1<<bits - 1}
+	if bits == 64 {
+		m.mask = ^uint64(0)
+	}
+	m.words = make([]uint64, (n*bits+63)/64)
+	return m
+}
+
+// Len returns the number of keys in m.
+func (m *Map[V]) Len() int {
+	return m.n
+}
+
+// Bits returns the number of bits used for each value.
+func (m *Map[V]) Bits() int {
+	return int(m.bits)
+}
+
+// Get returns the value for key k. It panics if k is not in [0, m.Len()).
+func (m *Map[V]) Get(k int) V {
+	m.checkKey(k)
+	pos := uint(k) * m.bits
+	w, off := pos/64, pos%64
+	v := m.words[w] >> off
+	if off+m.bits > 64 {
+		v |= m.words[w+1] << (64 - off)
+	}
+	return V(v & m.mask)
+}
+
+// Set sets the value for key k to v. It panics if k is not in
+// [0, m.Len()) or if v does not fit in m.Bits() bits.
+func (m *Map[V]) Set(k int, v V) {
+	m.checkKey(k)
+	x := uint64(v)
+	if x&^m.mask != 0 {
+		panic("densemap: value does not fit in bits per value")
+	}
+	pos := uint(k) * m.bits
+	w, off := pos/64, pos%64
+	m.words[w] = m.words[w]&^(m.mask<<off) | x<<off
+	if off+m.bits > 64 {
+		hi := 64 - off // bits already stored in words[w]
+		m.words[w+1] = m.words[w+1]&^(m.mask>>hi) | x>>hi
+	}
+}
+
+func (m *Map[V]) checkKey(k int) {
+	if k < 0 || k >= m.n {
+		panic("densemap: key out of range")
+	}
+}
+
+// All returns an iterator over every key of m and its value, in
+// increasing key order.
+func (m *Map[V]) All() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		for k := 0; k < m.n; k++ {
+			if !yield(k, m.Get(k)) {
+				return
+			}
+		}
+	}
+}
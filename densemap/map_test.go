@@ -0,0 +1,72 @@
+package densemap
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	for _, bits := range []int{1, 3, 7, 8, 13, 31, 63, 64} {
+		const n = 300
+		m := New[uint64](n, bits)
+		if m.Len() != n || m.Bits() != bits {
+			t.Fatalf("Len, Bits = %d, %d; want %d, %d", m.Len(), m.Bits(), n, bits)
+		}
+		if want := (n*bits + 63) / 64; len(m.words) != want {
+			t.Errorf("bits=%d: %d words, want %d", bits, len(m.words), want)
+		}
+
+		r := rand.New(rand.NewSource(int64(bits)))
+		ref := make([]uint64, n)
+		for i := 0; i < 3*n; i++ {
+			k := r.Intn(n)
+			v := r.Uint64()
+			if bits < 64 {
+				v &= 1<<bits - 1
+			}
+			m.Set(k, v)
+			ref[k] = v
+		}
+		for k, v := range m.All() {
+			if v != ref[k] {
+				t.Fatalf("bits=%d: Get(%d) = %d, want %d", bits, k, v, ref[k])
+			}
+		}
+	}
+}
+
+func TestMapPanics(t *testing.T) {
+	m := New[uint8](10, 3)
+	for name, f := range map[string]func(){
+		"Get(-1)":    func() { m.Get(-1) },
+		"Get(10)":    func() { m.Get(10) },
+		"Set(10, 0)": func() { m.Set(10, 0) },
+		"Set(0, 8)":  func() { m.Set(0, 8) },
+		"New(1, 0)":  func() { New[uint8](1, 0) },
+		"New(1, 65)": func() { New[uint8](1, 65) },
+		"New(-1, 8)": func() { New[uint8](-1, 8) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+	m.Set(9, 7)
+	if v := m.Get(9); v != 7 {
+		t.Errorf("Get(9) = %d, want 7", v)
+	}
+}
+
+type property uint8
+
+func TestMapNamedType(t *testing.T) {
+	m := New[property](4, 2)
+	m.Set(1, 3)
+	if m.Get(1) != property(3) || m.Get(0) != 0 {
+		t.Errorf("Get = %d, %d; want 3, 0", m.Get(1), m.Get(0))
+	}
+}
@@ -0,0 +1,236 @@
+// Package deque implements a double-ended queue backed by a ring buffer.
+package deque
+
+import "iter"
+
+const minCap = 8
+
+// Deque is a double-ended queue stored in a contiguous, growable ring
+// buffer. Pushes and pops at either end are amortized O(1) and At is O(1).
+// Compared with list.List, a Deque allocates far less and has better
+// locality, but does not support O(1) insertion or removal in the middle.
+//
+// The zero value for Deque is an empty deque ready to use.
+type Deque[T any] struct {
+	buf  []T // len(buf) is zero or a power of two
+	head int // index of the front element
+	n    int
+}
+
+// New returns an empty Deque.
+func New[T any]() *Deque[T] {
+	return new(Deque[T])
+}
+
+// Len returns the number of elements in d.
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// resize moves the elements of d into a buffer of capacity c.
+func (d *Deque[T]) resize(c int) {
+	buf := make([]T, c)
+	if d.n > 0 {
+		if d.head+d.n <= len(d.buf) {
+			copy(buf, d.buf[d.head:d.head+d.n])
+		} else {
+			k := copy(buf, d.buf[d.head:])
+			copy(buf[k:], d.buf[:d.n-k])
+		}
+	}
+	d.buf = buf
+	d.head = 0
+}
+
+func (d *Deque[T]) grow() {
+	if d.n == len(d.buf) {
+		d.resize(max(minCap, 2*len(d.buf)))
+	}
+}
+
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > minCap && d.n <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// PushBack inserts v at the back of d.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.index(d.n)] = v
+	d.n++
+}
+
+// PushFront inserts v at the front of d.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.n++
+}
+
+// Front returns the first element of d. The boolean is false if d is
+// empty.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the last element of d. The boolean is false if d is empty.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(d.n-1)], true
+}
+
+// PopFront removes and returns the first element of d. The boolean is
+// false if d is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	var zero T
+	v := d.buf[d.head]
+	d.buf[d.head] = zero // drop the reference for the GC
+	d.head = d.index(1)
+	d.n--
+	d.shrink()
+	return v, true
+}
+
+// PopBack removes and returns the last element of d. The boolean is false
+// if d is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	if d.n == 0 {
+		var zero T
+		return zero, false
+	}
+	var zero T
+	i := d.index(d.n - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.n--
+	d.shrink()
+	return v, true
+}
+
+// At returns the element at index i, where index 0 is the front. It panics
+// if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.n {
+		panic("deque: index out of range")
+	}
+	return d.buf[d.index(i)]
+}
+
+// Set replaces the element at index i with v. It panics if i is out of
+// range.
+func (d *Deque[T]) Set(i int, v T) {
+	if i < 0 || i >= d.n {
+		panic("deque: index out of range")
+	}
+	d.buf[d.index(i)] = v
+}
+
+// TryAt is like At but reports an out-of-range index by returning false
+// instead of panicking.
+func (d *Deque[T]) TryAt(i int) (T, bool) {
+	if i < 0 || i >= d.n {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(i)], true
+}
+
+// TrySet is like Set but reports an out-of-range index by returning false
+// instead of panicking.
+func (d *Deque[T]) TrySet(i int, v T) bool {
+	if i < 0 || i >= d.n {
+		return false
+	}
+	d.buf[d.index(i)] = v
+	return true
+}
+
+// Clear removes all elements from d.
+func (d *Deque[T]) Clear() {
+	*d = Deque[T]{}
+}
+
+// All returns an iterator over the elements of d, front to back.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.n; i++ {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// popFrontInto moves up to len(dst) elements from the front of d into dst
+// and returns how many it moved.
+func (d *Deque[T]) popFrontInto(dst []T) int {
+	k := min(len(dst), d.n)
+	if k == 0 {
+		return 0
+	}
+	end := d.head + k
+	if end <= len(d.buf) {
+		copy(dst, d.buf[d.head:end])
+		clear(d.buf[d.head:end])
+	} else {
+		m := copy(dst, d.buf[d.head:])
+		clear(d.buf[d.head:])
+		copy(dst[m:k], d.buf[:k-m])
+		clear(d.buf[:k-m])
+	}
+	d.head = d.index(k)
+	d.n -= k
+	d.shrink()
+	return k
+}
+
+// PopFrontN removes and returns up to n elements from the front of d, in
+// order. It returns nil if d is empty or n is not positive.
+func (d *Deque[T]) PopFrontN(n int) []T {
+	n = min(n, d.n)
+	if n <= 0 {
+		return nil
+	}
+	out := make([]T, n)
+	d.popFrontInto(out)
+	return out
+}
+
+// DrainTo moves elements from the front of d into dst, in order, until
+// dst is full, limit elements have been moved, or d is empty, and returns
+// how many were moved. A negative limit means no limit other than
+// len(dst).
+func (d *Deque[T]) DrainTo(dst []T, limit int) int {
+	if limit >= 0 && limit < len(dst) {
+		dst = dst[:limit]
+	}
+	return d.popFrontInto(dst)
+}
+
+// DrainFunc removes elements from the front of d and calls f with each,
+// stopping when d is empty or after f returns false.
+func (d *Deque[T]) DrainFunc(f func(T) bool) {
+	for {
+		v, ok := d.PopFront()
+		if !ok || !f(v) {
+			return
+		}
+	}
+}
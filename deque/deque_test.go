@@ -0,0 +1,151 @@
+package deque
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func checkDeque(t *testing.T, d *Deque[int], want []int) {
+	t.Helper()
+	if d.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", d.Len(), len(want))
+	}
+	if got := slices.Collect(d.All()); !slices.Equal(got, want) {
+		t.Errorf("contents = %v, want %v", got, want)
+	}
+	for i, v := range want {
+		if got := d.At(i); got != v {
+			t.Errorf("At(%d) = %d, want %d", i, got, v)
+		}
+	}
+}
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+	if _, ok := d.PopFront(); ok {
+		t.Errorf("PopFront on empty deque reported true")
+	}
+	if _, ok := d.Back(); ok {
+		t.Errorf("Back on empty deque reported true")
+	}
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+	checkDeque(t, &d, []int{0, 1, 2, 3})
+	if v, _ := d.Front(); v != 0 {
+		t.Errorf("Front() = %d, want 0", v)
+	}
+	if v, _ := d.PopBack(); v != 3 {
+		t.Errorf("PopBack() = %d, want 3", v)
+	}
+	d.Set(1, 10)
+	checkDeque(t, &d, []int{0, 10, 2})
+	d.Clear()
+	checkDeque(t, &d, nil)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("At out of range did not panic")
+		}
+	}()
+	d.At(0)
+}
+
+func TestDequeTryAt(t *testing.T) {
+	var d Deque[int]
+	if _, ok := d.TryAt(0); ok {
+		t.Errorf("TryAt(0) on empty deque reported true")
+	}
+	for i := range 10 {
+		d.PushFront(i) // wraps around the buffer
+	}
+	for _, i := range []int{-1, 10} {
+		if _, ok := d.TryAt(i); ok {
+			t.Errorf("TryAt(%d) reported true", i)
+		}
+		if d.TrySet(i, 0) {
+			t.Errorf("TrySet(%d) reported true", i)
+		}
+	}
+	if !d.TrySet(9, 100) {
+		t.Errorf("TrySet(9) reported false")
+	}
+	if v, ok := d.TryAt(9); !ok || v != 100 {
+		t.Errorf("TryAt(9) = %d, %v; want 100, true", v, ok)
+	}
+	checkDeque(t, &d, []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 100})
+}
+
+func TestDequeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	d := New[int]()
+	var ref []int
+	for i := 0; i < 5000; i++ {
+		op := r.Intn(4)
+		if i < 2500 && op >= 2 && r.Intn(2) == 0 {
+			op -= 2 // grow for the first half, then shrink
+		} else if i >= 2500 && op < 2 && r.Intn(2) == 0 {
+			op += 2
+		}
+		switch op {
+		case 0:
+			d.PushBack(i)
+			ref = append(ref, i)
+		case 1:
+			d.PushFront(i)
+			ref = slices.Insert(ref, 0, i)
+		case 2:
+			v, ok := d.PopFront()
+			if ok != (len(ref) > 0) || ok && v != ref[0] {
+				t.Fatalf("PopFront() = %d, %v; ref %v", v, ok, ref)
+			}
+			if ok {
+				ref = ref[1:]
+			}
+		case 3:
+			v, ok := d.PopBack()
+			if ok != (len(ref) > 0) || ok && v != ref[len(ref)-1] {
+				t.Fatalf("PopBack() = %d, %v; ref %v", v, ok, ref)
+			}
+			if ok {
+				ref = ref[:len(ref)-1]
+			}
+		}
+	}
+	checkDeque(t, d, ref)
+}
+
+func TestDequeDrain(t *testing.T) {
+	d := New[int]()
+	for i := 0; i < 16; i++ {
+		d.PushBack(i)
+	}
+	for i := 0; i < 6; i++ {
+		d.PopFront()
+		d.PushBack(16 + i) // wrap around the ring buffer
+	}
+	if got := d.PopFrontN(3); !slices.Equal(got, []int{6, 7, 8}) {
+		t.Errorf("PopFrontN(3) = %v, want [6 7 8]", got)
+	}
+	buf := make([]int, 20)
+	if n := d.DrainTo(buf, 10); n != 10 || !slices.Equal(buf[:n], []int{9, 10, 11, 12, 13, 14, 15, 16, 17, 18}) {
+		t.Errorf("DrainTo(buf, 10) = %d, %v", n, buf[:n])
+	}
+	var seen []int
+	d.DrainFunc(func(v int) bool {
+		seen = append(seen, v)
+		return v < 20
+	})
+	if !slices.Equal(seen, []int{19, 20}) {
+		t.Errorf("DrainFunc saw %v, want [19 20]", seen)
+	}
+	checkDeque(t, d, []int{21})
+	if n := d.DrainTo(buf, -1); n != 1 || buf[0] != 21 {
+		t.Errorf("DrainTo(buf, -1) = %d, %d; want 1, 21", n, buf[0])
+	}
+	if got := d.PopFrontN(5); got != nil {
+		t.Errorf("PopFrontN on empty deque = %v, want nil", got)
+	}
+}
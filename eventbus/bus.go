@@ -0,0 +1,299 @@
+// Package eventbus implements a small in-process publish-subscribe bus.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/nishanths/typedcontainer/deque"
+	"github.com/nishanths/typedcontainer/list"
+)
+
+// ErrClosed is returned by Publish after Close, and by Recv once a closed
+// or unsubscribed subscription has no more events.
+var ErrClosed = errors.New("eventbus: closed")
+
+// Policy determines what Publish does when a subscription's queue is full.
+type Policy int
+
+const (
+	// Block makes Publish wait until the subscriber makes room.
+	Block Policy = iota
+	// DropOldest discards the subscriber's oldest queued event.
+	DropOldest
+	// DropNewest discards the event being published, for that subscriber
+	// only.
+	DropNewest
+)
+
+// Bus delivers events published to a topic to every subscription on that
+// topic. Each subscription has its own bounded queue and Policy, so a slow
+// subscriber affects other subscribers only if its Policy is Block.
+//
+// A Bus is safe for concurrent use by multiple goroutines.
+type Bus[T any] struct {
+	mu     sync.Mutex
+	topics map[string]*list.List[*Subscription[T]]
+	closed bool
+}
+
+// New returns a Bus with no subscriptions.
+func New[T any]() *Bus[T] {
+	return &Bus[T]{topics: make(map[string]*list.List[*Subscription[T]])}
+}
+
+// Subscribe returns a new Subscription to topic that queues up to capacity
+// events and applies policy when its queue is full. It panics if capacity
+// is not positive. Subscribing to a closed Bus returns a closed
+// Subscription.
+func (b *Bus[T]) Subscribe(topic string, capacity int, policy Policy) *Subscription[T] {
+	if capacity <= 0 {
+		panic("eventbus: Subscribe with non-positive capacity")
+	}
+	s := &Subscription[T]{
+		bus:      b,
+		topic:    topic,
+		capacity: capacity,
+		policy:   policy,
+	}
+	s.changed.L = &s.mu
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		s.closed = true
+		return s
+	}
+	l := b.topics[topic]
+	if l == nil {
+		l = list.New[*Subscription[T]]()
+		b.topics[topic] = l
+	}
+	s.elem = l.PushBack(s)
+	return s
+}
+
+// Subscribers returns the number of subscriptions to topic.
+func (b *Bus[T]) Subscribers(topic string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if l := b.topics[topic]; l != nil {
+		return l.Len()
+	}
+	return 0
+}
+
+// Publish delivers v to every subscription to topic, in subscription
+// order, and returns the number of subscriptions that queued it. If a
+// Block subscription is full, Publish waits for it until there is room or
+// ctx is done; on ctx.Err() the event may already have been delivered to
+// earlier subscriptions.
+func (b *Bus[T]) Publish(ctx context.Context, topic string, v T) (int, error) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return 0, ErrClosed
+	}
+	var subs []*Subscription[T]
+	if l := b.topics[topic]; l != nil {
+		subs = make([]*Subscription[T], 0, l.Len())
+		for s := range l.All() {
+			subs = append(subs, s)
+		}
+	}
+	b.mu.Unlock()
+
+	n := 0
+	for _, s := range subs {
+		ok, err := s.deliver(ctx, v)
+		if err != nil {
+			return n, err
+		}
+		if ok {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// Close closes the bus and all of its subscriptions. Subscribers receive
+// the events already queued and then ErrClosed.
+func (b *Bus[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, l := range b.topics {
+		for s := range l.All() {
+			s.elem = nil
+			s.close()
+		}
+	}
+	clear(b.topics)
+}
+
+// Subscription is a bounded queue of events from one topic of a Bus. Its
+// methods may be called concurrently with the Bus's, but a single
+// Subscription should be received from by one goroutine at a time.
+type Subscription[T any] struct {
+	bus      *Bus[T]
+	topic    string
+	capacity int
+	policy   Policy
+	elem     *list.Element[*Subscription[T]] // guarded by bus.mu
+
+	mu      sync.Mutex
+	queue   deque.Deque[T]
+	dropped uint64
+	closed  bool
+	changed sync.Cond // broadcast on every state change; L is &mu
+}
+
+// Topic returns the topic s is subscribed to.
+func (s *Subscription[T]) Topic() string {
+	return s.topic
+}
+
+// notify wakes all goroutines waiting for a state change. s.mu must be
+// held.
+func (s *Subscription[T]) notify() {
+	s.changed.Broadcast()
+}
+
+// wait releases s.mu until the next state change or until ctx is done, and
+// reacquires it before returning. s.mu must be held.
+func (s *Subscription[T]) wait(ctx context.Context) error {
+	if ctx.Done() != nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Wake every waiter when ctx is done; the others go back to
+		// waiting. The callback needs s.mu, so it cannot run until this
+		// goroutine is inside Wait.
+		stop := context.AfterFunc(ctx, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.changed.Broadcast()
+		})
+		defer stop()
+	}
+	s.changed.Wait()
+	return ctx.Err()
+}
+
+// deliver queues v according to s's policy and reports whether it was
+// queued.
+func (s *Subscription[T]) deliver(ctx context.Context, v T) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for !s.closed && s.queue.Len() >= s.capacity {
+		switch s.policy {
+		case Block:
+			if err := s.wait(ctx); err != nil {
+				return false, err
+			}
+			continue
+		case DropOldest:
+			s.queue.PopFront()
+			s.dropped++
+			continue
+		}
+		s.dropped++
+		return false, nil
+	}
+	if s.closed {
+		return false, nil
+	}
+	s.queue.PushBack(v)
+	s.notify()
+	return true, nil
+}
+
+// Recv returns the next queued event, waiting until one is published or
+// ctx is done.
+func (s *Subscription[T]) Recv(ctx context.Context) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		if v, ok := s.queue.PopFront(); ok {
+			s.notify() // a blocked Publish may now have room
+			return v, nil
+		}
+		if s.closed {
+			var zero T
+			return zero, ErrClosed
+		}
+		if err := s.wait(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+// RecvBatch waits until at least one event is queued or ctx is done, and
+// then moves as many queued events into dst as fit, in order. It returns
+// the number of events moved, which is positive unless err is non-nil or
+// dst is empty. The subscription's lock is taken once per call rather
+// than once per event.
+func (s *Subscription[T]) RecvBatch(ctx context.Context, dst []T) (int, error) {
+	if len(dst) == 0 {
+		return 0, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		if n := s.queue.DrainTo(dst, -1); n > 0 {
+			s.notify() // a blocked Publish may now have room
+			return n, nil
+		}
+		if s.closed {
+			return 0, ErrClosed
+		}
+		if err := s.wait(ctx); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Len returns the number of events queued for s.
+func (s *Subscription[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.queue.Len()
+}
+
+// Dropped returns the number of events discarded for s under the
+// DropOldest and DropNewest policies.
+func (s *Subscription[T]) Dropped() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+func (s *Subscription[T]) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		s.notify()
+	}
+}
+
+// Unsubscribe removes s from its topic. Events already queued can still be
+// received; after them, Recv returns ErrClosed.
+func (s *Subscription[T]) Unsubscribe() {
+	b := s.bus
+	b.mu.Lock()
+	if s.elem != nil {
+		l := b.topics[s.topic]
+		l.Remove(s.elem)
+		if l.Len() == 0 {
+			delete(b.topics, s.topic)
+		}
+		s.elem = nil
+	}
+	b.mu.Unlock()
+	s.close()
+}
@@ -0,0 +1,197 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func recvAll[T any](t *testing.T, s *Subscription[T]) []T {
+	t.Helper()
+	var out []T
+	for s.Len() > 0 {
+		v, err := s.Recv(context.Background())
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestBusTopics(t *testing.T) {
+	ctx := context.Background()
+	b := New[string]()
+	a1 := b.Subscribe("a", 10, Block)
+	a2 := b.Subscribe("a", 10, Block)
+	other := b.Subscribe("b", 10, Block)
+	if b.Subscribers("a") != 2 || b.Subscribers("none") != 0 {
+		t.Errorf("Subscribers = %d, %d; want 2, 0", b.Subscribers("a"), b.Subscribers("none"))
+	}
+
+	if n, err := b.Publish(ctx, "a", "x"); n != 2 || err != nil {
+		t.Errorf("Publish(a) = %d, %v; want 2, nil", n, err)
+	}
+	if n, _ := b.Publish(ctx, "none", "y"); n != 0 {
+		t.Errorf("Publish to topic without subscribers = %d, want 0", n)
+	}
+	for _, s := range []*Subscription[string]{a1, a2} {
+		if v, err := s.Recv(ctx); v != "x" || err != nil {
+			t.Errorf("Recv() = %q, %v; want x, nil", v, err)
+		}
+	}
+	if other.Len() != 0 {
+		t.Errorf("subscriber to another topic received an event")
+	}
+
+	b.Publish(ctx, "a", "z")
+	a1.Unsubscribe()
+	if b.Subscribers("a") != 1 {
+		t.Errorf("Subscribers(a) after Unsubscribe = %d, want 1", b.Subscribers("a"))
+	}
+	if v, err := a1.Recv(ctx); v != "z" || err != nil {
+		t.Errorf("Recv of queued event after Unsubscribe = %q, %v", v, err)
+	}
+	if _, err := a1.Recv(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("Recv after Unsubscribe = %v, want ErrClosed", err)
+	}
+
+	b.Close()
+	if _, err := b.Publish(ctx, "a", "w"); !errors.Is(err, ErrClosed) {
+		t.Errorf("Publish after Close = %v, want ErrClosed", err)
+	}
+	if v, _ := a2.Recv(ctx); v != "z" {
+		t.Errorf("Recv after Close = %q, want queued z", v)
+	}
+	if _, err := a2.Recv(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("Recv on drained closed subscription = %v, want ErrClosed", err)
+	}
+	a2.Unsubscribe() // no-op after Close
+}
+
+func TestBusPolicies(t *testing.T) {
+	ctx := context.Background()
+	b := New[int]()
+	oldest := b.Subscribe("t", 2, DropOldest)
+	newest := b.Subscribe("t", 2, DropNewest)
+	for i := 1; i <= 4; i++ {
+		b.Publish(ctx, "t", i)
+	}
+	if got := recvAll(t, oldest); len(got) != 2 || got[0] != 3 || got[1] != 4 {
+		t.Errorf("DropOldest received %v, want [3 4]", got)
+	}
+	if got := recvAll(t, newest); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("DropNewest received %v, want [1 2]", got)
+	}
+	if oldest.Dropped() != 2 || newest.Dropped() != 2 {
+		t.Errorf("Dropped = %d, %d; want 2, 2", oldest.Dropped(), newest.Dropped())
+	}
+}
+
+func TestBusBlock(t *testing.T) {
+	ctx := context.Background()
+	b := New[int]()
+	s := b.Subscribe("t", 1, Block)
+	b.Publish(ctx, "t", 1)
+
+	tctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := b.Publish(tctx, "t", 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Publish to full Block subscriber = %v, want DeadlineExceeded", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := b.Publish(ctx, "t", 3)
+		done <- err
+	}()
+	if v, _ := s.Recv(ctx); v != 1 {
+		t.Errorf("Recv() = %d, want 1", v)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("blocked Publish = %v", err)
+	}
+	if v, _ := s.Recv(ctx); v != 3 {
+		t.Errorf("Recv() = %d, want 3", v)
+	}
+}
+
+func TestSubscriptionRecvCancel(t *testing.T) {
+	b := New[int]()
+	s := b.Subscribe("t", 1, Block)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.Recv(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Recv on empty subscription = %v, want DeadlineExceeded", err)
+	}
+	b.Publish(context.Background(), "t", 1)
+	if v, err := s.Recv(ctx); v != 1 || err != nil {
+		t.Errorf("Recv of queued event with done context = %d, %v; want 1, nil", v, err)
+	}
+}
+
+func TestSubscriptionRecvBatch(t *testing.T) {
+	ctx := context.Background()
+	b := New[int]()
+	s := b.Subscribe("t", 8, Block)
+	for i := range 5 {
+		b.Publish(ctx, "t", i)
+	}
+	dst := make([]int, 3)
+	if n, err := s.RecvBatch(ctx, dst); n != 3 || err != nil || dst[0] != 0 || dst[2] != 2 {
+		t.Errorf("RecvBatch(3) = %d, %v, %v; want 3, nil, [0 1 2]", n, err, dst)
+	}
+	if n, err := s.RecvBatch(ctx, dst); n != 2 || err != nil || dst[0] != 3 || dst[1] != 4 {
+		t.Errorf("RecvBatch = %d, %v, %v; want 2, nil, [3 4]", n, err, dst[:n])
+	}
+	if n, err := s.RecvBatch(ctx, nil); n != 0 || err != nil {
+		t.Errorf("RecvBatch(nil) = %d, %v; want 0, nil", n, err)
+	}
+
+	done := make(chan int)
+	go func() {
+		n, _ := s.RecvBatch(ctx, dst)
+		done <- n
+	}()
+	b.Publish(ctx, "t", 5)
+	if n := <-done; n != 1 || dst[0] != 5 {
+		t.Errorf("blocked RecvBatch = %d, %v; want 1, [5]", n, dst[:n])
+	}
+	tctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.RecvBatch(tctx, dst); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RecvBatch on empty subscription = %v, want DeadlineExceeded", err)
+	}
+	s.Unsubscribe()
+	if _, err := s.RecvBatch(ctx, dst); !errors.Is(err, ErrClosed) {
+		t.Errorf("RecvBatch after Unsubscribe = %v, want ErrClosed", err)
+	}
+}
+
+func BenchmarkPublish(b *testing.B) {
+	ctx := context.Background()
+	bus := New[int]()
+	var done []chan struct{}
+	for range 4 {
+		s := bus.Subscribe("t", 64, Block)
+		ch := make(chan struct{})
+		done = append(done, ch)
+		go func() {
+			defer close(ch)
+			for {
+				if _, err := s.Recv(ctx); err != nil {
+					return
+				}
+			}
+		}()
+	}
+	b.ReportAllocs()
+	for i := range b.N {
+		bus.Publish(ctx, "t", i)
+	}
+	bus.Close()
+	for _, ch := range done {
+		<-ch
+	}
+}
@@ -0,0 +1,122 @@
+// Package fairqueue implements a queue that serves producers fairly.
+package fairqueue
+
+import "github.com/nishanths/typedcontainer/list"
+
+type subqueue[K comparable, V any] struct {
+	key    K
+	items  list.List[V]
+	credit int // items left to serve in the current turn
+}
+
+// Queue holds a FIFO subqueue per key and serves the subqueues in
+// round-robin order, so that a key with many pending items cannot starve
+// the others. Each key is served up to its weight (1 by default) items per
+// turn. Push and Pop are O(1).
+//
+// The zero value for Queue is an empty queue ready to use.
+type Queue[K comparable, V any] struct {
+	subs    map[K]*subqueue[K, V] // keys with pending items
+	active  list.List[*subqueue[K, V]]
+	weights map[K]int
+	size    int
+}
+
+// New returns an empty Queue.
+func New[K comparable, V any]() *Queue[K, V] {
+	return &Queue[K, V]{subs: make(map[K]*subqueue[K, V])}
+}
+
+// Len returns the total number of items in q.
+func (q *Queue[K, V]) Len() int {
+	return q.size
+}
+
+// LenKey returns the number of items pending for k.
+func (q *Queue[K, V]) LenKey(k K) int {
+	if sq, ok := q.subs[k]; ok {
+		return sq.items.Len()
+	}
+	return 0
+}
+
+// Keys returns the number of keys with pending items.
+func (q *Queue[K, V]) Keys() int {
+	return len(q.subs)
+}
+
+// SetWeight sets the number of items served for k per round-robin turn.
+// The new weight takes effect from k's next turn. SetWeight panics if w is
+// less than 1.
+func (q *Queue[K, V]) SetWeight(k K, w int) {
+	if w < 1 {
+		panic("fairqueue: SetWeight with weight < 1")
+	}
+	if q.weights == nil {
+		q.weights = make(map[K]int)
+	}
+	if w == 1 {
+		delete(q.weights, k)
+		return
+	}
+	q.weights[k] = w
+}
+
+func (q *Queue[K, V]) weight(k K) int {
+	if w, ok := q.weights[k]; ok {
+		return w
+	}
+	return 1
+}
+
+// Push adds v to the back of the subqueue for k.
+func (q *Queue[K, V]) Push(k K, v V) {
+	sq, ok := q.subs[k]
+	if !ok {
+		if q.subs == nil {
+			q.subs = make(map[K]*subqueue[K, V])
+		}
+		sq = &subqueue[K, V]{key: k, credit: q.weight(k)}
+		q.active.PushBack(sq)
+		q.subs[k] = sq
+	}
+	sq.items.PushBack(v)
+	q.size++
+}
+
+// Peek returns the item Pop would return, without removing it. The boolean
+// is false if q is empty.
+func (q *Queue[K, V]) Peek() (K, V, bool) {
+	e := q.active.Front()
+	if e == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	sq := e.Value
+	return sq.key, sq.items.Front().Value, true
+}
+
+// Pop removes and returns the next item in round-robin order, along with
+// its key. The boolean is false if q is empty.
+func (q *Queue[K, V]) Pop() (K, V, bool) {
+	e := q.active.Front()
+	if e == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	sq := e.Value
+	v := sq.items.Remove(sq.items.Front())
+	q.size--
+	sq.credit--
+	switch {
+	case sq.items.Len() == 0:
+		q.active.Remove(e)
+		delete(q.subs, sq.key)
+	case sq.credit == 0:
+		sq.credit = q.weight(sq.key)
+		q.active.MoveToBack(e)
+	}
+	return sq.key, v, true
+}
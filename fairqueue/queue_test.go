@@ -0,0 +1,81 @@
+package fairqueue
+
+import (
+	"slices"
+	"testing"
+)
+
+func drainKeys[K comparable, V any](q *Queue[K, V]) []K {
+	var keys []K
+	for q.Len() > 0 {
+		k, _, _ := q.Pop()
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestQueueRoundRobin(t *testing.T) {
+	q := New[string, int]()
+	if _, _, ok := q.Pop(); ok {
+		t.Errorf("Pop on empty queue reported true")
+	}
+
+	for i := 0; i < 5; i++ {
+		q.Push("noisy", i)
+	}
+	q.Push("a", 100)
+	q.Push("b", 200)
+	q.Push("a", 101)
+	if q.Len() != 8 || q.Keys() != 3 || q.LenKey("noisy") != 5 {
+		t.Errorf("Len, Keys, LenKey = %d, %d, %d; want 8, 3, 5", q.Len(), q.Keys(), q.LenKey("noisy"))
+	}
+	if k, v, _ := q.Peek(); k != "noisy" || v != 0 {
+		t.Errorf("Peek() = %q, %d; want noisy, 0", k, v)
+	}
+
+	var got []int
+	for q.Len() > 0 {
+		_, v, _ := q.Pop()
+		got = append(got, v)
+	}
+	want := []int{0, 100, 200, 1, 101, 2, 3, 4}
+	if !slices.Equal(got, want) {
+		t.Errorf("pop order = %v, want %v", got, want)
+	}
+	if q.Keys() != 0 || q.LenKey("noisy") != 0 {
+		t.Errorf("drained queue still has keys")
+	}
+}
+
+func TestQueueWeights(t *testing.T) {
+	var q Queue[string, int]
+	q.SetWeight("heavy", 3)
+	for i := 0; i < 6; i++ {
+		q.Push("heavy", i)
+		q.Push("light", i)
+	}
+	got := drainKeys(&q)
+	want := []string{
+		"heavy", "heavy", "heavy", "light",
+		"heavy", "heavy", "heavy", "light",
+		"light", "light", "light", "light",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("pop order = %v, want %v", got, want)
+	}
+
+	q.SetWeight("heavy", 1)
+	q.Push("heavy", 0)
+	q.Push("heavy", 1)
+	q.Push("light", 0)
+	if got := drainKeys(&q); !slices.Equal(got, []string{"heavy", "light", "heavy"}) {
+		t.Errorf("pop order after reset = %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SetWeight(0) did not panic")
+		}
+	}()
+	q.SetWeight("x", 0)
+}
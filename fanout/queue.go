@@ -0,0 +1,266 @@
+// Package fanout implements a broadcast queue that delivers every item to
+// each of its subscribers at the subscriber's own pace.
+package fanout
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrClosed is returned by Push after Close, and by Recv once a
+	// closed queue has no more items for the subscriber.
+	ErrClosed = errors.New("fanout: queue closed")
+	// ErrDisconnected is returned by Recv after the subscriber was
+	// disconnected for falling behind, or after Unsubscribe.
+	ErrDisconnected = errors.New("fanout: subscriber disconnected")
+)
+
+// Policy determines what Push does when the slowest subscriber is a full
+// buffer behind.
+type Policy int
+
+const (
+	// Block makes Push wait until the slowest subscriber catches up.
+	Block Policy = iota
+	// DropOldest makes slow subscribers skip their oldest unread item.
+	// Skipped items are counted by Subscriber.Dropped.
+	DropOldest
+	// Disconnect removes slow subscribers; their Recv returns
+	// ErrDisconnected.
+	Disconnect
+)
+
+// Queue is a bounded broadcast queue. Items are kept in a ring buffer of
+// fixed capacity until every subscriber has received them.
+//
+// A Queue is safe for concurrent use by multiple goroutines.
+type Queue[T any] struct {
+	policy Policy
+
+	mu      sync.Mutex
+	buf     []T
+	head    uint64 // sequence number of the next item to publish
+	low     uint64 // sequence number of the oldest slot not yet cleared
+	subs    map[*Subscriber[T]]struct{}
+	closed  bool
+	changed sync.Cond // broadcast on every state change; L is &mu
+}
+
+// New returns an empty Queue that retains up to capacity items and applies
+// policy to slow subscribers. It panics if capacity is not positive.
+func New[T any](capacity int, policy Policy) *Queue[T] {
+	if capacity <= 0 {
+		panic("fanout: New with non-positive capacity")
+	}
+	q := &Queue[T]{
+		policy: policy,
+		buf:    make([]T, capacity),
+		subs:   make(map[*Subscriber[T]]struct{}),
+	}
+	q.changed.L = &q.mu
+	return q
+}
+
+// notify wakes all goroutines waiting for a state change. q.mu must be
+// held.
+func (q *Queue[T]) notify() {
+	q.changed.Broadcast()
+}
+
+// wait releases q.mu until the next state change or until ctx is done, and
+// reacquires it before returning. q.mu must be held.
+func (q *Queue[T]) wait(ctx context.Context) error {
+	if ctx.Done() != nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Wake every waiter when ctx is done; the others go back to
+		// waiting. The callback needs q.mu, so it cannot run until this
+		// goroutine is inside Wait.
+		stop := context.AfterFunc(ctx, func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			q.changed.Broadcast()
+		})
+		defer stop()
+	}
+	q.changed.Wait()
+	return ctx.Err()
+}
+
+// Subscribe returns a new Subscriber that receives every item pushed after
+// the call. A Subscriber created after Close starts disconnected.
+func (q *Queue[T]) Subscribe() *Subscriber[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	s := &Subscriber[T]{q: q, next: q.head}
+	if q.closed {
+		s.disconnected = true
+	} else {
+		q.subs[s] = struct{}{}
+	}
+	return s
+}
+
+// Len returns the number of subscribers.
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.subs)
+}
+
+// tail returns the sequence number of the oldest item some subscriber has
+// not yet received. q.mu must be held.
+func (q *Queue[T]) tail() uint64 {
+	t := q.head
+	for s := range q.subs {
+		t = min(t, s.next)
+	}
+	return t
+}
+
+// release clears the slots of items that every subscriber has received.
+// q.mu must be held.
+func (q *Queue[T]) release() {
+	var zero T
+	for t := q.tail(); q.low < t; q.low++ {
+		q.buf[q.low%uint64(len(q.buf))] = zero // drop the reference for the GC
+	}
+}
+
+// Push publishes v to all current subscribers. If the buffer is full, Push
+// applies the queue's Policy; with Block it waits until there is room or
+// ctx is done.
+func (q *Queue[T]) Push(ctx context.Context, v T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	capacity := uint64(len(q.buf))
+	for !q.closed && q.head-q.tail() >= capacity {
+		switch q.policy {
+		case Block:
+			if err := q.wait(ctx); err != nil {
+				return err
+			}
+			continue
+		case DropOldest:
+			for s := range q.subs {
+				if q.head-s.next >= capacity {
+					s.next++
+					s.dropped++
+				}
+			}
+		case Disconnect:
+			for s := range q.subs {
+				if q.head-s.next >= capacity {
+					s.disconnected = true
+					delete(q.subs, s)
+				}
+			}
+		}
+		q.release()
+	}
+	if q.closed {
+		return ErrClosed
+	}
+	if len(q.subs) == 0 {
+		q.low = q.head + 1 // no one will receive v
+	} else {
+		q.buf[q.head%capacity] = v
+	}
+	q.head++
+	q.notify()
+	return nil
+}
+
+// Close closes the queue. Subscribers receive the items already pushed and
+// then ErrClosed.
+func (q *Queue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		q.notify()
+	}
+}
+
+// A Subscriber is a cursor into a Queue. Its methods may be called
+// concurrently with the Queue's, but a single Subscriber should be used by
+// one goroutine at a time.
+type Subscriber[T any] struct {
+	q            *Queue[T]
+	next         uint64 // sequence number of the next item to receive
+	dropped      uint64
+	disconnected bool
+}
+
+// Recv returns the next item, waiting until one is pushed or ctx is done.
+func (s *Subscriber[T]) Recv(ctx context.Context) (T, error) {
+	var v [1]T
+	_, err := s.RecvBatch(ctx, v[:])
+	return v[0], err
+}
+
+// RecvBatch waits until at least one item is available or ctx is done, and
+// then copies as many of the available items into dst as fit, in order. It
+// returns the number of items copied, which is positive unless err is
+// non-nil or dst is empty. The queue's lock is taken once per call rather
+// than once per item.
+func (s *Subscriber[T]) RecvBatch(ctx context.Context, dst []T) (int, error) {
+	if len(dst) == 0 {
+		return 0, nil
+	}
+	q := s.q
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		if s.disconnected {
+			return 0, ErrDisconnected
+		}
+		if s.next < q.head {
+			capacity := uint64(len(q.buf))
+			n := int(min(uint64(len(dst)), q.head-s.next))
+			for c := 0; c < n; {
+				i := s.next % capacity
+				m := copy(dst[c:n], q.buf[i:])
+				c += m
+				s.next += uint64(m)
+			}
+			if s.next-uint64(n) == q.low {
+				q.release() // s may have been the slowest subscriber
+			}
+			q.notify() // a blocked Push may now have room
+			return n, nil
+		}
+		if q.closed {
+			return 0, ErrClosed
+		}
+		if err := q.wait(ctx); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Dropped returns the number of items s skipped under the DropOldest
+// policy.
+func (s *Subscriber[T]) Dropped() uint64 {
+	s.q.mu.Lock()
+	defer s.q.mu.Unlock()
+	return s.dropped
+}
+
+// Unsubscribe removes s from its queue, so that it no longer holds back
+// Push. Subsequent calls to Recv return ErrDisconnected.
+func (s *Subscriber[T]) Unsubscribe() {
+	q := s.q
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	s.disconnected = true
+	if _, ok := q.subs[s]; ok {
+		delete(q.subs, s)
+		q.release()
+		q.notify()
+	}
+}
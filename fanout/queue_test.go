@@ -0,0 +1,279 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func recvAll(t *testing.T, s *Subscriber[int]) []int {
+	t.Helper()
+	var out []int
+	for {
+		v, err := s.Recv(context.Background())
+		if err != nil {
+			if !errors.Is(err, ErrClosed) {
+				t.Errorf("Recv error = %v, want ErrClosed", err)
+			}
+			return out
+		}
+		out = append(out, v)
+	}
+}
+
+func TestQueueBroadcast(t *testing.T) {
+	ctx := context.Background()
+	q := New[int](4, Block)
+	a, b := q.Subscribe(), q.Subscribe()
+	if q.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", q.Len())
+	}
+
+	var wg sync.WaitGroup
+	var gotA, gotB []int
+	wg.Add(2)
+	go func() { defer wg.Done(); gotA = recvAll(t, a) }()
+	go func() { defer wg.Done(); gotB = recvAll(t, b) }()
+
+	var want []int
+	for i := 0; i < 100; i++ {
+		if err := q.Push(ctx, i); err != nil {
+			t.Fatalf("Push(%d) error: %v", i, err)
+		}
+		want = append(want, i)
+	}
+	q.Close()
+	wg.Wait()
+	if !slices.Equal(gotA, want) || !slices.Equal(gotB, want) {
+		t.Errorf("subscribers got %v and %v, want %v", gotA, gotB, want)
+	}
+	if err := q.Push(ctx, 0); !errors.Is(err, ErrClosed) {
+		t.Errorf("Push after Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestQueueBlock(t *testing.T) {
+	q := New[int](2, Block)
+	s := q.Subscribe()
+	ctx := context.Background()
+	q.Push(ctx, 1)
+	q.Push(ctx, 2)
+
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := q.Push(short, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Push on full queue = %v, want DeadlineExceeded", err)
+	}
+
+	done := make(chan error)
+	go func() { done <- q.Push(ctx, 3) }()
+	if v, _ := s.Recv(ctx); v != 1 {
+		t.Errorf("Recv() = %d, want 1", v)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("blocked Push error: %v", err)
+	}
+	q.Close()
+	if got := recvAll(t, s); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("remaining = %v, want [2 3]", got)
+	}
+}
+
+func TestQueueDropOldest(t *testing.T) {
+	ctx := context.Background()
+	q := New[int](3, DropOldest)
+	slow := q.Subscribe()
+	for i := 0; i < 10; i++ {
+		if err := q.Push(ctx, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	q.Close()
+	if got := recvAll(t, slow); !slices.Equal(got, []int{7, 8, 9}) {
+		t.Errorf("slow subscriber got %v, want [7 8 9]", got)
+	}
+	if n := slow.Dropped(); n != 7 {
+		t.Errorf("Dropped() = %d, want 7", n)
+	}
+}
+
+func TestQueueDisconnect(t *testing.T) {
+	ctx := context.Background()
+	q := New[int](2, Disconnect)
+	slow, fast := q.Subscribe(), q.Subscribe()
+	for i := 0; i < 5; i++ {
+		q.Push(ctx, i)
+		if v, err := fast.Recv(ctx); v != i || err != nil {
+			t.Fatalf("fast.Recv() = %d, %v; want %d, nil", v, err, i)
+		}
+	}
+	if _, err := slow.Recv(ctx); !errors.Is(err, ErrDisconnected) {
+		t.Errorf("slow.Recv() = %v, want ErrDisconnected", err)
+	}
+	if q.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", q.Len())
+	}
+
+	fast.Unsubscribe()
+	if _, err := fast.Recv(ctx); !errors.Is(err, ErrDisconnected) {
+		t.Errorf("Recv after Unsubscribe = %v, want ErrDisconnected", err)
+	}
+}
+
+func TestSubscriberRecvCancel(t *testing.T) {
+	q := New[int](1, Block)
+	s := q.Subscribe()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Recv(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Recv with canceled context = %v, want Canceled", err)
+	}
+}
+
+func TestSubscribeAfterClose(t *testing.T) {
+	q := New[int](2, Block)
+	q.Close()
+	s := q.Subscribe()
+	if _, err := s.Recv(context.Background()); !errors.Is(err, ErrDisconnected) {
+		t.Errorf("Recv on subscriber created after Close = %v, want ErrDisconnected", err)
+	}
+	s.Unsubscribe()
+	if _, err := s.Recv(context.Background()); !errors.Is(err, ErrDisconnected) {
+		t.Errorf("Recv after Unsubscribe = %v, want ErrDisconnected", err)
+	}
+	if q.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", q.Len())
+	}
+}
+
+func TestSubscriberRecvBatch(t *testing.T) {
+	ctx := context.Background()
+	q := New[int](4, Block)
+	s, other := q.Subscribe(), q.Subscribe()
+	for i := range 3 {
+		q.Push(ctx, i)
+	}
+	dst := make([]int, 10)
+	if n, err := s.RecvBatch(ctx, dst[:2]); n != 2 || err != nil || !slices.Equal(dst[:2], []int{0, 1}) {
+		t.Errorf("RecvBatch(2) = %d, %v, %v; want 2, nil, [0 1]", n, err, dst[:2])
+	}
+	other.Unsubscribe()
+	for i := 3; i < 6; i++ {
+		q.Push(ctx, i) // wraps around the buffer
+	}
+	if n, err := s.RecvBatch(ctx, dst); n != 4 || err != nil || !slices.Equal(dst[:4], []int{2, 3, 4, 5}) {
+		t.Errorf("RecvBatch across wrap = %d, %v, %v; want 4, nil, [2 3 4 5]", n, err, dst[:n])
+	}
+	if n, err := s.RecvBatch(ctx, nil); n != 0 || err != nil {
+		t.Errorf("RecvBatch(nil) = %d, %v; want 0, nil", n, err)
+	}
+
+	done := make(chan int)
+	go func() {
+		n, _ := s.RecvBatch(ctx, dst)
+		done <- n
+	}()
+	q.Push(ctx, 6)
+	if n := <-done; n != 1 || dst[0] != 6 {
+		t.Errorf("blocked RecvBatch = %d, %v; want 1, [6]", n, dst[:n])
+	}
+	q.Close()
+	if n, err := s.RecvBatch(ctx, dst); n != 0 || !errors.Is(err, ErrClosed) {
+		t.Errorf("RecvBatch on drained closed queue = %d, %v; want 0, ErrClosed", n, err)
+	}
+	s.Unsubscribe()
+	if _, err := s.RecvBatch(ctx, dst); !errors.Is(err, ErrDisconnected) {
+		t.Errorf("RecvBatch after Unsubscribe = %v, want ErrDisconnected", err)
+	}
+}
+
+func TestQueueReleasesSlots(t *testing.T) {
+	ctx := context.Background()
+	live := func(q *Queue[*int]) int {
+		n := 0
+		for _, p := range q.buf {
+			if p != nil {
+				n++
+			}
+		}
+		return n
+	}
+
+	q := New[*int](4, DropOldest)
+	q.Push(ctx, new(int)) // no subscribers
+	if n := live(q); n != 0 {
+		t.Errorf("%d slots live after Push without subscribers, want 0", n)
+	}
+	a, b := q.Subscribe(), q.Subscribe()
+	for range 3 {
+		q.Push(ctx, new(int))
+	}
+	for range 3 {
+		a.Recv(ctx)
+	}
+	if n := live(q); n != 3 {
+		t.Errorf("%d slots live before b has received, want 3", n)
+	}
+	b.Recv(ctx)
+	b.Recv(ctx)
+	if n := live(q); n != 1 {
+		t.Errorf("%d slots live after both have received 2 items, want 1", n)
+	}
+	for range 5 {
+		q.Push(ctx, new(int))
+		a.Recv(ctx)
+	}
+	if n := live(q); n != 4 || b.Dropped() != 2 {
+		t.Errorf("%d slots live, b.Dropped() = %d; want 4, 2", n, b.Dropped())
+	}
+	b.Unsubscribe()
+	if n := live(q); n != 0 {
+		t.Errorf("%d slots live after the slower subscriber left, want 0", n)
+	}
+}
+
+func BenchmarkQueue(b *testing.B) {
+	ctx := context.Background()
+	q := New[int](64, Block)
+	s := q.Subscribe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, err := s.Recv(ctx); err != nil {
+				return
+			}
+		}
+	}()
+	b.ReportAllocs()
+	for i := range b.N {
+		q.Push(ctx, i)
+	}
+	q.Close()
+	<-done
+}
+
+func BenchmarkQueueRecvBatch(b *testing.B) {
+	ctx := context.Background()
+	q := New[int](64, Block)
+	s := q.Subscribe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dst := make([]int, 64)
+		for {
+			if _, err := s.RecvBatch(ctx, dst); err != nil {
+				return
+			}
+		}
+	}()
+	b.ReportAllocs()
+	for i := range b.N {
+		q.Push(ctx, i)
+	}
+	q.Close()
+	<-done
+}
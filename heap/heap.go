@@ -0,0 +1,399 @@
+// Package heap implements a binary heap. It provides the operations of
+// the container/heap package in the Go standard library on a typed
+// container, without the heap.Interface boilerplate or interface boxing.
+//
+// A heap is a tree with the property that each node is the minimum-valued
+// node in its subtree, according to the heap's less function. The minimum
+// element is at index 0.
+package heap
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"unsafe"
+)
+
+// IndirectThreshold is the element size, in bytes, above which a Heap
+// created by New, NewOrdered, or NewIndexed uses indirect storage.
+const IndirectThreshold = 128
+
+// Heap is a binary min-heap stored in a slice.
+//
+// By default the heap moves its elements as it reorders them. Elements
+// larger than IndirectThreshold bytes, and all elements of a heap created
+// with NewIndirect, are instead stored once in an arena that the heap
+// indexes into, so that reordering moves small indices and each Pop or
+// Remove copies at most one element. Both layouts report the same heap
+// indices to At, Set, Fix, Remove, and the setIndex callback.
+//
+// A heap created by New, NewOrdered, or NewIndexed still passes elements
+// to its less and setIndex functions by value, copying them on every
+// comparison. NewIndirect takes functions of pointers instead, so that
+// large elements are not copied at all while the heap is reordered.
+//
+// Use New, NewOrdered, NewIndexed, or NewIndirect to create a Heap.
+type Heap[T any] struct {
+	data     []T // the elements, in heap order unless indirect
+	less     func(a, b T) bool
+	setIndex func(v T, i int) // may be nil
+
+	// Set instead of less and setIndex by NewIndirect.
+	lessPtr     func(a, b *T) bool
+	setIndexPtr func(v *T, i int) // may be nil
+
+	indirect bool
+	ord      []int // heap index -> data index, if indirect
+	pos      []int // data index -> heap index, if indirect
+}
+
+// New returns an empty Heap ordered by less.
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return NewIndexed(less, nil)
+}
+
+// NewOrdered returns an empty Heap of an ordered type, least value first.
+func NewOrdered[T cmp.Ordered]() *Heap[T] {
+	return New(cmp.Less[T])
+}
+
+// NewIndexed returns an empty Heap ordered by less that calls setIndex
+// whenever an element moves to index i, and with i == -1 when an element
+// leaves the heap. Elements, usually pointers, can record their index to
+// be used with Fix and Remove, as with container/heap.
+func NewIndexed[T any](less func(a, b T) bool, setIndex func(v T, i int)) *Heap[T] {
+	var zero T
+	return &Heap[T]{
+		less:     less,
+		setIndex: setIndex,
+		indirect: unsafe.Sizeof(zero) > IndirectThreshold,
+	}
+}
+
+// NewIndirect is like NewIndexed, but the Heap always uses indirect
+// storage regardless of the element size, and less and setIndex are
+// passed pointers to the elements in the arena rather than copies. The
+// pointers are valid only for the duration of the call. setIndex may be
+// nil.
+func NewIndirect[T any](less func(a, b *T) bool, setIndex func(v *T, i int)) *Heap[T] {
+	return &Heap[T]{lessPtr: less, setIndexPtr: setIndex, indirect: true}
+}
+
+// elem returns a pointer to the element at heap index i.
+func (h *Heap[T]) elem(i int) *T {
+	if h.indirect {
+		return &h.data[h.ord[i]]
+	}
+	return &h.data[i]
+}
+
+// Init replaces the contents of h with the elements of s and establishes
+// the heap invariants. h takes ownership of s. The complexity is O(n)
+// where n = len(s).
+func (h *Heap[T]) Init(s []T) {
+	h.data = s
+	if h.indirect {
+		h.ord = make([]int, len(s))
+		h.pos = make([]int, len(s))
+		for i := range s {
+			h.ord[i] = i
+			h.pos[i] = i
+		}
+	}
+	if h.setIndex != nil || h.setIndexPtr != nil {
+		for i := range s {
+			h.moved(i)
+		}
+	}
+	h.heapify()
+}
+
+// SetLess replaces the ordering function of h with less and re-establishes
+// the heap invariants in O(n). The setIndex callback is called for every
+// element that moves, so indices recorded by the elements stay valid. On a
+// Heap created by NewIndirect, less replaces the pointer comparison and is
+// passed copies of the elements from then on.
+func (h *Heap[T]) SetLess(less func(a, b T) bool) {
+	h.less, h.lessPtr = less, nil
+	h.heapify()
+}
+
+// Collect returns a Heap ordered by less holding the values yielded by
+// seq. The values are gathered first and the heap is built with a single
+// O(n) heapify, rather than with n pushes.
+func Collect[T any](seq iter.Seq[T], less func(a, b T) bool) *Heap[T] {
+	h := New(less)
+	h.Init(slices.Collect(seq))
+	return h
+}
+
+// CollectOrdered is like Collect for an ordered type, least value first.
+func CollectOrdered[T cmp.Ordered](seq iter.Seq[T]) *Heap[T] {
+	return Collect(seq, cmp.Less[T])
+}
+
+// Len returns the number of elements in h.
+func (h *Heap[T]) Len() int {
+	return len(h.data)
+}
+
+// Push pushes v onto h. The complexity is O(log n) where n = h.Len().
+func (h *Heap[T]) Push(v T) {
+	h.data = append(h.data, v)
+	i := len(h.data) - 1
+	if h.indirect {
+		h.ord = append(h.ord, i)
+		h.pos = append(h.pos, i)
+	}
+	h.moved(i)
+	h.up(i)
+}
+
+// Peek returns the minimum element of h without removing it. The boolean
+// is false if h is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return *h.elem(0), true
+}
+
+// Pop removes and returns the minimum element of h. The boolean is false
+// if h is empty. The complexity is O(log n) where n = h.Len().
+func (h *Heap[T]) Pop() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	n := len(h.data) - 1
+	h.swap(0, n)
+	h.down(0, n)
+	return h.removeLast(), true
+}
+
+// PopN removes and returns up to n of the least elements of h, in order,
+// or nil if none are removed. Popping every element sorts h once, in
+// O(n log n) without sifting; popping k < h.Len() elements costs one Pop
+// per element, O(k log n).
+func (h *Heap[T]) PopN(n int) []T {
+	if n < len(h.data) {
+		var out []T
+		for len(out) < n {
+			v, _ := h.Pop()
+			out = append(out, v)
+		}
+		return out
+	}
+	if len(h.data) == 0 {
+		return nil
+	}
+	var out []T
+	if h.indirect {
+		// Sort the arena indices rather than the elements, then copy each
+		// element out once.
+		slices.SortFunc(h.ord, func(i, j int) int {
+			return h.compare(&h.data[i], &h.data[j])
+		})
+		out = make([]T, len(h.data))
+		for i, s := range h.ord {
+			out[i] = h.data[s]
+		}
+		clear(h.data) // drop the references for the GC
+		h.data, h.ord, h.pos = h.data[:0], h.ord[:0], h.pos[:0]
+	} else {
+		out = h.data
+		sortLess(out, h.less)
+		h.data = nil
+	}
+	for i := range out {
+		switch {
+		case h.setIndexPtr != nil:
+			h.setIndexPtr(&out[i], -1)
+		case h.setIndex != nil:
+			h.setIndex(out[i], -1)
+		}
+	}
+	return out
+}
+
+// PopWhile removes and returns, in order, the least elements of h for as
+// long as pred reports true for them, or nil if none are removed. It
+// costs one Pop per element, O(k log n) for k elements.
+func (h *Heap[T]) PopWhile(pred func(T) bool) []T {
+	var out []T
+	for len(h.data) > 0 && pred(*h.elem(0)) {
+		v, _ := h.Pop()
+		out = append(out, v)
+	}
+	return out
+}
+
+// Remove removes and returns the element at index i from h. The
+// complexity is O(log n) where n = h.Len(). Remove panics if i is out of
+// range.
+func (h *Heap[T]) Remove(i int) T {
+	n := len(h.data) - 1
+	if n != i {
+		h.swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	return h.removeLast()
+}
+
+// Fix re-establishes the heap ordering after the element at index i has
+// changed its value. Changing the value of the element at index i and then
+// calling Fix is equivalent to, but less expensive than, calling Remove(i)
+// followed by a Push of the new value. The complexity is O(log n) where
+// n = h.Len().
+func (h *Heap[T]) Fix(i int) {
+	if !h.down(i, len(h.data)) {
+		h.up(i)
+	}
+}
+
+// Set replaces the element at index i with v and fixes its position.
+// It panics if i is out of range.
+func (h *Heap[T]) Set(i int, v T) {
+	*h.elem(i) = v
+	h.moved(i)
+	h.Fix(i)
+}
+
+// At returns the element at index i. It panics if i is out of range.
+func (h *Heap[T]) At(i int) T {
+	return *h.elem(i)
+}
+
+// removeLast removes and returns the element at the last heap index.
+func (h *Heap[T]) removeLast() T {
+	n := len(h.data) - 1
+	var v, zero T
+	if h.indirect {
+		// Fill the vacated arena slot with the last one to keep the arena
+		// dense.
+		s := h.ord[n]
+		if h.setIndexPtr != nil {
+			h.setIndexPtr(&h.data[s], -1)
+		}
+		v = h.data[s]
+		h.ord = h.ord[:n]
+		if s != n {
+			h.data[s] = h.data[n]
+			h.pos[s] = h.pos[n]
+			h.ord[h.pos[s]] = s
+		}
+		h.pos = h.pos[:n]
+	} else {
+		v = h.data[n]
+	}
+	h.data[n] = zero // drop the reference for the GC
+	h.data = h.data[:n]
+	if h.setIndex != nil {
+		h.setIndex(v, -1)
+	}
+	return v
+}
+
+// heapify establishes the heap invariants over all of h.data.
+func (h *Heap[T]) heapify() {
+	n := len(h.data)
+	for i := n/2 - 1; i >= 0; i-- {
+		h.down(i, n)
+	}
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	if h.indirect {
+		h.ord[i], h.ord[j] = h.ord[j], h.ord[i]
+		h.pos[h.ord[i]] = i
+		h.pos[h.ord[j]] = j
+	} else {
+		h.data[i], h.data[j] = h.data[j], h.data[i]
+	}
+	h.moved(i)
+	h.moved(j)
+}
+
+// moved calls the setIndex callback, if any, for the element at heap
+// index i.
+func (h *Heap[T]) moved(i int) {
+	switch {
+	case h.setIndexPtr != nil:
+		h.setIndexPtr(h.elem(i), i)
+	case h.setIndex != nil:
+		h.setIndex(*h.elem(i), i)
+	}
+}
+
+// lessAt reports whether the element at heap index i is less than the one
+// at heap index j.
+func (h *Heap[T]) lessAt(i, j int) bool {
+	return h.lessVal(h.elem(i), h.elem(j))
+}
+
+// lessVal reports whether *a is less than *b.
+func (h *Heap[T]) lessVal(a, b *T) bool {
+	if h.lessPtr != nil {
+		return h.lessPtr(a, b)
+	}
+	return h.less(*a, *b)
+}
+
+// compare returns -1, 0, or +1 as *a is less than, equivalent to, or
+// greater than *b.
+func (h *Heap[T]) compare(a, b *T) int {
+	switch {
+	case h.lessVal(a, b):
+		return -1
+	case h.lessVal(b, a):
+		return +1
+	}
+	return 0
+}
+
+// sortLess sorts s by less.
+func sortLess[T any](s []T, less func(a, b T) bool) {
+	slices.SortFunc(s, func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return +1
+		}
+		return 0
+	})
+}
+
+func (h *Heap[T]) up(j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !h.lessAt(j, i) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+func (h *Heap[T]) down(i0, n int) bool {
+	i := i0
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && h.lessAt(j2, j1) {
+			j = j2 // = 2*i + 2  // right child
+		}
+		if !h.lessAt(j, i) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
@@ -0,0 +1,366 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func drain[T any](h *Heap[T]) []T {
+	var out []T
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestHeapPushPop(t *testing.T) {
+	h := NewOrdered[int]()
+	if _, ok := h.Pop(); ok {
+		t.Errorf("Pop on empty heap reported true")
+	}
+	if _, ok := h.Peek(); ok {
+		t.Errorf("Peek on empty heap reported true")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	var want []int
+	for i := 0; i < 200; i++ {
+		v := r.Intn(50)
+		h.Push(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+	if v, _ := h.Peek(); v != want[0] {
+		t.Errorf("Peek() = %d, want %d", v, want[0])
+	}
+	if got := drain(h); !slices.Equal(got, want) {
+		t.Errorf("pop order = %v, want %v", got, want)
+	}
+}
+
+func TestHeapInit(t *testing.T) {
+	h := New(func(a, b int) bool { return a > b })
+	h.Init([]int{3, 9, 1, 7, 5, 8})
+	if got := drain(h); !slices.Equal(got, []int{9, 8, 7, 5, 3, 1}) {
+		t.Errorf("pop order = %v, want [9 8 7 5 3 1]", got)
+	}
+}
+
+type item struct {
+	prio  int
+	index int
+}
+
+func TestHeapIndexed(t *testing.T) {
+	h := NewIndexed(
+		func(a, b *item) bool { return a.prio < b.prio },
+		func(it *item, i int) { it.index = i },
+	)
+	items := make([]*item, 20)
+	for i := range items {
+		items[i] = &item{prio: i * 10}
+	}
+	h.Init(slices.Clone(items))
+	for i := range h.Len() {
+		if h.At(i).index != i {
+			t.Fatalf("At(%d).index = %d", i, h.At(i).index)
+		}
+	}
+
+	items[15].prio = -1
+	h.Fix(items[15].index)
+	if v, _ := h.Peek(); v != items[15] {
+		t.Errorf("Peek() after Fix = %v, want %v", v, items[15])
+	}
+
+	removed := h.Remove(items[7].index)
+	if removed != items[7] || removed.index != -1 {
+		t.Errorf("Remove returned %v with index %d", removed, removed.index)
+	}
+
+	h.Set(items[3].index, &item{prio: 1000})
+	got := drain(h)
+	if len(got) != 19 {
+		t.Fatalf("drained %d items, want 19", len(got))
+	}
+	if got[0] != items[15] || got[len(got)-1].prio != 1000 {
+		t.Errorf("unexpected drain order")
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i-1].prio > got[i].prio {
+			t.Errorf("drain out of order at %d", i)
+		}
+		if got[i].index != -1 {
+			t.Errorf("popped item has index %d, want -1", got[i].index)
+		}
+	}
+}
+
+func TestHeapRemoveRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	h := NewOrdered[int]()
+	var ref []int
+	for i := 0; i < 500; i++ {
+		if len(ref) > 0 && r.Intn(3) == 0 {
+			v := h.Remove(r.Intn(h.Len()))
+			j := slices.Index(ref, v)
+			ref = slices.Delete(ref, j, j+1)
+		} else {
+			v := r.Intn(1000)
+			h.Push(v)
+			ref = append(ref, v)
+		}
+	}
+	slices.Sort(ref)
+	if got := drain(h); !slices.Equal(got, ref) {
+		t.Errorf("pop order = %v, want %v", got, ref)
+	}
+}
+
+func TestHeapSetLess(t *testing.T) {
+	for _, indirect := range []bool{false, true} {
+		less := func(a, b *item) bool { return a.prio < b.prio }
+		setIndex := func(it *item, i int) { it.index = i }
+		h := NewIndexed(less, setIndex)
+		if indirect {
+			h = NewIndirect(
+				func(a, b **item) bool { return less(*a, *b) },
+				func(it **item, i int) { setIndex(*it, i) },
+			)
+		}
+		items := make([]*item, 50)
+		for i := range items {
+			items[i] = &item{prio: i}
+			h.Push(items[i])
+		}
+		h.SetLess(func(a, b *item) bool { return a.prio > b.prio })
+		for _, it := range items {
+			if h.At(it.index) != it {
+				t.Fatalf("indirect=%v: index %d is stale after SetLess", indirect, it.index)
+			}
+		}
+		if v, _ := h.Peek(); v != items[49] {
+			t.Errorf("indirect=%v: Peek() after SetLess = %v, want prio 49", indirect, v)
+		}
+
+		items[3].prio = 100
+		h.Fix(items[3].index)
+		if v, _ := h.Peek(); v != items[3] {
+			t.Errorf("indirect=%v: Peek() after Fix = %v, want %v", indirect, v, items[3])
+		}
+		if v := h.Remove(items[49].index); v != items[49] || v.index != -1 {
+			t.Errorf("indirect=%v: Remove returned %v with index %d", indirect, v, v.index)
+		}
+		got := drain(h)
+		if len(got) != 49 || got[0] != items[3] {
+			t.Fatalf("indirect=%v: drained %d items starting %v", indirect, len(got), got[0])
+		}
+		for i := 2; i < len(got); i++ {
+			if got[i-1].prio < got[i].prio {
+				t.Errorf("indirect=%v: drain out of order at %d", indirect, i)
+			}
+		}
+	}
+}
+
+func TestHeapPopN(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for _, indirect := range []bool{false, true} {
+		h := NewIndexed(
+			func(a, b *item) bool { return a.prio < b.prio },
+			func(it *item, i int) { it.index = i },
+		)
+		if indirect {
+			h = NewIndirect(
+				func(a, b **item) bool { return (*a).prio < (*b).prio },
+				func(it **item, i int) { (*it).index = i },
+			)
+		}
+		var want []int
+		for range 300 {
+			p := r.Intn(100)
+			h.Push(&item{prio: p})
+			want = append(want, p)
+		}
+		slices.Sort(want)
+		prios := func(its []*item) []int {
+			var ps []int
+			for _, it := range its {
+				if it.index != -1 {
+					t.Errorf("indirect=%v: popped item has index %d", indirect, it.index)
+				}
+				ps = append(ps, it.prio)
+			}
+			return ps
+		}
+		if got := prios(h.PopN(10)); !slices.Equal(got, want[:10]) {
+			t.Errorf("indirect=%v: PopN(10) = %v, want %v", indirect, got, want[:10])
+		}
+		if got := prios(h.PopWhile(func(it *item) bool { return it.prio < 20 })); !slices.Equal(got, want[10:len(got)+10]) || h.Len() > 0 && h.At(0).prio < 20 {
+			t.Errorf("indirect=%v: PopWhile(< 20) = %v", indirect, got)
+		}
+		rest := want[len(want)-h.Len():]
+		if got := prios(h.PopN(h.Len())); !slices.Equal(got, rest) || h.Len() != 0 {
+			t.Errorf("indirect=%v: PopN(Len()) = %v, want %v", indirect, got, rest)
+		}
+		if got := h.PopN(1); got != nil {
+			t.Errorf("indirect=%v: PopN on empty heap = %v, want nil", indirect, got)
+		}
+		h.Push(&item{prio: 1})
+		h.Push(&item{prio: 0})
+		if v, _ := h.Pop(); v.prio != 0 {
+			t.Errorf("indirect=%v: Pop after drain = %d, want 0", indirect, v.prio)
+		}
+	}
+}
+
+type big struct {
+	key int
+	pad [200]byte
+}
+
+func TestHeapIndirect(t *testing.T) {
+	if h := New(func(a, b big) bool { return a.key < b.key }); !h.indirect {
+		t.Errorf("heap of %d-byte elements is not indirect", len(big{}.pad))
+	}
+	if h := NewOrdered[int](); h.indirect {
+		t.Errorf("heap of ints is indirect")
+	}
+
+	r := rand.New(rand.NewSource(3))
+	type tracked struct {
+		key   int
+		index *int
+	}
+	direct := NewIndexed(
+		func(a, b tracked) bool { return a.key < b.key },
+		func(v tracked, i int) { *v.index = i },
+	)
+	indirect := NewIndirect(
+		func(a, b *tracked) bool { return a.key < b.key },
+		func(v *tracked, i int) { *v.index = i },
+	)
+	var live []tracked
+	var live2 []tracked
+	for i := 0; i < 2000; i++ {
+		switch op := r.Intn(5); {
+		case op < 2 || direct.Len() == 0:
+			k := r.Intn(100)
+			a, b := tracked{k, new(int)}, tracked{k, new(int)}
+			direct.Push(a)
+			indirect.Push(b)
+			live = append(live, a)
+			live2 = append(live2, b)
+		case op == 2:
+			v1, _ := direct.Pop()
+			v2, _ := indirect.Pop()
+			if v1.key != v2.key {
+				t.Fatalf("Pop() = %d, indirect %d", v1.key, v2.key)
+			}
+			if *v2.index != -1 {
+				t.Fatalf("indirect popped element has index %d", *v2.index)
+			}
+		default:
+			j := r.Intn(len(live))
+			k := r.Intn(100)
+			if *live[j].index >= 0 && *live2[j].index >= 0 {
+				direct.Set(*live[j].index, tracked{k, live[j].index})
+				indirect.Set(*live2[j].index, tracked{k, live2[j].index})
+			}
+		}
+		for _, v := range live2 {
+			if i := *v.index; i >= 0 && indirect.At(i).index != v.index {
+				t.Fatalf("indirect index %d is stale", i)
+			}
+		}
+	}
+	for direct.Len() > 0 {
+		v1, _ := direct.Pop()
+		v2, _ := indirect.Pop()
+		if v1.key != v2.key {
+			t.Fatalf("drain Pop() = %d, indirect %d", v1.key, v2.key)
+		}
+	}
+	if indirect.Len() != 0 || len(indirect.ord) != 0 || len(indirect.pos) != 0 {
+		t.Errorf("indirect heap not empty after drain")
+	}
+
+	h := NewIndirect(func(a, b *int) bool { return *a < *b }, nil)
+	h.Init([]int{5, 3, 8, 1})
+	h.Remove(2)
+	if got := drain(h); !slices.IsSorted(got) || len(got) != 3 {
+		t.Errorf("indirect Init, Remove, drain = %v", got)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	vs := make([]int, 500)
+	for i := range vs {
+		vs[i] = r.Intn(100)
+	}
+	h := CollectOrdered(slices.Values(vs))
+	want := slices.Sorted(slices.Values(vs))
+	if got := drain(h); !slices.Equal(got, want) {
+		t.Errorf("CollectOrdered pop order = %v, want %v", got, want)
+	}
+	h = Collect(slices.Values(vs), func(a, b int) bool { return a > b })
+	slices.Reverse(want)
+	if got := drain(h); !slices.Equal(got, want) {
+		t.Errorf("Collect pop order = %v, want %v", got, want)
+	}
+	if h := CollectOrdered(slices.Values([]int(nil))); h.Len() != 0 {
+		t.Errorf("Collect of empty sequence has Len() = %d", h.Len())
+	}
+}
+
+func BenchmarkCollect(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	vs := make([]int, 10000)
+	for i := range vs {
+		vs[i] = r.Int()
+	}
+	b.Run("Push", func(b *testing.B) {
+		for range b.N {
+			h := NewOrdered[int]()
+			for _, v := range vs {
+				h.Push(v)
+			}
+		}
+	})
+	b.Run("Collect", func(b *testing.B) {
+		for range b.N {
+			CollectOrdered(slices.Values(vs))
+		}
+	})
+}
+
+func BenchmarkHeapLarge(b *testing.B) {
+	const n = 1000
+	keys := rand.New(rand.NewSource(1)).Perm(n)
+	less := func(a, b big) bool { return a.key < b.key }
+	for _, bm := range []struct {
+		name string
+		new  func() *Heap[big]
+	}{
+		{"Direct", func() *Heap[big] { return &Heap[big]{less: less} }},
+		{"Indirect", func() *Heap[big] { return New(less) }},
+		{"IndirectPtr", func() *Heap[big] {
+			return NewIndirect(func(a, b *big) bool { return a.key < b.key }, nil)
+		}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			h := bm.new()
+			for range b.N {
+				for _, k := range keys {
+					h.Push(big{key: k})
+				}
+				for h.Len() > 0 {
+					h.Pop()
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Package intervalmap implements a map that assigns values to half-open
+// ranges of keys.
+package intervalmap
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sort"
+)
+
+// A Span is a half-open key range [Lo, Hi) and its value.
+type Span[K cmp.Ordered, V any] struct {
+	Lo, Hi K
+	Value  V
+}
+
+// Map assigns values to disjoint half-open key ranges. Assigning a value
+// to a range overwrites the overlapping parts of existing ranges, splitting
+// them as needed. If the Map was created with an equality function,
+// adjacent ranges with equal values are merged into one.
+//
+// Lookups are O(log n) in the number of ranges; assignments are O(n) in
+// the worst case.
+//
+// The zero value for Map is an empty map, without merging, ready to use.
+type Map[K cmp.Ordered, V any] struct {
+	spans []Span[K, V] // sorted and disjoint
+	eq    func(a, b V) bool
+}
+
+// New returns an empty Map that merges adjacent ranges with equal values.
+func New[K cmp.Ordered, V comparable]() *Map[K, V] {
+	return NewFunc[K](func(a, b V) bool { return a == b })
+}
+
+// NewFunc returns an empty Map that merges adjacent ranges whose values
+// are equal according to eq. If eq is nil, ranges are never merged.
+func NewFunc[K cmp.Ordered, V any](eq func(a, b V) bool) *Map[K, V] {
+	return &Map[K, V]{eq: eq}
+}
+
+// Len returns the number of disjoint ranges in m.
+func (m *Map[K, V]) Len() int {
+	return len(m.spans)
+}
+
+// Get returns the value of the range containing k. The boolean is false if
+// no range contains k.
+func (m *Map[K, V]) Get(k K) (V, bool) {
+	s, ok := m.Lookup(k)
+	return s.Value, ok
+}
+
+// GetOr returns the value of the range containing k, or def if no range
+// contains k.
+func (m *Map[K, V]) GetOr(k K, def V) V {
+	if v, ok := m.Get(k); ok {
+		return v
+	}
+	return def
+}
+
+// MustGet returns the value of the range containing k. It panics if no
+// range contains k.
+func (m *Map[K, V]) MustGet(k K) V {
+	v, ok := m.Get(k)
+	if !ok {
+		panic("intervalmap: MustGet of key outside all ranges")
+	}
+	return v
+}
+
+// Lookup returns the range containing k. The boolean is false if no range
+// contains k.
+func (m *Map[K, V]) Lookup(k K) (Span[K, V], bool) {
+	i := sort.Search(len(m.spans), func(i int) bool { return m.spans[i].Hi > k })
+	if i < len(m.spans) && m.spans[i].Lo <= k {
+		return m.spans[i], true
+	}
+	return Span[K, V]{}, false
+}
+
+// Set assigns v to the range [lo, hi). It does nothing if lo >= hi.
+func (m *Map[K, V]) Set(lo, hi K, v V) {
+	if lo >= hi {
+		return
+	}
+	i, j, left, right := m.cut(lo, hi)
+	repl := append(append(left, Span[K, V]{lo, hi, v}), right...)
+	m.spans = slices.Replace(m.spans, i, j, repl...)
+	m.merge(i, i+len(repl))
+}
+
+// Delete removes the range [lo, hi) from m, splitting ranges that extend
+// beyond it. It does nothing if lo >= hi.
+func (m *Map[K, V]) Delete(lo, hi K) {
+	if lo >= hi {
+		return
+	}
+	i, j, left, right := m.cut(lo, hi)
+	m.spans = slices.Replace(m.spans, i, j, append(left, right...)...)
+}
+
+// cut finds the spans m.spans[i:j] that overlap [lo, hi) and returns the
+// parts of them that lie to the left and right of [lo, hi), each of which
+// holds at most one span.
+func (m *Map[K, V]) cut(lo, hi K) (i, j int, left, right []Span[K, V]) {
+	i = sort.Search(len(m.spans), func(i int) bool { return m.spans[i].Hi > lo })
+	j = sort.Search(len(m.spans), func(j int) bool { return m.spans[j].Lo >= hi })
+	if i < j && m.spans[i].Lo < lo {
+		s := m.spans[i]
+		s.Hi = lo
+		left = []Span[K, V]{s}
+	}
+	if i < j && m.spans[j-1].Hi > hi {
+		s := m.spans[j-1]
+		s.Lo = hi
+		right = []Span[K, V]{s}
+	}
+	return i, j, left, right
+}
+
+// merge coalesces equal-valued adjacent spans in m.spans[i-1:j+1].
+func (m *Map[K, V]) merge(i, j int) {
+	if m.eq == nil {
+		return
+	}
+	lo, hi := max(i-1, 0), min(j+1, len(m.spans))
+	out := m.spans[lo:lo]
+	for _, s := range m.spans[lo:hi] {
+		if n := len(out); n > 0 && out[n-1].Hi == s.Lo && m.eq(out[n-1].Value, s.Value) {
+			out[n-1].Hi = s.Hi
+			continue
+		}
+		out = append(out, s)
+	}
+	m.spans = slices.Delete(m.spans, lo+len(out), hi)
+}
+
+// All returns an iterator over the ranges of m in increasing key order.
+func (m *Map[K, V]) All() iter.Seq[Span[K, V]] {
+	return func(yield func(Span[K, V]) bool) {
+		for _, s := range m.spans {
+			if !yield(s) {
+				return
+			}
+		}
+	}
+}
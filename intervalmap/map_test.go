@@ -0,0 +1,129 @@
+package intervalmap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func spans(m *Map[int, string]) []Span[int, string] {
+	return slices.Collect(m.All())
+}
+
+func TestMap(t *testing.T) {
+	m := New[int, string]()
+	if _, ok := m.Get(0); ok {
+		t.Errorf("Get on empty map reported true")
+	}
+
+	m.Set(0, 10, "a")
+	m.Set(20, 30, "b")
+	m.Set(5, 25, "c") // splits both neighbors
+	want := []Span[int, string]{{0, 5, "a"}, {5, 25, "c"}, {25, 30, "b"}}
+	if got := spans(m); !slices.Equal(got, want) {
+		t.Errorf("after Set(5, 25) = %v, want %v", got, want)
+	}
+
+	for _, tt := range []struct {
+		k    int
+		v    string
+		want bool
+	}{
+		{-1, "", false}, {0, "a", true}, {4, "a", true}, {5, "c", true},
+		{24, "c", true}, {25, "b", true}, {29, "b", true}, {30, "", false},
+	} {
+		if v, ok := m.Get(tt.k); v != tt.v || ok != tt.want {
+			t.Errorf("Get(%d) = %q, %v; want %q, %v", tt.k, v, ok, tt.v, tt.want)
+		}
+	}
+
+	// Assigning an equal value next to an existing range merges them.
+	m.Set(30, 40, "b")
+	m.Set(-5, 0, "a")
+	want = []Span[int, string]{{-5, 5, "a"}, {5, 25, "c"}, {25, 40, "b"}}
+	if got := spans(m); !slices.Equal(got, want) {
+		t.Errorf("after merging Sets = %v, want %v", got, want)
+	}
+
+	m.Set(10, 15, "c") // no-op in value; must not split
+	if m.Len() != 3 {
+		t.Errorf("Len() after redundant Set = %d, want 3", m.Len())
+	}
+
+	m.Delete(0, 30)
+	want = []Span[int, string]{{-5, 0, "a"}, {30, 40, "b"}}
+	if got := spans(m); !slices.Equal(got, want) {
+		t.Errorf("after Delete(0, 30) = %v, want %v", got, want)
+	}
+
+	m.Set(3, 3, "x") // empty range
+	if s, ok := m.Lookup(35); !ok || s != (Span[int, string]{30, 40, "b"}) {
+		t.Errorf("Lookup(35) = %v, %v", s, ok)
+	}
+}
+
+func TestMapNoMerge(t *testing.T) {
+	var m Map[int, string]
+	m.Set(0, 5, "a")
+	m.Set(5, 10, "a")
+	if m.Len() != 2 {
+		t.Errorf("zero Map merged ranges: %v", spans(&m))
+	}
+}
+
+func TestMapRandom(t *testing.T) {
+	const n = 60
+	r := rand.New(rand.NewSource(1))
+	m := New[int, int]()
+	var ref [n]int // 0 means unset
+	for i := 0; i < 2000; i++ {
+		lo := r.Intn(n)
+		hi := lo + r.Intn(n-lo+1)
+		if r.Intn(4) == 0 {
+			m.Delete(lo, hi)
+			for k := lo; k < hi; k++ {
+				ref[k] = 0
+			}
+		} else {
+			v := 1 + r.Intn(3)
+			m.Set(lo, hi, v)
+			for k := lo; k < hi; k++ {
+				ref[k] = v
+			}
+		}
+
+		var prev Span[int, int]
+		for j, s := range slices.Collect(m.All()) {
+			if s.Lo >= s.Hi || (j > 0 && (s.Lo < prev.Hi || s.Lo == prev.Hi && s.Value == prev.Value)) {
+				t.Fatalf("invalid spans: %v", slices.Collect(m.All()))
+			}
+			prev = s
+		}
+		for k := 0; k < n; k++ {
+			v, ok := m.Get(k)
+			if ok != (ref[k] != 0) || v != ref[k] {
+				t.Fatalf("Get(%d) = %d, %v; want %d", k, v, ok, ref[k])
+			}
+		}
+	}
+}
+
+func TestMapGetOr(t *testing.T) {
+	m := New[int, string]()
+	m.Set(0, 10, "a")
+	if v := m.GetOr(5, "none"); v != "a" {
+		t.Errorf("GetOr(5) = %q, want a", v)
+	}
+	if v := m.GetOr(10, "none"); v != "none" {
+		t.Errorf("GetOr(10) = %q, want none", v)
+	}
+	if v := m.MustGet(0); v != "a" {
+		t.Errorf("MustGet(0) = %q, want a", v)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustGet(10) did not panic")
+		}
+	}()
+	m.MustGet(10)
+}
@@ -0,0 +1,239 @@
+// Package intrusive implements an intrusive doubly-linked list, in which
+// the links live in the listed objects themselves.
+//
+// Where list.List allocates an Element to hold each value, an intrusive
+// List links objects through a Hook that the user embeds in their own
+// struct type. Inserting and removing objects allocates nothing, and an
+// object can be removed in O(1) given only a pointer to it. A struct with
+// several Hooks can be in several lists at once.
+package intrusive
+
+import "iter"
+
+// Hook holds the links of an object of type T in a List. Embed it in T,
+// or add it as a field, and give the List a function that returns the
+// Hook of an object. A Hook is in at most one List at a time; its zero
+// value is unlinked.
+type Hook[T any] struct {
+	prev, next *Hook[T]
+	list       *List[T]
+	owner      *T
+}
+
+// Linked reports whether h is in a List.
+func (h *Hook[T]) Linked() bool {
+	return h.list != nil
+}
+
+// List is an intrusive doubly-linked list of *T. All operations other
+// than iteration are O(1).
+//
+// The zero value for List is not usable; use New.
+type List[T any] struct {
+	root Hook[T] // sentinel; root.next is the front
+	len  int
+	hook func(*T) *Hook[T]
+}
+
+// New returns an empty List that links objects through the Hook returned
+// by hook, which must return the same Hook each time it is called with
+// the same object.
+func New[T any](hook func(*T) *Hook[T]) *List[T] {
+	l := &List[T]{hook: hook}
+	l.root.prev = &l.root
+	l.root.next = &l.root
+	return l
+}
+
+// Len returns the number of objects in l.
+func (l *List[T]) Len() int {
+	return l.len
+}
+
+// Contains reports whether v is in l.
+func (l *List[T]) Contains(v *T) bool {
+	return l.hook(v).list == l
+}
+
+// Front returns the first object in l, or nil if l is empty.
+func (l *List[T]) Front() *T {
+	return l.root.next.owner
+}
+
+// Back returns the last object in l, or nil if l is empty.
+func (l *List[T]) Back() *T {
+	return l.root.prev.owner
+}
+
+// Next returns the object after v in l, or nil if v is last or not in l.
+func (l *List[T]) Next(v *T) *T {
+	h := l.hook(v)
+	if h.list != l {
+		return nil
+	}
+	return h.next.owner
+}
+
+// Prev returns the object before v in l, or nil if v is first or not in
+// l.
+func (l *List[T]) Prev(v *T) *T {
+	h := l.hook(v)
+	if h.list != l {
+		return nil
+	}
+	return h.prev.owner
+}
+
+// link inserts v after the hook at. It panics if v is already in a list,
+// since relinking it would corrupt that list.
+func (l *List[T]) link(v *T, at *Hook[T]) {
+	h := l.hook(v)
+	if h.list != nil {
+		panic("intrusive: object is already in a list")
+	}
+	h.owner = v
+	h.list = l
+	h.prev = at
+	h.next = at.next
+	at.next.prev = h
+	at.next = h
+	l.len++
+}
+
+func (l *List[T]) unlink(h *Hook[T]) {
+	h.prev.next = h.next
+	h.next.prev = h.prev
+	h.prev = nil
+	h.next = nil
+	h.list = nil
+	h.owner = nil
+	l.len--
+}
+
+// PushFront inserts v at the front of l. It panics if v is already in a
+// list.
+func (l *List[T]) PushFront(v *T) {
+	l.link(v, &l.root)
+}
+
+// PushBack inserts v at the back of l. It panics if v is already in a
+// list.
+func (l *List[T]) PushBack(v *T) {
+	l.link(v, l.root.prev)
+}
+
+// InsertAfter inserts v immediately after mark and reports whether it
+// did so; it does nothing if mark is not in l. It panics if v is already
+// in a list.
+func (l *List[T]) InsertAfter(v, mark *T) bool {
+	m := l.hook(mark)
+	if m.list != l {
+		return false
+	}
+	l.link(v, m)
+	return true
+}
+
+// InsertBefore inserts v immediately before mark and reports whether it
+// did so; it does nothing if mark is not in l. It panics if v is already
+// in a list.
+func (l *List[T]) InsertBefore(v, mark *T) bool {
+	m := l.hook(mark)
+	if m.list != l {
+		return false
+	}
+	l.link(v, m.prev)
+	return true
+}
+
+// Remove removes v from l. It reports whether v was in l.
+func (l *List[T]) Remove(v *T) bool {
+	h := l.hook(v)
+	if h.list != l {
+		return false
+	}
+	l.unlink(h)
+	return true
+}
+
+// PopFront removes and returns the first object in l, or returns nil if
+// l is empty.
+func (l *List[T]) PopFront() *T {
+	v := l.Front()
+	if v != nil {
+		l.unlink(l.root.next)
+	}
+	return v
+}
+
+// PopBack removes and returns the last object in l, or returns nil if l
+// is empty.
+func (l *List[T]) PopBack() *T {
+	v := l.Back()
+	if v != nil {
+		l.unlink(l.root.prev)
+	}
+	return v
+}
+
+// MoveToFront moves v to the front of l. It reports whether v is in l.
+func (l *List[T]) MoveToFront(v *T) bool {
+	h := l.hook(v)
+	if h.list != l {
+		return false
+	}
+	if l.root.next != h {
+		l.unlink(h)
+		l.link(v, &l.root)
+	}
+	return true
+}
+
+// MoveToBack moves v to the back of l. It reports whether v is in l.
+func (l *List[T]) MoveToBack(v *T) bool {
+	h := l.hook(v)
+	if h.list != l {
+		return false
+	}
+	if l.root.prev != h {
+		l.unlink(h)
+		l.link(v, l.root.prev)
+	}
+	return true
+}
+
+// Clear removes all objects from l, unlinking each of them so that they
+// can be inserted into another list. Clear is O(n).
+func (l *List[T]) Clear() {
+	for l.len > 0 {
+		l.unlink(l.root.next)
+	}
+}
+
+// All returns an iterator over the objects in l, front to back. It is
+// safe to remove the current object during iteration.
+func (l *List[T]) All() iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		for h := l.root.next; h != &l.root; {
+			next := h.next
+			if !yield(h.owner) {
+				return
+			}
+			h = next
+		}
+	}
+}
+
+// Backward returns an iterator over the objects in l, back to front. It
+// is safe to remove the current object during iteration.
+func (l *List[T]) Backward() iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		for h := l.root.prev; h != &l.root; {
+			prev := h.prev
+			if !yield(h.owner) {
+				return
+			}
+			h = prev
+		}
+	}
+}
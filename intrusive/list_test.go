@@ -0,0 +1,161 @@
+package intrusive
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nishanths/typedcontainer/list"
+)
+
+type task struct {
+	id      int
+	all     Hook[task] // in the list of all tasks
+	pending Hook[task] // in the list of pending tasks
+}
+
+func allHook(t *task) *Hook[task]     { return &t.all }
+func pendingHook(t *task) *Hook[task] { return &t.pending }
+
+func ids(l *List[task]) []int {
+	var out []int
+	for t := range l.All() {
+		out = append(out, t.id)
+	}
+	return out
+}
+
+func checkList(t *testing.T, l *List[task], want []int) {
+	t.Helper()
+	if l.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", l.Len(), len(want))
+	}
+	if got := ids(l); !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	var back []int
+	for v := range l.Backward() {
+		back = append(back, v.id)
+	}
+	slices.Reverse(back)
+	if !slices.Equal(back, want) {
+		t.Errorf("reversed Backward() = %v, want %v", back, want)
+	}
+	// Walk with Next and Prev.
+	var fwd []int
+	for v := l.Front(); v != nil; v = l.Next(v) {
+		fwd = append(fwd, v.id)
+	}
+	if !slices.Equal(fwd, want) {
+		t.Errorf("Front/Next walk = %v, want %v", fwd, want)
+	}
+	var rev []int
+	for v := l.Back(); v != nil; v = l.Prev(v) {
+		rev = append(rev, v.id)
+	}
+	slices.Reverse(rev)
+	if !slices.Equal(rev, want) {
+		t.Errorf("Back/Prev walk = %v, want %v", rev, want)
+	}
+}
+
+func TestList(t *testing.T) {
+	l := New(allHook)
+	checkList(t, l, nil)
+	if l.PopFront() != nil || l.PopBack() != nil {
+		t.Errorf("Pop on empty list returned an object")
+	}
+	ts := make([]task, 5)
+	for i := range ts {
+		ts[i].id = i
+	}
+	l.PushBack(&ts[1])
+	l.PushFront(&ts[0])
+	l.PushBack(&ts[3])
+	if !l.InsertBefore(&ts[2], &ts[3]) || !l.InsertAfter(&ts[4], &ts[3]) {
+		t.Errorf("Insert with a mark in l reported false")
+	}
+	checkList(t, l, []int{0, 1, 2, 3, 4})
+
+	if !l.Remove(&ts[2]) || l.Remove(&ts[2]) || ts[2].all.Linked() {
+		t.Errorf("Remove did not unlink exactly once")
+	}
+	if l.InsertAfter(&task{id: 9}, &ts[2]) {
+		t.Errorf("InsertAfter with a mark not in l reported true")
+	}
+	l.MoveToFront(&ts[4])
+	l.MoveToBack(&ts[0])
+	checkList(t, l, []int{4, 1, 3, 0})
+	if v := l.PopFront(); v != &ts[4] {
+		t.Errorf("PopFront() = %v, want task 4", v)
+	}
+	if v := l.PopBack(); v != &ts[0] {
+		t.Errorf("PopBack() = %v, want task 0", v)
+	}
+	checkList(t, l, []int{1, 3})
+
+	// Removing during iteration.
+	for v := range l.All() {
+		l.Remove(v)
+	}
+	checkList(t, l, nil)
+	l.PushBack(&ts[1])
+	l.Clear()
+	if ts[1].all.Linked() {
+		t.Errorf("object still linked after Clear")
+	}
+	checkList(t, l, nil)
+}
+
+func TestListTwoHooks(t *testing.T) {
+	all, pending := New(allHook), New(pendingHook)
+	ts := make([]task, 3)
+	for i := range ts {
+		ts[i].id = i
+		all.PushBack(&ts[i])
+	}
+	pending.PushBack(&ts[2])
+	pending.PushBack(&ts[0])
+	checkList(t, all, []int{0, 1, 2})
+	checkList(t, pending, []int{2, 0})
+	if !all.Contains(&ts[1]) || pending.Contains(&ts[1]) {
+		t.Errorf("Contains does not distinguish lists")
+	}
+	pending.Remove(&ts[2])
+	checkList(t, all, []int{0, 1, 2})
+
+	other := New(allHook)
+	if other.Remove(&ts[0]) || other.MoveToFront(&ts[0]) {
+		t.Errorf("another list's operations affected an object it does not hold")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("inserting a linked object did not panic")
+		}
+	}()
+	other.PushBack(&ts[0])
+}
+
+func BenchmarkChurn(b *testing.B) {
+	b.Run("list", func(b *testing.B) {
+		l := list.New[*task]()
+		ts := make([]task, 1000)
+		for i := range ts {
+			l.PushBack(&ts[i])
+		}
+		b.ReportAllocs()
+		for range b.N {
+			l.PushBack(l.Remove(l.Front()))
+		}
+	})
+	b.Run("intrusive", func(b *testing.B) {
+		l := New(allHook)
+		ts := make([]task, 1000)
+		for i := range ts {
+			l.PushBack(&ts[i])
+		}
+		b.ReportAllocs()
+		for range b.N {
+			l.PushBack(l.PopFront())
+		}
+	})
+}
@@ -0,0 +1,318 @@
+// Package invindex implements an in-memory inverted index for boolean
+// term queries.
+package invindex
+
+import (
+	"math"
+	"slices"
+)
+
+// Index maps terms to the documents that contain them. Each document is
+// given an internal sequence number when first added, and each term's
+// posting list holds those numbers in ascending order, so that queries
+// are answered by merging sorted lists rather than by hashing.
+//
+// Numbers of removed documents are not reused, since that would break
+// the order in which Search returns documents. Instead, once removed
+// documents outnumber live ones, the live documents are renumbered in
+// order, so memory stays proportional to the live documents under any
+// amount of churn. An Index holds at most math.MaxUint32 documents.
+//
+// The zero value for Index is not usable; use New.
+type Index[D comparable] struct {
+	ids      map[D]uint32
+	docs     []D                            // id -> document
+	terms    map[uint32]map[string]struct{} // id -> terms, for live documents
+	postings map[string][]uint32
+	all      []uint32 // ids of live documents
+}
+
+// New returns an empty Index.
+func New[D comparable]() *Index[D] {
+	return &Index[D]{
+		ids:      make(map[D]uint32),
+		terms:    make(map[uint32]map[string]struct{}),
+		postings: make(map[string][]uint32),
+	}
+}
+
+// Len returns the number of documents in x.
+func (x *Index[D]) Len() int {
+	return len(x.all)
+}
+
+// Contains reports whether doc is in x.
+func (x *Index[D]) Contains(doc D) bool {
+	_, ok := x.ids[doc]
+	return ok
+}
+
+// Add records that doc contains terms, adding doc to x if needed. Terms
+// already recorded for doc are ignored.
+func (x *Index[D]) Add(doc D, terms ...string) {
+	id, ok := x.ids[doc]
+	if !ok {
+		if len(x.docs) == math.MaxUint32 {
+			x.compact()
+			if len(x.docs) == math.MaxUint32 {
+				panic("invindex: too many documents")
+			}
+		}
+		id = uint32(len(x.docs))
+		x.ids[doc] = id
+		x.docs = append(x.docs, doc)
+		x.terms[id] = make(map[string]struct{})
+		x.all = append(x.all, id)
+	}
+	ts := x.terms[id]
+	for _, t := range terms {
+		if _, ok := ts[t]; ok {
+			continue
+		}
+		ts[t] = struct{}{}
+		p := x.postings[t]
+		if n := len(p); n == 0 || p[n-1] < id {
+			x.postings[t] = append(p, id)
+		} else {
+			i, _ := slices.BinarySearch(p, id)
+			x.postings[t] = slices.Insert(p, i, id)
+		}
+	}
+}
+
+// Remove removes doc and all of its terms from x. It reports whether doc
+// was in x.
+func (x *Index[D]) Remove(doc D) bool {
+	id, ok := x.ids[doc]
+	if !ok {
+		return false
+	}
+	for t := range x.terms[id] {
+		p := removeID(x.postings[t], id)
+		if len(p) == 0 {
+			delete(x.postings, t)
+		} else {
+			x.postings[t] = p
+		}
+	}
+	delete(x.terms, id)
+	delete(x.ids, doc)
+	var zero D
+	x.docs[id] = zero // drop the reference for the GC
+	x.all = removeID(x.all, id)
+	if dead := len(x.docs) - len(x.all); dead >= minCompact && dead > len(x.all) {
+		x.compact()
+	}
+	return true
+}
+
+// minCompact is the number of removed documents below which Remove does
+// not bother to compact.
+const minCompact = 64
+
+// compact renumbers the live documents densely, keeping their order, and
+// forgets the numbers of removed documents. It costs time proportional to
+// the total length of the posting lists.
+func (x *Index[D]) compact() {
+	remap := make([]uint32, len(x.docs)) // old id -> new id, for live ids
+	docs := make([]D, len(x.all))
+	terms := make(map[uint32]map[string]struct{}, len(x.all))
+	for i, id := range x.all {
+		n := uint32(i)
+		remap[id] = n
+		docs[n] = x.docs[id]
+		terms[n] = x.terms[id]
+		x.ids[docs[n]] = n
+		x.all[i] = n
+	}
+	for _, p := range x.postings {
+		for i, id := range p {
+			p[i] = remap[id]
+		}
+	}
+	x.docs, x.terms = docs, terms
+}
+
+func removeID(p []uint32, id uint32) []uint32 {
+	if i, ok := slices.BinarySearch(p, id); ok {
+		return slices.Delete(p, i, i+1)
+	}
+	return p
+}
+
+// Frequency returns the number of documents that contain term.
+func (x *Index[D]) Frequency(term string) int {
+	return len(x.postings[term])
+}
+
+// Search returns the documents matching q, in the order they were added
+// to x. A document that was removed and added again counts as new.
+func (x *Index[D]) Search(q Query) []D {
+	ids := q.eval(x.postings, x.all)
+	if len(ids) == 0 {
+		return nil
+	}
+	docs := make([]D, len(ids))
+	for i, id := range ids {
+		docs[i] = x.docs[id]
+	}
+	return docs
+}
+
+// Query is a boolean query over terms, built with Term, And, Or, and Not.
+type Query struct {
+	op   op
+	term string
+	subs []Query
+}
+
+type op int
+
+const (
+	opTerm op = iota
+	opAnd
+	opOr
+	opNot
+)
+
+// Term returns a query matching documents that contain t.
+func Term(t string) Query {
+	return Query{op: opTerm, term: t}
+}
+
+// And returns a query matching documents that match every one of qs. And
+// with no arguments matches every document.
+func And(qs ...Query) Query {
+	return Query{op: opAnd, subs: qs}
+}
+
+// Or returns a query matching documents that match any of qs. Or with no
+// arguments matches no documents.
+func Or(qs ...Query) Query {
+	return Query{op: opOr, subs: qs}
+}
+
+// Not returns a query matching documents that do not match q. Within an
+// And, Not is evaluated as a difference rather than a complement, so
+// And(Term("a"), Not(Term("b"))) costs no more than its two terms.
+func Not(q Query) Query {
+	return Query{op: opNot, subs: []Query{q}}
+}
+
+// eval returns the sorted ids matching q. all is the sorted ids of every
+// live document. The result may alias a posting list and must not be
+// modified.
+func (q Query) eval(postings map[string][]uint32, all []uint32) []uint32 {
+	switch q.op {
+	case opTerm:
+		return postings[q.term]
+	case opNot:
+		return difference(all, q.subs[0].eval(postings, all))
+	case opOr:
+		var acc []uint32
+		for _, s := range q.subs {
+			acc = union(acc, s.eval(postings, all))
+		}
+		return acc
+	}
+
+	// opAnd: intersect the positive parts, shortest first, then subtract
+	// the negated parts.
+	var pos, neg [][]uint32
+	for _, s := range q.subs {
+		if s.op == opNot {
+			neg = append(neg, s.subs[0].eval(postings, all))
+		} else {
+			pos = append(pos, s.eval(postings, all))
+		}
+	}
+	acc := all
+	if len(pos) > 0 {
+		slices.SortFunc(pos, func(a, b []uint32) int { return len(a) - len(b) })
+		acc = pos[0]
+		for _, p := range pos[1:] {
+			if len(acc) == 0 {
+				break
+			}
+			acc = intersect(acc, p)
+		}
+	}
+	for _, n := range neg {
+		acc = difference(acc, n)
+	}
+	return acc
+}
+
+// skip returns the stride of the implicit skip pointers over a posting
+// list of length n.
+func skip(n int) int {
+	return max(1, int(math.Sqrt(float64(n))))
+}
+
+// intersect returns the ids in both sorted lists a and b. While looking
+// for a match in the longer list it follows skip pointers, jumping a
+// whole stride whenever the element a stride ahead is still too small.
+func intersect(a, b []uint32) []uint32 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var out []uint32
+	step := skip(len(b))
+	j := 0
+	for _, id := range a {
+		for j+step < len(b) && b[j+step] <= id {
+			j += step
+		}
+		for j < len(b) && b[j] < id {
+			j++
+		}
+		if j == len(b) {
+			break
+		}
+		if b[j] == id {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// union returns the ids in either sorted list a or b.
+func union(a, b []uint32) []uint32 {
+	out := make([]uint32, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			out = append(out, a[i])
+			i++
+		case b[j] < a[i]:
+			out = append(out, b[j])
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	out = append(out, a[i:]...)
+	return append(out, b[j:]...)
+}
+
+// difference returns the ids in sorted list a that are not in b.
+func difference(a, b []uint32) []uint32 {
+	var out []uint32
+	step := skip(len(b))
+	j := 0
+	for _, id := range a {
+		for j+step < len(b) && b[j+step] <= id {
+			j += step
+		}
+		for j < len(b) && b[j] < id {
+			j++
+		}
+		if j == len(b) || b[j] != id {
+			out = append(out, id)
+		}
+	}
+	return out
+}
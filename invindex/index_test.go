@@ -0,0 +1,142 @@
+package invindex
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestIndexQueries(t *testing.T) {
+	x := New[string]()
+	x.Add("d1", "go", "fast", "typed")
+	x.Add("d2", "rust", "fast")
+	x.Add("d3", "go", "slow")
+	x.Add("d4", "python", "slow", "typed")
+
+	tests := []struct {
+		name string
+		q    Query
+		want []string
+	}{
+		{"term", Term("go"), []string{"d1", "d3"}},
+		{"missing term", Term("java"), nil},
+		{"and", And(Term("go"), Term("fast")), []string{"d1"}},
+		{"or", Or(Term("rust"), Term("python")), []string{"d2", "d4"}},
+		{"not", Not(Term("fast")), []string{"d3", "d4"}},
+		{"and not", And(Term("typed"), Not(Term("go"))), []string{"d4"}},
+		{"nested", Or(And(Term("go"), Term("slow")), Term("rust")), []string{"d2", "d3"}},
+		{"empty and", And(), []string{"d1", "d2", "d3", "d4"}},
+		{"empty or", Or(), nil},
+	}
+	for _, tt := range tests {
+		if got := x.Search(tt.q); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Search = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	x.Add("d2", "go", "fast") // go is new for d2, fast is already recorded
+	if got := x.Search(Term("go")); !slices.Equal(got, []string{"d1", "d2", "d3"}) {
+		t.Errorf("Search(go) after Add = %v", got)
+	}
+	if x.Frequency("fast") != 2 {
+		t.Errorf("Frequency(fast) = %d, want 2", x.Frequency("fast"))
+	}
+
+	if !x.Remove("d1") || x.Remove("d1") || x.Contains("d1") {
+		t.Errorf("Remove did not report removals correctly")
+	}
+	if got := x.Search(Term("typed")); !slices.Equal(got, []string{"d4"}) {
+		t.Errorf("Search(typed) after Remove = %v, want [d4]", got)
+	}
+	if got := x.Search(Not(Term("slow"))); !slices.Equal(got, []string{"d2"}) {
+		t.Errorf("Search(not slow) after Remove = %v, want [d2]", got)
+	}
+	if x.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", x.Len())
+	}
+}
+
+func TestIndexRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	x := New[int]()
+	ref := make(map[int]map[string]bool)
+	terms := []string{"a", "b", "c", "d", "e"}
+	for i := 0; i < 2000; i++ {
+		doc := r.Intn(300)
+		if r.Intn(4) == 0 {
+			x.Remove(doc)
+			delete(ref, doc)
+			continue
+		}
+		t1, t2 := terms[r.Intn(len(terms))], terms[r.Intn(len(terms))]
+		x.Add(doc, t1, t2)
+		if ref[doc] == nil {
+			ref[doc] = make(map[string]bool)
+		}
+		ref[doc][t1], ref[doc][t2] = true, true
+	}
+
+	for i, tt := range []struct {
+		q     Query
+		match func(ts map[string]bool) bool
+	}{
+		{And(Term("a"), Term("b")), func(ts map[string]bool) bool { return ts["a"] && ts["b"] }},
+		{Or(Term("c"), Term("d")), func(ts map[string]bool) bool { return ts["c"] || ts["d"] }},
+		{And(Term("e"), Not(Term("a"))), func(ts map[string]bool) bool { return ts["e"] && !ts["a"] }},
+		{Not(Or(Term("a"), Term("b"))), func(ts map[string]bool) bool { return !ts["a"] && !ts["b"] }},
+	} {
+		got := x.Search(tt.q)
+		slices.Sort(got)
+		var want []int
+		for doc, ts := range ref {
+			if tt.match(ts) {
+				want = append(want, doc)
+			}
+		}
+		slices.Sort(want)
+		if !slices.Equal(got, want) {
+			t.Errorf("query %d: Search = %d docs, want %d", i, len(got), len(want))
+		}
+	}
+}
+
+func TestIndexChurn(t *testing.T) {
+	x := New[int]()
+	x.Add(-1, "keep", "even")
+	const window = 20
+	for i := range 10000 {
+		term := "odd"
+		if i%2 == 0 {
+			term = "even"
+		}
+		x.Add(i, term)
+		if i >= window {
+			x.Remove(i - window)
+		}
+		if n := len(x.docs); n > 2*(window+1)+minCompact {
+			t.Fatalf("step %d: %d document slots for %d live documents", i, n, x.Len())
+		}
+	}
+	want := []int{-1}
+	for i := 10000 - window; i < 10000; i++ {
+		want = append(want, i)
+	}
+	if got := x.Search(And()); !slices.Equal(got, want) {
+		t.Errorf("Search(And()) = %v, want %v", got, want)
+	}
+	even := []int{-1}
+	for _, d := range want[1:] {
+		if d%2 == 0 {
+			even = append(even, d)
+		}
+	}
+	if got := x.Search(Term("even")); !slices.Equal(got, even) {
+		t.Errorf("Search(even) = %v, want %v", got, even)
+	}
+	if got := x.Search(And(Term("keep"), Not(Term("odd")))); !slices.Equal(got, []int{-1}) {
+		t.Errorf("Search(keep, not odd) = %v, want [-1]", got)
+	}
+	if !x.Remove(-1) || x.Frequency("keep") != 0 || x.Len() != window {
+		t.Errorf("Remove(-1) after compaction: Frequency(keep) = %d, Len() = %d", x.Frequency("keep"), x.Len())
+	}
+}
@@ -0,0 +1,298 @@
+// Package iptree implements a radix tree keyed by IP prefixes, for
+// routing and access-control tables.
+package iptree
+
+import (
+	"iter"
+	"math/bits"
+	"net/netip"
+)
+
+// node is a node of a path-compressed binary trie. A node's children hold
+// prefixes strictly inside its own, split by the first bit after it. Nodes
+// without a value exist only to join two children.
+type node[V any] struct {
+	prefix netip.Prefix
+	value  V
+	has    bool
+	child  [2]*node[V]
+}
+
+// Tree maps IP prefixes to values. IPv4 and IPv6 prefixes are kept apart:
+// an address only matches prefixes of its own family, so IPv4-mapped IPv6
+// addresses should be converted with netip.Addr.Unmap before lookup if
+// they are meant to match IPv4 prefixes.
+//
+// Prefixes are stored in canonical form, with the bits after the prefix
+// length cleared, so 10.1.2.3/8 and 10.0.0.0/8 are the same key. Insert,
+// Delete, Get, and Lookup take time proportional to the address length,
+// independent of the number of prefixes.
+//
+// The zero value for Tree is an empty tree ready to use.
+type Tree[V any] struct {
+	v4, v6 *node[V]
+	len    int
+}
+
+// Len returns the number of prefixes in t.
+func (t *Tree[V]) Len() int {
+	return t.len
+}
+
+func (t *Tree[V]) root(a netip.Addr) **node[V] {
+	if a.Is4() {
+		return &t.v4
+	}
+	return &t.v6
+}
+
+// bitAt returns bit i of a, counting from the most significant bit.
+func bitAt(a netip.Addr, i int) int {
+	if a.Is4() {
+		b := a.As4()
+		return int(b[i/8]>>(7-i%8)) & 1
+	}
+	b := a.As16()
+	return int(b[i/8]>>(7-i%8)) & 1
+}
+
+// commonBits returns the length of the longest prefix shared by p and q,
+// which must be of the same family.
+func commonBits(p, q netip.Prefix) int {
+	limit := min(p.Bits(), q.Bits())
+	var a, b []byte
+	if p.Addr().Is4() {
+		a4, b4 := p.Addr().As4(), q.Addr().As4()
+		a, b = a4[:], b4[:]
+	} else {
+		a16, b16 := p.Addr().As16(), q.Addr().As16()
+		a, b = a16[:], b16[:]
+	}
+	n := 0
+	for i := range a {
+		if x := a[i] ^ b[i]; x != 0 {
+			n += bits.LeadingZeros8(x)
+			break
+		}
+		n += 8
+	}
+	return min(n, limit)
+}
+
+// canonical returns p with its host bits cleared. It panics if p is not
+// valid.
+func canonical(p netip.Prefix) netip.Prefix {
+	if !p.IsValid() {
+		panic("iptree: invalid prefix")
+	}
+	return p.Masked()
+}
+
+// Insert sets the value for prefix p. It reports whether p was added,
+// rather than already in t. It panics if p is not valid.
+func (t *Tree[V]) Insert(p netip.Prefix, v V) bool {
+	p = canonical(p)
+	for n := t.root(p.Addr()); ; {
+		cur := *n
+		if cur == nil {
+			*n = &node[V]{prefix: p, value: v, has: true}
+			t.len++
+			return true
+		}
+		c := commonBits(cur.prefix, p)
+		switch {
+		case c == cur.prefix.Bits() && c == p.Bits():
+			added := !cur.has
+			cur.value, cur.has = v, true
+			if added {
+				t.len++
+			}
+			return added
+		case c == cur.prefix.Bits():
+			// p is inside cur.
+			n = &cur.child[bitAt(p.Addr(), c)]
+			continue
+		case c == p.Bits():
+			// cur is inside p.
+			nn := &node[V]{prefix: p, value: v, has: true}
+			nn.child[bitAt(cur.prefix.Addr(), c)] = cur
+			*n = nn
+		default:
+			// p and cur diverge after c bits; join them.
+			leaf := &node[V]{prefix: p, value: v, has: true}
+			glue := &node[V]{prefix: netip.PrefixFrom(p.Addr(), c).Masked()}
+			glue.child[bitAt(p.Addr(), c)] = leaf
+			glue.child[bitAt(cur.prefix.Addr(), c)] = cur
+			*n = glue
+		}
+		t.len++
+		return true
+	}
+}
+
+// Delete removes prefix p from t. It reports whether p was in t. It
+// panics if p is not valid.
+func (t *Tree[V]) Delete(p netip.Prefix) bool {
+	p = canonical(p)
+	root := t.root(p.Addr())
+	var deleted bool
+	*root, deleted = deleteNode(*root, p)
+	if deleted {
+		t.len--
+	}
+	return deleted
+}
+
+// deleteNode removes p from the subtree n and returns the new subtree,
+// with any node left without a value and with fewer than two children
+// spliced out.
+func deleteNode[V any](n *node[V], p netip.Prefix) (*node[V], bool) {
+	if n == nil || !n.prefix.Overlaps(p) || n.prefix.Bits() > p.Bits() {
+		return n, false
+	}
+	var deleted bool
+	if n.prefix.Bits() == p.Bits() {
+		if !n.has {
+			return n, false
+		}
+		var zero V
+		n.value, n.has = zero, false
+		deleted = true
+	} else {
+		i := bitAt(p.Addr(), n.prefix.Bits())
+		n.child[i], deleted = deleteNode(n.child[i], p)
+		if !deleted {
+			return n, false
+		}
+	}
+	if n.has {
+		return n, true
+	}
+	switch {
+	case n.child[0] == nil:
+		return n.child[1], true
+	case n.child[1] == nil:
+		return n.child[0], true
+	}
+	return n, true
+}
+
+// find returns the node holding exactly p, which must be canonical.
+func (t *Tree[V]) find(p netip.Prefix) *node[V] {
+	n := *t.root(p.Addr())
+	for n != nil && n.prefix.Bits() <= p.Bits() && n.prefix.Contains(p.Addr()) {
+		if n.prefix.Bits() == p.Bits() {
+			if n.has {
+				return n
+			}
+			return nil
+		}
+		n = n.child[bitAt(p.Addr(), n.prefix.Bits())]
+	}
+	return nil
+}
+
+// Get returns the value for exactly prefix p. The boolean is false if p is
+// not in t or is not valid.
+func (t *Tree[V]) Get(p netip.Prefix) (V, bool) {
+	if p.IsValid() {
+		if n := t.find(p.Masked()); n != nil {
+			return n.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// GetOr returns the value for exactly prefix p, or def if p is not in t.
+func (t *Tree[V]) GetOr(p netip.Prefix, def V) V {
+	if v, ok := t.Get(p); ok {
+		return v
+	}
+	return def
+}
+
+// MustGet returns the value for exactly prefix p. It panics if p is not in
+// t.
+func (t *Tree[V]) MustGet(p netip.Prefix) V {
+	v, ok := t.Get(p)
+	if !ok {
+		panic("iptree: MustGet of missing key")
+	}
+	return v
+}
+
+// Lookup returns the longest prefix in t that contains a, and its value.
+// The boolean is false if no prefix contains a.
+func (t *Tree[V]) Lookup(a netip.Addr) (netip.Prefix, V, bool) {
+	var best *node[V]
+	if a.IsValid() {
+		for n := *t.root(a); n != nil && n.prefix.Contains(a); {
+			if n.has {
+				best = n
+			}
+			if n.prefix.Bits() == a.BitLen() {
+				break
+			}
+			n = n.child[bitAt(a, n.prefix.Bits())]
+		}
+	}
+	if best == nil {
+		var zero V
+		return netip.Prefix{}, zero, false
+	}
+	return best.prefix, best.value, true
+}
+
+// Overlaps reports whether any prefix in t overlaps p, that is, contains
+// it or is contained in it.
+func (t *Tree[V]) Overlaps(p netip.Prefix) bool {
+	for range t.Overlapping(p) {
+		return true
+	}
+	return false
+}
+
+// Overlapping returns an iterator over the prefixes in t that overlap p,
+// and their values: first the prefixes containing p, from the shortest,
+// then the prefixes inside p, in the order of All.
+func (t *Tree[V]) Overlapping(p netip.Prefix) iter.Seq2[netip.Prefix, V] {
+	return func(yield func(netip.Prefix, V) bool) {
+		if !p.IsValid() {
+			return
+		}
+		p = p.Masked()
+		n := *t.root(p.Addr())
+		for n != nil && n.prefix.Overlaps(p) {
+			if n.prefix.Bits() >= p.Bits() {
+				walk(n, yield) // n and everything below it is inside p
+				return
+			}
+			if n.has && !yield(n.prefix, n.value) {
+				return
+			}
+			n = n.child[bitAt(p.Addr(), n.prefix.Bits())]
+		}
+	}
+}
+
+// All returns an iterator over the prefixes in t and their values: IPv4
+// before IPv6, and within each family in order of address, with shorter
+// prefixes before the longer prefixes inside them.
+func (t *Tree[V]) All() iter.Seq2[netip.Prefix, V] {
+	return func(yield func(netip.Prefix, V) bool) {
+		_ = walk(t.v4, yield) && walk(t.v6, yield)
+	}
+}
+
+// walk yields the prefixes of the subtree n in preorder. It reports
+// whether iteration should continue.
+func walk[V any](n *node[V], yield func(netip.Prefix, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.has && !yield(n.prefix, n.value) {
+		return false
+	}
+	return walk(n.child[0], yield) && walk(n.child[1], yield)
+}
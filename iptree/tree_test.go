@@ -0,0 +1,201 @@
+package iptree
+
+import (
+	"math/rand"
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+func mustPrefixes(ss ...string) []netip.Prefix {
+	ps := make([]netip.Prefix, len(ss))
+	for i, s := range ss {
+		ps[i] = netip.MustParsePrefix(s)
+	}
+	return ps
+}
+
+func prefixes(seq func(func(netip.Prefix, int) bool)) []netip.Prefix {
+	var ps []netip.Prefix
+	for p := range seq {
+		ps = append(ps, p)
+	}
+	return ps
+}
+
+func TestTree(t *testing.T) {
+	var tr Tree[int]
+	if _, _, ok := tr.Lookup(netip.MustParseAddr("10.0.0.1")); ok {
+		t.Errorf("Lookup on empty tree reported true")
+	}
+	for i, p := range mustPrefixes("10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24", "192.168.0.0/16", "0.0.0.0/0", "2001:db8::/32") {
+		if !tr.Insert(p, i) {
+			t.Errorf("Insert(%v) reported false", p)
+		}
+	}
+	if tr.Insert(netip.MustParsePrefix("10.9.9.9/8"), 100) {
+		t.Errorf("Insert of existing prefix in non-canonical form reported true")
+	}
+	if v, ok := tr.Get(netip.MustParsePrefix("10.0.0.0/8")); !ok || v != 100 {
+		t.Errorf("Get(10.0.0.0/8) = %d, %v; want 100, true", v, ok)
+	}
+	if _, ok := tr.Get(netip.MustParsePrefix("10.1.0.0/15")); ok {
+		t.Errorf("Get(10.1.0.0/15) reported true")
+	}
+	if tr.MustGet(netip.MustParsePrefix("10.1.2.0/24")) != 2 || tr.GetOr(netip.MustParsePrefix("10.1.0.0/15"), -1) != -1 {
+		t.Errorf("MustGet/GetOr returned wrong values")
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("MustGet of missing prefix did not panic")
+			}
+		}()
+		tr.MustGet(netip.Prefix{})
+	}()
+	if tr.Len() != 6 {
+		t.Errorf("Len() = %d, want 6", tr.Len())
+	}
+
+	for _, tt := range []struct {
+		addr, want string
+	}{
+		{"10.1.2.3", "10.1.2.0/24"},
+		{"10.1.3.3", "10.1.0.0/16"},
+		{"10.2.0.0", "10.0.0.0/8"},
+		{"8.8.8.8", "0.0.0.0/0"},
+		{"2001:db8::1", "2001:db8::/32"},
+		{"::ffff:10.1.2.3", ""}, // IPv4-mapped addresses are IPv6
+		{"2001:db9::1", ""},
+	} {
+		p, _, ok := tr.Lookup(netip.MustParseAddr(tt.addr))
+		if got := ""; ok {
+			got = p.String()
+			if got != tt.want {
+				t.Errorf("Lookup(%s) = %s, want %s", tt.addr, got, tt.want)
+			}
+		} else if tt.want != "" {
+			t.Errorf("Lookup(%s) reported false, want %s", tt.addr, tt.want)
+		}
+	}
+
+	want := mustPrefixes("0.0.0.0/0", "10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24", "192.168.0.0/16", "2001:db8::/32")
+	if got := prefixes(tr.All()); !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	want = mustPrefixes("0.0.0.0/0", "10.0.0.0/8", "10.1.0.0/16", "10.1.2.0/24")
+	if got := prefixes(tr.Overlapping(netip.MustParsePrefix("10.1.0.0/16"))); !slices.Equal(got, want) {
+		t.Errorf("Overlapping(10.1.0.0/16) = %v, want %v", got, want)
+	}
+	if tr.Overlaps(netip.MustParsePrefix("2001:db9::/32")) {
+		t.Errorf("Overlaps(2001:db9::/32) reported true")
+	}
+
+	if !tr.Delete(netip.MustParsePrefix("10.1.0.0/16")) {
+		t.Errorf("Delete(10.1.0.0/16) reported false")
+	}
+	if tr.Delete(netip.MustParsePrefix("10.1.0.0/16")) {
+		t.Errorf("second Delete(10.1.0.0/16) reported true")
+	}
+	if p, _, _ := tr.Lookup(netip.MustParseAddr("10.1.3.3")); p.String() != "10.0.0.0/8" {
+		t.Errorf("Lookup(10.1.3.3) after Delete = %v, want 10.0.0.0/8", p)
+	}
+	if tr.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", tr.Len())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Insert of invalid prefix did not panic")
+		}
+	}()
+	tr.Insert(netip.Prefix{}, 0)
+}
+
+func randPrefix(r *rand.Rand) netip.Prefix {
+	// Few distinct leading bits, so that prefixes nest and collide often.
+	var a netip.Addr
+	if r.Intn(2) == 0 {
+		a = netip.AddrFrom4([4]byte{byte(r.Intn(4)), byte(r.Intn(256)), 0, byte(r.Intn(4))})
+	} else {
+		var b [16]byte
+		b[0], b[1], b[15] = 0x20, byte(r.Intn(4)), byte(r.Intn(256))
+		a = netip.AddrFrom16(b)
+	}
+	return netip.PrefixFrom(a, r.Intn(a.BitLen()+1)).Masked()
+}
+
+func TestTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var tr Tree[int]
+	ref := make(map[netip.Prefix]int)
+	for i := 0; i < 5000; i++ {
+		p := randPrefix(r)
+		switch r.Intn(3) {
+		case 0, 1:
+			_, had := ref[p]
+			if added := tr.Insert(p, i); added == had {
+				t.Fatalf("Insert(%v) = %v, want %v", p, added, !had)
+			}
+			ref[p] = i
+		case 2:
+			_, had := ref[p]
+			if deleted := tr.Delete(p); deleted != had {
+				t.Fatalf("Delete(%v) = %v, want %v", p, deleted, had)
+			}
+			delete(ref, p)
+		}
+	}
+	if tr.Len() != len(ref) {
+		t.Errorf("Len() = %d, want %d", tr.Len(), len(ref))
+	}
+	for p, v := range ref {
+		if got, ok := tr.Get(p); !ok || got != v {
+			t.Errorf("Get(%v) = %d, %v; want %d, true", p, got, ok, v)
+		}
+	}
+	want := make([]netip.Prefix, 0, len(ref))
+	for p := range ref {
+		want = append(want, p)
+	}
+	sortPrefixes(want)
+	if got := prefixes(tr.All()); !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+
+	for range 1000 {
+		q := randPrefix(r)
+		var best netip.Prefix
+		var overlapping []netip.Prefix
+		for p := range ref {
+			if p.Contains(q.Addr()) && (!best.IsValid() || p.Bits() > best.Bits()) {
+				best = p
+			}
+			if p.Overlaps(q) {
+				overlapping = append(overlapping, p)
+			}
+		}
+		p, _, ok := tr.Lookup(q.Addr())
+		if ok != best.IsValid() || ok && p != best {
+			t.Errorf("Lookup(%v) = %v, %v; want %v", q.Addr(), p, ok, best)
+		}
+		got := prefixes(tr.Overlapping(q))
+		sortPrefixes(got)
+		sortPrefixes(overlapping)
+		if !slices.Equal(got, overlapping) {
+			t.Errorf("Overlapping(%v) = %v, want %v", q, got, overlapping)
+		}
+		if want := len(overlapping) > 0; tr.Overlaps(q) != want {
+			t.Errorf("Overlaps(%v) = %v, want %v", q, !want, want)
+		}
+	}
+}
+
+func sortPrefixes(ps []netip.Prefix) {
+	slices.SortFunc(ps, func(a, b netip.Prefix) int {
+		if c := a.Addr().Compare(b.Addr()); c != 0 {
+			return c
+		}
+		return a.Bits() - b.Bits()
+	})
+}
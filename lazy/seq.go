@@ -0,0 +1,128 @@
+// Package lazy implements a memoizing wrapper for iterators.
+package lazy
+
+import (
+	"iter"
+	"sync"
+)
+
+// Seq is a sequence whose elements are produced on demand by an
+// underlying iterator and cached as they are produced. Each element is
+// generated at most once however many times the sequence is traversed,
+// and elements that have been produced can be accessed by index.
+//
+// The underlying iterator is advanced with iter.Pull. If a Seq is not
+// traversed to the end, call Stop to release it.
+//
+// A Seq is safe for concurrent use by multiple goroutines. The underlying
+// iterator runs with an internal lock held and must not call methods of
+// the Seq.
+type Seq[T any] struct {
+	mu    sync.Mutex
+	seq   iter.Seq[T]
+	next  func() (T, bool) // nil until first use, and after the end
+	stop  func()
+	cache []T
+	done  bool // seq is exhausted or stopped
+}
+
+// New returns a Seq over the elements of seq. Nothing is generated until
+// an element is requested.
+func New[T any](seq iter.Seq[T]) *Seq[T] {
+	return &Seq[T]{seq: seq}
+}
+
+// fill generates elements until there are more than i, or seq ends.
+// s.mu must be held.
+func (s *Seq[T]) fill(i int) {
+	for !s.done && len(s.cache) <= i {
+		if s.next == nil {
+			s.next, s.stop = iter.Pull(s.seq)
+		}
+		v, ok := s.next()
+		if !ok {
+			s.finish()
+			return
+		}
+		s.cache = append(s.cache, v)
+	}
+}
+
+// finish releases the underlying iterator. s.mu must be held.
+func (s *Seq[T]) finish() {
+	if s.stop != nil {
+		s.stop()
+	}
+	s.next, s.stop, s.seq = nil, nil, nil
+	s.done = true
+}
+
+// At returns the element at index i, generating elements up to it if
+// needed. The boolean is false if the sequence has fewer than i+1
+// elements, or was stopped before producing them.
+func (s *Seq[T]) At(i int) (T, bool) {
+	if i < 0 {
+		var zero T
+		return zero, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fill(i)
+	if i >= len(s.cache) {
+		var zero T
+		return zero, false
+	}
+	return s.cache[i], true
+}
+
+// Forced returns the number of elements generated so far. Indexes below
+// Forced are answered from the cache.
+func (s *Seq[T]) Forced() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.cache)
+}
+
+// Done reports whether the underlying iterator has ended or been stopped,
+// so that no more elements will be generated.
+func (s *Seq[T]) Done() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done
+}
+
+// Len generates all remaining elements and returns the length of the
+// sequence. It does not return if the sequence is infinite.
+func (s *Seq[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for !s.done {
+		s.fill(len(s.cache))
+	}
+	return len(s.cache)
+}
+
+// Stop releases the underlying iterator. Elements already generated stay
+// available; no more are generated.
+func (s *Seq[T]) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.done {
+		s.finish()
+	}
+}
+
+// All returns an iterator over the elements of s, from the start. It
+// replays cached elements and generates the rest as needed, so several
+// traversals, even interleaved ones, share one run of the underlying
+// iterator.
+func (s *Seq[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; ; i++ {
+			v, ok := s.At(i)
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package lazy
+
+import (
+	"iter"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// counting returns an iterator over 0, 1, ..., n-1, or an unbounded
+// sequence if n < 0, and a pointer to the number of elements generated.
+func counting(n int) (iter.Seq[int], *int) {
+	var generated int
+	return func(yield func(int) bool) {
+		for i := 0; n < 0 || i < n; i++ {
+			generated++
+			if !yield(i) {
+				return
+			}
+		}
+	}, &generated
+}
+
+func TestSeq(t *testing.T) {
+	seq, generated := counting(5)
+	s := New(seq)
+	if *generated != 0 || s.Forced() != 0 {
+		t.Errorf("New generated %d elements", *generated)
+	}
+	if v, ok := s.At(2); !ok || v != 2 {
+		t.Errorf("At(2) = %d, %v; want 2, true", v, ok)
+	}
+	if *generated != 3 || s.Forced() != 3 {
+		t.Errorf("after At(2): generated %d, Forced() = %d; want 3", *generated, s.Forced())
+	}
+	if v, _ := s.At(0); v != 0 || *generated != 3 {
+		t.Errorf("At(0) = %d, regenerated elements", v)
+	}
+	for range 3 {
+		if got := slices.Collect(s.All()); !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+			t.Errorf("All() = %v, want [0 1 2 3 4]", got)
+		}
+	}
+	if *generated != 5 || !s.Done() || s.Len() != 5 {
+		t.Errorf("generated %d, Done() = %v, Len() = %d; want 5, true, 5", *generated, s.Done(), s.Len())
+	}
+	if _, ok := s.At(5); ok {
+		t.Errorf("At(5) past the end reported true")
+	}
+	if _, ok := s.At(-1); ok {
+		t.Errorf("At(-1) reported true")
+	}
+}
+
+func TestSeqStop(t *testing.T) {
+	seq, generated := counting(-1)
+	s := New(seq)
+	for v := range s.All() {
+		if v == 9 {
+			break
+		}
+	}
+	s.Stop()
+	if !s.Done() || s.Forced() != 10 || *generated != 10 {
+		t.Errorf("after Stop: Done() = %v, Forced() = %d, generated %d", s.Done(), s.Forced(), *generated)
+	}
+	if v, ok := s.At(9); !ok || v != 9 {
+		t.Errorf("At(9) after Stop = %d, %v; want 9, true", v, ok)
+	}
+	if _, ok := s.At(10); ok {
+		t.Errorf("At(10) after Stop reported true")
+	}
+	if s.Len() != 10 {
+		t.Errorf("Len() after Stop = %d, want 10", s.Len())
+	}
+}
+
+func TestSeqConcurrent(t *testing.T) {
+	seq, generated := counting(1000)
+	s := New(seq)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sum := 0
+			for v := range s.All() {
+				sum += v
+			}
+			if sum != 999*1000/2 {
+				t.Errorf("sum = %d, want %d", sum, 999*1000/2)
+			}
+		}()
+	}
+	wg.Wait()
+	if *generated != 1000 {
+		t.Errorf("generated %d elements, want 1000", *generated)
+	}
+}
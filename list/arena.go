@@ -0,0 +1,90 @@
+package list
+
+// Arena is a pool of elements that lists can allocate from, so that
+// elements removed from a list are reused by later insertions instead of
+// being left to the garbage collector. An Arena may be shared by several
+// lists, for example the segments of a cache that moves entries between
+// them.
+//
+// Recycling changes the meaning of a removed element: once Remove
+// returns, the element may be handed out again by a later insertion into
+// any list using the arena, so callers must not keep or use pointers to
+// removed elements. Only use an Arena with lists whose removed elements
+// are dropped immediately.
+//
+// An Arena is not safe for concurrent use; lists sharing an Arena must be
+// used by one goroutine at a time.
+type Arena[T any] struct {
+	free *Element[T] // linked through next
+	n    int         // len(free list)
+	max  int
+}
+
+// NewArena returns an Arena that retains up to capacity free elements.
+// The elements are allocated up front, in a single block, so a list that
+// stays within capacity elements allocates nothing after NewArena.
+// NewArena panics if capacity is negative.
+func NewArena[T any](capacity int) *Arena[T] {
+	if capacity < 0 {
+		panic("list: NewArena with negative capacity")
+	}
+	a := &Arena[T]{max: capacity}
+	block := make([]Element[T], capacity)
+	for i := range block {
+		block[i].next = a.free
+		a.free = &block[i]
+	}
+	a.n = capacity
+	return a
+}
+
+// Free returns the number of elements available for reuse in a.
+func (a *Arena[T]) Free() int {
+	return a.n
+}
+
+// get returns a free element, or nil if there is none.
+func (a *Arena[T]) get() *Element[T] {
+	e := a.free
+	if e != nil {
+		a.free = e.next
+		e.next = nil
+		a.n--
+	}
+	return e
+}
+
+// put returns the removed element e to a, unless a is full.
+func (a *Arena[T]) put(e *Element[T]) {
+	if a.n >= a.max {
+		return
+	}
+	var zero T
+	e.Value = zero // drop the reference for the GC
+	e.next = a.free
+	a.free = e
+	a.n++
+}
+
+// NewWithArena returns an empty list that allocates its elements from a.
+func NewWithArena[T any](a *Arena[T]) *List[T] {
+	l := New[T]()
+	l.arena = a
+	return l
+}
+
+// SetArena makes l allocate its elements from a, and return removed
+// elements to it. A nil a restores ordinary allocation. See Arena for the
+// restrictions this places on removed elements.
+func (l *List[T]) SetArena(a *Arena[T]) {
+	l.arena = a
+}
+
+func (l *List[T]) newElement() *Element[T] {
+	if l.arena != nil {
+		if e := l.arena.get(); e != nil {
+			return e
+		}
+	}
+	return new(Element[T])
+}
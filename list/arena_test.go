@@ -0,0 +1,62 @@
+package list
+
+import "testing"
+
+func TestArena(t *testing.T) {
+	a := NewArena[int](2)
+	l := NewWithArena(a)
+	e1 := l.PushBack(1)
+	e2 := l.PushBack(2)
+	e3 := l.PushBack(3) // arena exhausted; allocated normally
+	if a.Free() != 0 {
+		t.Errorf("Free() = %d, want 0", a.Free())
+	}
+	checkListPointers(t, l, []*Element[int]{e1, e2, e3})
+
+	if v := l.Remove(e2); v != 2 {
+		t.Errorf("Remove(e2) = %d, want 2", v)
+	}
+	if a.Free() != 1 {
+		t.Errorf("Free() after Remove = %d, want 1", a.Free())
+	}
+	if e := l.PushFront(0); e != e2 {
+		t.Errorf("PushFront did not reuse the removed element")
+	}
+	checkList(t, l, []int{0, 1, 3})
+
+	// A second list shares the arena.
+	var m List[int]
+	m.SetArena(a)
+	l.Remove(e3)
+	l.Remove(e1)
+	l.Remove(e2)
+	if a.Free() != 2 {
+		t.Errorf("Free() = %d, want capacity 2", a.Free())
+	}
+	m.PushBack(7)
+	m.PushBack(8)
+	checkList(t, &m, []int{7, 8})
+	checkList(t, l, nil)
+
+	m.SetArena(nil)
+	m.Remove(m.Front())
+	if a.Free() != 0 {
+		t.Errorf("Free() = %d after Remove without arena, want 0", a.Free())
+	}
+}
+
+func BenchmarkChurn(b *testing.B) {
+	run := func(b *testing.B, l *List[int]) {
+		for i := range 1000 {
+			l.PushBack(i)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := range b.N {
+			l.Remove(l.Front())
+			l.PushBack(i)
+		}
+	}
+	b.Run("New", func(b *testing.B) { run(b, New[int]()) })
+	b.Run("Arena", func(b *testing.B) { run(b, NewWithArena(NewArena[int](16))) })
+}
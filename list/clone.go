@@ -0,0 +1,31 @@
+package list
+
+// Clone returns a new list holding the element values of l, in order.
+// The values are copied with ordinary assignment, so the copy is shallow.
+func (l *List[T]) Clone() *List[T] {
+	c := New[T]()
+	for e := l.Front(); e != nil; e = e.Next() {
+		c.PushBack(e.Value)
+	}
+	return c
+}
+
+// Equal reports whether a and b have the same length and eq reports true
+// for each pair of element values at the same position.
+func Equal[T any](a, b *List[T], eq func(T, T) bool) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for ea, eb := a.Front(), b.Front(); ea != nil; ea, eb = ea.Next(), eb.Next() {
+		if !eq(ea.Value, eb.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualOrdered reports whether a and b have the same length and equal
+// element values, compared with ==, at each position.
+func EqualOrdered[T comparable](a, b *List[T]) bool {
+	return Equal(a, b, func(x, y T) bool { return x == y })
+}
@@ -0,0 +1,45 @@
+package list
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	l := newIntList(1, 2, 3)
+	c := l.Clone()
+	checkList(t, c, []int{1, 2, 3})
+	c.PushBack(4)
+	c.Front().Value = 0
+	checkList(t, l, []int{1, 2, 3})
+
+	var zero List[int]
+	checkList(t, zero.Clone(), nil)
+}
+
+func TestEqual(t *testing.T) {
+	for _, tt := range []struct {
+		a, b []int
+		want bool
+	}{
+		{nil, nil, true},
+		{[]int{1, 2}, []int{1, 2}, true},
+		{[]int{1, 2}, []int{1, 3}, false},
+		{[]int{1, 2}, []int{1, 2, 3}, false},
+		{nil, []int{1}, false},
+	} {
+		if got := EqualOrdered(newIntList(tt.a...), newIntList(tt.b...)); got != tt.want {
+			t.Errorf("EqualOrdered(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	a := FromSlice([]string{"Go", "List"})
+	b := FromSlice([]string{"go", "list"})
+	if EqualOrdered(a, b) || !Equal(a, b, strings.EqualFold) {
+		t.Errorf("Equal with EqualFold did not match case-insensitively")
+	}
+	var zero List[string]
+	if !Equal(&zero, New[string](), strings.EqualFold) {
+		t.Errorf("zero and empty lists are not equal")
+	}
+}
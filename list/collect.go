@@ -0,0 +1,39 @@
+package list
+
+import "iter"
+
+// maxChunk bounds the number of elements allocated together by Collect.
+const maxChunk = 1024
+
+// Collect returns a new list holding the values yielded by seq, in order.
+// It is faster than pushing the values one at a time: elements are
+// allocated in chunks and linked directly. Because elements in a chunk
+// share an allocation, a chunk's memory is held until all of its elements
+// are unreachable.
+func Collect[T any](seq iter.Seq[T]) *List[T] {
+	l := New[T]()
+	var chunk []Element[T]
+	for v := range seq {
+		if len(chunk) == 0 {
+			// Grow chunks with the list, so the unused tail of the last
+			// chunk is at most about the size of the list.
+			chunk = make([]Element[T], min(max(l.size, 8), maxChunk))
+		}
+		l.linkBack(&chunk[0], v)
+		chunk = chunk[1:]
+	}
+	return l
+}
+
+// linkBack initializes e with value v and links it at the back of l,
+// which must be initialized.
+func (l *List[T]) linkBack(e *Element[T], v T) {
+	last := l.root.prev
+	e.Value = v
+	e.list = l
+	e.prev = last
+	e.next = &l.root
+	last.next = e
+	l.root.prev = e
+	l.size++
+}
@@ -0,0 +1,49 @@
+package list
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCollect(t *testing.T) {
+	for _, n := range []int{0, 1, 8, 9, 100, 3000} {
+		want := make([]int, n)
+		for i := range want {
+			want[i] = i
+		}
+		l := Collect(slices.Values(want))
+		checkList(t, l, want)
+		if n == 0 {
+			continue
+		}
+		// The list is fully functional.
+		l.Remove(l.Front())
+		l.PushFront(-1)
+		l.MoveToBack(l.Front())
+		want = append(want[1:], -1)
+		checkList(t, l, want)
+	}
+	checkList(t, FromSlice([]int{3, 1, 2}), []int{3, 1, 2})
+}
+
+func BenchmarkCollect(b *testing.B) {
+	vs := make([]int, 10000)
+	b.Run("PushBack", func(b *testing.B) {
+		for range b.N {
+			l := New[int]()
+			for _, v := range vs {
+				l.PushBack(v)
+			}
+		}
+	})
+	b.Run("Collect", func(b *testing.B) {
+		for range b.N {
+			Collect(slices.Values(vs))
+		}
+	})
+	b.Run("FromSlice", func(b *testing.B) {
+		for range b.N {
+			FromSlice(vs)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package list
+
+// Find returns the first element of l whose value satisfies pred, or nil
+// if there is none.
+func (l *List[T]) Find(pred func(T) bool) *Element[T] {
+	for e := l.Front(); e != nil; e = e.Next() {
+		if pred(e.Value) {
+			return e
+		}
+	}
+	return nil
+}
+
+// FindLast returns the last element of l whose value satisfies pred, or
+// nil if there is none.
+func (l *List[T]) FindLast(pred func(T) bool) *Element[T] {
+	for e := l.Back(); e != nil; e = e.Prev() {
+		if pred(e.Value) {
+			return e
+		}
+	}
+	return nil
+}
+
+// Contains reports whether v is the value of some element of l.
+func Contains[T comparable](l *List[T], v T) bool {
+	return IndexOf(l, v) >= 0
+}
+
+// IndexOf returns the position of the first element of l whose value is
+// v, counting from 0 at the front, or -1 if there is none.
+func IndexOf[T comparable](l *List[T], v T) int {
+	i := 0
+	for e := l.Front(); e != nil; e = e.Next() {
+		if e.Value == v {
+			return i
+		}
+		i++
+	}
+	return -1
+}
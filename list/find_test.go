@@ -0,0 +1,39 @@
+package list
+
+import "testing"
+
+func TestFind(t *testing.T) {
+	l := newIntList(1, 2, 3, 4, 3)
+	even := func(v int) bool { return v%2 == 0 }
+	if e := l.Find(even); e != l.Front().Next() {
+		t.Errorf("Find(even) = %v, want second element", e)
+	}
+	if e := l.FindLast(even); e != l.Back().Prev() {
+		t.Errorf("FindLast(even) = %v, want fourth element", e)
+	}
+	if e := l.Find(func(v int) bool { return v > 10 }); e != nil {
+		t.Errorf("Find(no match) = %v, want nil", e)
+	}
+	if e := l.FindLast(func(v int) bool { return v > 10 }); e != nil {
+		t.Errorf("FindLast(no match) = %v, want nil", e)
+	}
+
+	for _, tt := range []struct {
+		v, index int
+	}{{1, 0}, {3, 2}, {4, 3}, {5, -1}} {
+		if got := IndexOf(l, tt.v); got != tt.index {
+			t.Errorf("IndexOf(%d) = %d, want %d", tt.v, got, tt.index)
+		}
+		if got := Contains(l, tt.v); got != (tt.index >= 0) {
+			t.Errorf("Contains(%d) = %v, want %v", tt.v, got, tt.index >= 0)
+		}
+	}
+
+	var zero List[int]
+	if zero.Find(even) != nil || zero.FindLast(even) != nil {
+		t.Errorf("Find on empty list returned an element")
+	}
+	if IndexOf(&zero, 0) != -1 || Contains(&zero, 0) {
+		t.Errorf("IndexOf/Contains on empty list found a value")
+	}
+}
@@ -0,0 +1,33 @@
+package list
+
+import "iter"
+
+// Reader is the read-only part of a List. It does not expose elements,
+// whose Value fields are writable.
+type Reader[T any] interface {
+	Len() int
+	All() iter.Seq[T]
+	Backward() iter.Seq[T]
+}
+
+// Freeze makes l read-only and returns it as a Reader. After Freeze, any
+// method that would change l's contents or order panics, including when
+// called through a *List obtained by asserting the Reader. Writes to an
+// element's Value field cannot be detected. A frozen list stays frozen;
+// use Clone to get a mutable copy.
+func (l *List[T]) Freeze() Reader[T] {
+	l.lazyInit()
+	l.frozen = true
+	return l
+}
+
+// Frozen reports whether Freeze has been called on l.
+func (l *List[T]) Frozen() bool {
+	return l.frozen
+}
+
+func (l *List[T]) checkFrozen() {
+	if l.frozen {
+		panic("list: mutation of frozen List")
+	}
+}
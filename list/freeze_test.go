@@ -0,0 +1,67 @@
+package list
+
+import (
+	"slices"
+	"testing"
+)
+
+func mustPanic(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s on frozen list did not panic", name)
+		}
+	}()
+	f()
+}
+
+func TestFreeze(t *testing.T) {
+	l := newIntList(3, 1, 2)
+	r := l.Freeze()
+	if !l.Frozen() {
+		t.Errorf("Frozen() = false after Freeze")
+	}
+	if r.Len() != 3 || !slices.Equal(slices.Collect(r.All()), []int{3, 1, 2}) {
+		t.Errorf("Reader contents = %v, want [3 1 2]", slices.Collect(r.All()))
+	}
+	if got := slices.Collect(r.Backward()); !slices.Equal(got, []int{2, 1, 3}) {
+		t.Errorf("Reader Backward() = %v, want [2 1 3]", got)
+	}
+
+	other := newIntList(4)
+	e := l.Front()
+	for _, tt := range []struct {
+		name string
+		f    func()
+	}{
+		{"PushBack", func() { l.PushBack(0) }},
+		{"PushFront", func() { l.PushFront(0) }},
+		{"InsertAfter", func() { l.InsertAfter(0, e) }},
+		{"Remove", func() { l.Remove(e) }},
+		{"MoveToBack", func() { l.MoveToBack(e) }},
+		{"Init", func() { l.Init() }},
+		{"Sort", func() { SortOrdered(l) }},
+		{"SpliceBack", func() { l.SpliceBack(other) }},
+		{"SpliceBack from frozen", func() { other.SpliceBack(l) }},
+		{"PushBackList", func() { l.PushBackList(other) }},
+	} {
+		mustPanic(t, tt.name, tt.f)
+	}
+	checkList(t, l, []int{3, 1, 2})
+	checkList(t, other, []int{4})
+
+	// Reading the frozen list into another is allowed, and clones thaw.
+	other.PushBackList(l)
+	checkList(t, other, []int{4, 3, 1, 2})
+	c := l.Clone()
+	c.PushBack(9)
+	checkList(t, c, []int{3, 1, 2, 9})
+	if c.Frozen() {
+		t.Errorf("Clone of frozen list is frozen")
+	}
+
+	var zero List[int]
+	zero.Freeze()
+	mustPanic(t, "PushBack on zero", func() { zero.PushBack(1) })
+	checkList(t, &zero, nil)
+}
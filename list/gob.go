@@ -0,0 +1,46 @@
+package list
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// GobEncode implements gob.GobEncoder by encoding the element values of
+// l, front to back, as a gob-encoded []T. T must itself be encodable by
+// gob: channel and function values cannot be encoded, and if T is an
+// interface type, the concrete types stored in it must be registered with
+// gob.Register. Otherwise GobEncode returns gob's error.
+func (l *List[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(l.AppendTo(make([]T, 0, l.Len()))); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder by replacing the contents of l with
+// the values in data, which must have been produced by GobEncode or be a
+// gob-encoded slice of T.
+func (l *List[T]) GobDecode(data []byte) error {
+	var vs []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&vs); err != nil {
+		return err
+	}
+	l.Init()
+	for _, v := range vs {
+		l.PushBack(v)
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. It uses the same
+// gob-based format as GobEncode, with the same requirements on T.
+func (l *List[T]) MarshalBinary() ([]byte, error) {
+	return l.GobEncode()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It accepts the
+// output of MarshalBinary or GobEncode.
+func (l *List[T]) UnmarshalBinary(data []byte) error {
+	return l.GobDecode(data)
+}
@@ -0,0 +1,55 @@
+package list
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*List[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*List[int])(nil)
+)
+
+func TestGob(t *testing.T) {
+	type message struct {
+		Name  string
+		Items *List[int]
+	}
+	var buf bytes.Buffer
+	in := message{Name: "m", Items: newIntList(3, 1, 2)}
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode error: %v", err)
+	}
+	var out message
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+	if out.Name != "m" {
+		t.Errorf("Name = %q, want m", out.Name)
+	}
+	checkList(t, out.Items, []int{3, 1, 2})
+
+	// Decoding replaces existing contents, including with an empty list.
+	b, err := New[int]().MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary of empty list error: %v", err)
+	}
+	if err := out.Items.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary error: %v", err)
+	}
+	checkList(t, out.Items, nil)
+
+	if err := out.Items.UnmarshalBinary([]byte("junk")); err == nil {
+		t.Errorf("UnmarshalBinary of junk succeeded")
+	}
+}
+
+func TestGobUnencodable(t *testing.T) {
+	l := New[func()]()
+	l.PushBack(func() {})
+	if _, err := l.GobEncode(); err == nil {
+		t.Errorf("GobEncode of a list of funcs succeeded")
+	}
+}
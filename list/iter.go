@@ -0,0 +1,90 @@
+package list
+
+import "iter"
+
+// All returns an iterator over the element values of l, front to back.
+// It is safe to remove the current element during iteration.
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range l.Elements() {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the element values of l, back to
+// front. It is safe to remove the current element during iteration.
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var prev *Element[T]
+		for e := l.Back(); e != nil; e = prev {
+			prev = e.Prev()
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Elements returns an iterator over the elements of l, front to back.
+// It is safe to remove the current element during iteration.
+func (l *List[T]) Elements() iter.Seq[*Element[T]] {
+	return func(yield func(*Element[T]) bool) {
+		var next *Element[T]
+		for e := l.Front(); e != nil; e = next {
+			next = e.Next()
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Pairs returns an iterator over each pair of adjacent element values in
+// l, front to back. A list with fewer than two elements yields no pairs.
+func Pairs[T any](l *List[T]) iter.Seq2[T, T] {
+	return func(yield func(T, T) bool) {
+		e := l.Front()
+		if e == nil {
+			return
+		}
+		for next := e.Next(); next != nil; e, next = next, next.Next() {
+			if !yield(e.Value, next.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Windows returns an iterator over each run of n consecutive element
+// values in l, front to back. A list with fewer than n elements yields no
+// windows. The yielded slice is reused between iterations; callers that
+// retain it must copy it. Windows panics if n is less than 1.
+func Windows[T any](l *List[T], n int) iter.Seq[[]T] {
+	if n < 1 {
+		panic("list: Windows with n < 1")
+	}
+	return func(yield func([]T) bool) {
+		if l.Len() < n {
+			return
+		}
+		buf := make([]T, n)
+		e := l.Front()
+		for i := range buf {
+			buf[i] = e.Value
+			e = e.Next()
+		}
+		if !yield(buf) {
+			return
+		}
+		for ; e != nil; e = e.Next() {
+			copy(buf, buf[1:])
+			buf[n-1] = e.Value
+			if !yield(buf) {
+				return
+			}
+		}
+	}
+}
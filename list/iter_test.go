@@ -0,0 +1,127 @@
+package list
+
+import (
+	"slices"
+	"testing"
+)
+
+func newIntList(vs ...int) *List[int] {
+	l := New[int]()
+	for _, v := range vs {
+		l.PushBack(v)
+	}
+	return l
+}
+
+func TestAll(t *testing.T) {
+	l := newIntList(1, 2, 3, 4)
+	if got := slices.Collect(l.All()); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("All() = %v, want [1 2 3 4]", got)
+	}
+	if got := slices.Collect(l.Backward()); !slices.Equal(got, []int{4, 3, 2, 1}) {
+		t.Errorf("Backward() = %v, want [4 3 2 1]", got)
+	}
+	var zero List[int]
+	if got := slices.Collect(zero.All()); len(got) != 0 {
+		t.Errorf("All() on zero List = %v", got)
+	}
+
+	var got []int
+	for v := range l.All() {
+		got = append(got, v)
+		if v == 2 {
+			break
+		}
+	}
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("All() with break = %v, want [1 2]", got)
+	}
+}
+
+func TestElementsRemove(t *testing.T) {
+	l := newIntList(1, 2, 3, 4, 5)
+	for e := range l.Elements() {
+		if e.Value%2 == 1 {
+			l.Remove(e)
+		}
+	}
+	checkList(t, l, []int{2, 4})
+
+	l = newIntList(1, 2, 3)
+	var seen []int
+	for e := range l.Elements() {
+		seen = append(seen, e.Value)
+		l.Remove(e)
+	}
+	if !slices.Equal(seen, []int{1, 2, 3}) {
+		t.Errorf("visited %v while removing, want [1 2 3]", seen)
+	}
+	checkList(t, l, nil)
+
+	l = newIntList(1, 2, 3)
+	seen = seen[:0]
+	for v := range l.Backward() {
+		seen = append(seen, v)
+		l.Remove(l.Back())
+	}
+	if !slices.Equal(seen, []int{3, 2, 1}) {
+		t.Errorf("Backward visited %v while removing, want [3 2 1]", seen)
+	}
+}
+
+func TestPairs(t *testing.T) {
+	for _, tt := range []struct {
+		in   []int
+		want [][2]int
+	}{
+		{nil, nil},
+		{[]int{1}, nil},
+		{[]int{1, 2}, [][2]int{{1, 2}}},
+		{[]int{1, 2, 4, 7}, [][2]int{{1, 2}, {2, 4}, {4, 7}}},
+	} {
+		var got [][2]int
+		for a, b := range Pairs(newIntList(tt.in...)) {
+			got = append(got, [2]int{a, b})
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Pairs(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	n := 0
+	for range Pairs(newIntList(1, 2, 3, 4)) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("Pairs did not stop after break")
+	}
+}
+
+func TestWindows(t *testing.T) {
+	l := newIntList(1, 2, 3, 4, 5)
+	for _, tt := range []struct {
+		n    int
+		want [][]int
+	}{
+		{1, [][]int{{1}, {2}, {3}, {4}, {5}}},
+		{3, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}},
+		{5, [][]int{{1, 2, 3, 4, 5}}},
+		{6, nil},
+	} {
+		var got [][]int
+		for w := range Windows(l, tt.n) {
+			got = append(got, slices.Clone(w))
+		}
+		if !slices.EqualFunc(got, tt.want, slices.Equal) {
+			t.Errorf("Windows(l, %d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Windows(l, 0) did not panic")
+		}
+	}()
+	Windows(l, 0)
+}
@@ -0,0 +1,23 @@
+package list
+
+import "encoding/json"
+
+// MarshalJSON encodes l as a JSON array of its element values, front to
+// back. An empty list encodes as [].
+func (l *List[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.AppendTo(make([]T, 0, l.Len())))
+}
+
+// UnmarshalJSON replaces the contents of l with the elements of a JSON
+// array, in order. JSON null leaves l empty.
+func (l *List[T]) UnmarshalJSON(data []byte) error {
+	var vs []T
+	if err := json.Unmarshal(data, &vs); err != nil {
+		return err
+	}
+	l.Init()
+	for _, v := range vs {
+		l.PushBack(v)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package list
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	type response struct {
+		IDs  *List[int]   `json:"ids"`
+		Tags List[string] `json:"tags"`
+	}
+	r := response{IDs: newIntList(3, 1, 2)}
+	r.Tags.PushBack("x")
+	b, err := json.Marshal(&r)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if want := `{"ids":[3,1,2],"tags":["x"]}`; string(b) != want {
+		t.Errorf("Marshal = %s, want %s", b, want)
+	}
+	if b, _ := json.Marshal(New[int]()); string(b) != "[]" {
+		t.Errorf("Marshal of empty list = %s, want []", b)
+	}
+
+	var got response
+	if err := json.Unmarshal([]byte(`{"ids":[5,4],"tags":["a","b"]}`), &got); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	checkList(t, got.IDs, []int{5, 4})
+	if tags := got.Tags.ToSlice(); len(tags) != 2 || tags[0] != "a" || tags[1] != "b" {
+		t.Errorf("Unmarshal tags = %v, want [a b]", tags)
+	}
+
+	// Unmarshal replaces existing contents.
+	l := newIntList(9, 9)
+	if err := json.Unmarshal([]byte(`[1]`), l); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	checkList(t, l, []int{1})
+	if err := json.Unmarshal([]byte(`null`), l); err != nil {
+		t.Fatalf("Unmarshal null error: %v", err)
+	}
+	checkList(t, l, nil)
+	if err := json.Unmarshal([]byte(`{"a":1}`), l); err == nil {
+		t.Errorf("Unmarshal of an object succeeded")
+	}
+}
@@ -31,8 +31,11 @@ func (e *Element[T]) Prev() *Element[T] {
 }
 
 type List[T any] struct {
-	root Element[T]
-	size int
+	root   Element[T]
+	size   int
+	frozen bool
+	strict bool
+	arena  *Arena[T]
 }
 
 func New[T any]() *List[T] {
@@ -54,6 +57,7 @@ func (l *List[T]) Front() *Element[T] {
 }
 
 func (l *List[T]) Init() *List[T] {
+	l.checkFrozen()
 	l.root.prev = &l.root
 	l.root.next = &l.root
 	l.size = 0
@@ -61,15 +65,18 @@ func (l *List[T]) Init() *List[T] {
 }
 
 func (l *List[T]) insertValueAfter(v T, mark *Element[T]) *Element[T] {
-	e := Element[T]{prev: mark, next: mark.next, Value: v, list: l}
-	mark.next.prev = &e
-	mark.next = &e
+	l.checkFrozen()
+	e := l.newElement()
+	*e = Element[T]{prev: mark, next: mark.next, Value: v, list: l}
+	mark.next.prev = e
+	mark.next = e
 	l.size++
-	return &e
+	return e
 }
 
 func (l *List[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
 	if mark.list != l {
+		l.misuse("InsertAfter")
 		return nil
 	}
 	return l.insertValueAfter(v, mark)
@@ -77,6 +84,7 @@ func (l *List[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
 
 func (l *List[T]) InsertBefore(v T, mark *Element[T]) *Element[T] {
 	if mark.list != l {
+		l.misuse("InsertBefore")
 		return nil
 	}
 	return l.insertValueAfter(v, mark.prev)
@@ -90,6 +98,7 @@ func (l *List[T]) moveAfter(e, mark *Element[T]) {
 	if e == mark {
 		return
 	}
+	l.checkFrozen()
 	// fixup around old |e| position.
 	e.prev.next = e.next
 	e.next.prev = e.prev
@@ -102,28 +111,44 @@ func (l *List[T]) moveAfter(e, mark *Element[T]) {
 }
 
 func (l *List[T]) MoveAfter(e, mark *Element[T]) {
-	if e.list != l || mark.list != l || e == mark {
+	if e.list != l || mark.list != l {
+		l.misuse("MoveAfter")
+		return
+	}
+	if e == mark {
 		return
 	}
 	l.moveAfter(e, mark)
 }
 
 func (l *List[T]) MoveBefore(e, mark *Element[T]) {
-	if e.list != l || mark.list != l || e == mark {
+	if e.list != l || mark.list != l {
+		l.misuse("MoveBefore")
+		return
+	}
+	if e == mark {
 		return
 	}
 	l.moveAfter(e, mark.prev)
 }
 
 func (l *List[T]) MoveToBack(e *Element[T]) {
-	if e.list != l || l.root.prev == e {
+	if e.list != l {
+		l.misuse("MoveToBack")
+		return
+	}
+	if l.root.prev == e {
 		return
 	}
 	l.moveAfter(e, l.root.prev)
 }
 
 func (l *List[T]) MoveToFront(e *Element[T]) {
-	if e.list != l || l.root.next == e {
+	if e.list != l {
+		l.misuse("MoveToFront")
+		return
+	}
+	if l.root.next == e {
 		return
 	}
 	l.moveAfter(e, &l.root)
@@ -165,15 +190,21 @@ func (l *List[T]) PushFrontList(other *List[T]) {
 
 func (l *List[T]) Remove(e *Element[T]) T {
 	if e.list != l {
+		l.misuse("Remove")
 		return e.Value
 	}
+	l.checkFrozen()
 	e.prev.next = e.next
 	e.next.prev = e.prev
 	e.prev = nil
 	e.next = nil
 	e.list = nil
 	l.size--
-	return e.Value
+	v := e.Value
+	if l.arena != nil {
+		l.arena.put(e)
+	}
+	return v
 }
 
 // lazyInit lazily initializes a zero List value.
@@ -0,0 +1,55 @@
+package list
+
+// MoveToIndex moves e so that it is at position i of l, counting from 0
+// at the front, and returns a function that undoes the move by returning
+// e to the position it had before. Undo functions called in the reverse
+// order of their moves restore l's original order, so a stack of them is
+// enough to implement undo for reordering; no snapshot of l is needed.
+// An undo after e has been removed from l does nothing, and one whose old
+// position is past the end of a shrunken l moves e to the back.
+//
+// MoveToIndex is O(n). It panics if i is out of range. If e is not an
+// element of l, it does nothing, or panics in strict mode, and the
+// returned function does nothing.
+func (l *List[T]) MoveToIndex(e *Element[T], i int) (undo func()) {
+	if e.list != l {
+		l.misuse("MoveToIndex")
+		return func() {}
+	}
+	if i < 0 || i >= l.size {
+		panic("list: MoveToIndex index out of range")
+	}
+	from := l.position(e)
+	l.moveToIndex(e, from, i)
+	return func() {
+		if e.list != l {
+			return
+		}
+		l.moveToIndex(e, l.position(e), min(from, l.size-1))
+	}
+}
+
+// position returns the position of e, which must be an element of l.
+func (l *List[T]) position(e *Element[T]) int {
+	i := 0
+	for p := l.root.next; p != e; p = p.next {
+		i++
+	}
+	return i
+}
+
+// moveToIndex moves e from position from to position to.
+func (l *List[T]) moveToIndex(e *Element[T], from, to int) {
+	if from == to {
+		return
+	}
+	target := l.root.next
+	for range to {
+		target = target.next
+	}
+	if to < from {
+		l.moveAfter(e, target.prev)
+	} else {
+		l.moveAfter(e, target)
+	}
+}
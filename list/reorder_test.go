@@ -0,0 +1,75 @@
+package list
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMoveToIndex(t *testing.T) {
+	l := newIntList(0, 1, 2, 3, 4)
+	es := slices.Collect(l.Elements())
+	undo1 := l.MoveToIndex(es[0], 3)
+	checkList(t, l, []int{1, 2, 3, 0, 4})
+	undo2 := l.MoveToIndex(es[4], 0)
+	checkList(t, l, []int{4, 1, 2, 3, 0})
+	undo3 := l.MoveToIndex(es[2], 2) // already there
+	checkList(t, l, []int{4, 1, 2, 3, 0})
+	undo3()
+	undo2()
+	checkList(t, l, []int{1, 2, 3, 0, 4})
+	undo1()
+	checkListPointers(t, l, es)
+
+	// Undo after removal does nothing; undo past the end clamps.
+	undo := l.MoveToIndex(es[4], 0)
+	l.Remove(es[4])
+	undo()
+	checkList(t, l, []int{0, 1, 2, 3})
+	undo = l.MoveToIndex(es[3], 0)
+	l.Remove(es[1])
+	l.Remove(es[2])
+	checkList(t, l, []int{3, 0})
+	undo()
+	checkList(t, l, []int{0, 3})
+
+	other := newIntList(7)
+	l.MoveToIndex(other.Front(), 0)()
+	checkList(t, l, []int{0, 3})
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MoveToIndex out of range did not panic")
+		}
+	}()
+	l.MoveToIndex(l.Front(), 2)
+}
+
+func TestMoveToIndexRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const n = 20
+	vs := make([]int, n)
+	for i := range vs {
+		vs[i] = i
+	}
+	l := FromSlice(vs)
+	ref := slices.Clone(vs)
+	var undos []func()
+	var history [][]int
+	for range 200 {
+		history = append(history, slices.Clone(ref))
+		from, to := r.Intn(n), r.Intn(n)
+		e := l.Front()
+		for range from {
+			e = e.Next()
+		}
+		undos = append(undos, l.MoveToIndex(e, to))
+		v := ref[from]
+		ref = slices.Insert(slices.Delete(ref, from, from+1), to, v)
+		checkList(t, l, ref)
+	}
+	for i := len(undos) - 1; i >= 0; i-- {
+		undos[i]()
+		checkList(t, l, history[i])
+	}
+}
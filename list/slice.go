@@ -0,0 +1,32 @@
+package list
+
+// FromSlice returns a new list holding the elements of s, in order. Each
+// element is allocated separately, so removed elements can be freed one
+// at a time. For faster construction at the cost of holding memory until
+// a whole chunk of elements is unreachable, use Collect(slices.Values(s)).
+func FromSlice[T any](s []T) *List[T] {
+	l := New[T]()
+	for _, v := range s {
+		l.PushBack(v)
+	}
+	return l
+}
+
+// ToSlice returns a new slice holding the element values of l, front to
+// back. It returns nil if l is empty.
+func (l *List[T]) ToSlice() []T {
+	if l.Len() == 0 {
+		return nil
+	}
+	return l.AppendTo(make([]T, 0, l.Len()))
+}
+
+// AppendTo appends the element values of l, front to back, to dst and
+// returns the extended slice. Passing a reused buffer, such as buf[:0],
+// avoids allocating when it has enough capacity.
+func (l *List[T]) AppendTo(dst []T) []T {
+	for e := l.Front(); e != nil; e = e.Next() {
+		dst = append(dst, e.Value)
+	}
+	return dst
+}
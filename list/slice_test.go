@@ -0,0 +1,33 @@
+package list
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSliceConversions(t *testing.T) {
+	l := FromSlice([]int{1, 2, 3})
+	checkList(t, l, []int{1, 2, 3})
+	if got := l.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("ToSlice() = %v, want [1 2 3]", got)
+	}
+	if got := FromSlice[int](nil).ToSlice(); got != nil {
+		t.Errorf("ToSlice() of empty list = %v, want nil", got)
+	}
+	var zero List[int]
+	if got := zero.ToSlice(); got != nil {
+		t.Errorf("ToSlice() of zero List = %v, want nil", got)
+	}
+
+	buf := make([]int, 0, 8)
+	got := l.AppendTo(buf)
+	if !slices.Equal(got, []int{1, 2, 3}) || &got[0] != &buf[:1][0] {
+		t.Errorf("AppendTo(buf) = %v, did not reuse buf", got)
+	}
+	if got := l.AppendTo([]int{0}); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Errorf("AppendTo([0]) = %v, want [0 1 2 3]", got)
+	}
+	if n := testing.AllocsPerRun(10, func() { got = l.AppendTo(buf[:0]) }); n != 0 {
+		t.Errorf("AppendTo into a large enough buffer allocated %v times", n)
+	}
+}
@@ -0,0 +1,87 @@
+package list
+
+import "cmp"
+
+// Sort sorts l in place according to less, using a stable merge sort on
+// the links. Elements are relinked rather than copied, so existing
+// *Element pointers remain valid and keep their values. Sort is
+// O(n log n) and allocates nothing.
+func (l *List[T]) Sort(less func(a, b T) bool) {
+	l.checkFrozen()
+	if l.size < 2 {
+		return
+	}
+	// bins[i] is nil or a sorted, nil-terminated run of 2^i elements;
+	// higher bins hold earlier elements.
+	var bins [64]*Element[T]
+	l.root.prev.next = nil
+	for e := l.root.next; e != nil; {
+		next := e.next
+		e.next = nil
+		carry := e
+		i := 0
+		for ; bins[i] != nil; i++ {
+			carry = mergeRuns(bins[i], carry, less)
+			bins[i] = nil
+		}
+		bins[i] = carry
+		e = next
+	}
+	var head *Element[T]
+	for _, run := range bins {
+		if run != nil {
+			head = mergeRuns(run, head, less)
+		}
+	}
+
+	prev := &l.root
+	for e := head; e != nil; e = e.next {
+		e.prev = prev
+		prev.next = e
+		prev = e
+	}
+	prev.next = &l.root
+	l.root.prev = prev
+}
+
+// mergeRuns merges the sorted, nil-terminated runs a and b, where a holds
+// earlier elements than b, keeping equal elements in their original order.
+// Only next links are set.
+func mergeRuns[T any](a, b *Element[T], less func(a, b T) bool) *Element[T] {
+	var head Element[T]
+	tail := &head
+	for a != nil && b != nil {
+		if less(b.Value, a.Value) {
+			tail.next, b = b, b.next
+		} else {
+			tail.next, a = a, a.next
+		}
+		tail = tail.next
+	}
+	if a != nil {
+		tail.next = a
+	} else {
+		tail.next = b
+	}
+	return head.next
+}
+
+// SortOrdered sorts l in place in ascending order. It is like l.Sort with
+// cmp.Less.
+func SortOrdered[T cmp.Ordered](l *List[T]) {
+	l.Sort(cmp.Less[T])
+}
+
+// InsertOrdered inserts a new element with value v into l, which must be
+// sorted according to less, and returns it. v is placed after any
+// elements equal to it, so repeated insertion keeps l stably sorted. The
+// scan starts at the back, making appends of increasing values O(1);
+// otherwise InsertOrdered is O(n).
+func (l *List[T]) InsertOrdered(v T, less func(a, b T) bool) *Element[T] {
+	l.lazyInit()
+	mark := l.root.prev
+	for mark != &l.root && less(v, mark.Value) {
+		mark = mark.prev
+	}
+	return l.insertValueAfter(v, mark)
+}
@@ -0,0 +1,77 @@
+package list
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	var zero List[int]
+	zero.Sort(func(a, b int) bool { return a < b })
+	checkList(t, &zero, nil)
+
+	l := newIntList(5, 2, 8, 1, 9, 3)
+	es := slices.Collect(l.Elements())
+	SortOrdered(l)
+	checkList(t, l, []int{1, 2, 3, 5, 8, 9})
+	checkListPointers(t, l, []*Element[int]{es[3], es[1], es[5], es[0], es[2], es[4]})
+
+	l.Sort(func(a, b int) bool { return a > b })
+	checkList(t, l, []int{9, 8, 5, 3, 2, 1})
+	l.Remove(es[0])
+	l.PushBack(4)
+	SortOrdered(l)
+	checkList(t, l, []int{1, 2, 3, 4, 8, 9})
+}
+
+func TestSortStable(t *testing.T) {
+	type pair struct{ key, seq int }
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{2, 3, 17, 100, 1000} {
+		l := New[pair]()
+		var ref []pair
+		for i := 0; i < n; i++ {
+			p := pair{r.Intn(10), i}
+			l.PushBack(p)
+			ref = append(ref, p)
+		}
+		byKey := func(a, b pair) int { return a.key - b.key }
+		slices.SortStableFunc(ref, byKey)
+		l.Sort(func(a, b pair) bool { return a.key < b.key })
+		if got := slices.Collect(l.All()); !slices.Equal(got, ref) {
+			t.Errorf("Sort of %d elements is not stable", n)
+		}
+		slices.Reverse(ref)
+		if got := slices.Collect(l.Backward()); !slices.Equal(got, ref) {
+			t.Errorf("Sort of %d elements left bad prev links", n)
+		}
+	}
+}
+
+func TestInsertOrdered(t *testing.T) {
+	type pair struct{ key, seq int }
+	less := func(a, b pair) bool { return a.key < b.key }
+	r := rand.New(rand.NewSource(2))
+	var l List[pair]
+	var ref []pair
+	for i := 0; i < 200; i++ {
+		p := pair{r.Intn(20), i}
+		e := l.InsertOrdered(p, less)
+		if e.Value != p {
+			t.Fatalf("InsertOrdered returned element with %v, want %v", e.Value, p)
+		}
+		ref = append(ref, p)
+	}
+	slices.SortStableFunc(ref, func(a, b pair) int { return a.key - b.key })
+	if got := slices.Collect(l.All()); !slices.Equal(got, ref) {
+		t.Errorf("after InsertOrdered, l = %v, want %v", got, ref)
+	}
+
+	ints := newIntList(1, 3, 5)
+	ints.InsertOrdered(0, cmp.Less[int])
+	ints.InsertOrdered(4, cmp.Less[int])
+	ints.InsertOrdered(6, cmp.Less[int])
+	checkList(t, ints, []int{0, 1, 3, 4, 5, 6})
+}
@@ -0,0 +1,45 @@
+package list
+
+// SpliceBack moves all elements of other to the back of l, in order,
+// leaving other empty. Unlike PushBackList, the elements themselves are
+// moved: no values are copied and no elements are allocated, so pointers
+// to other's elements remain valid and now belong to l. The links are
+// rewired in constant time, but each moved element's owner is updated,
+// so SpliceBack takes time proportional to other.Len(). If other is l,
+// SpliceBack does nothing.
+func (l *List[T]) SpliceBack(other *List[T]) {
+	l.lazyInit()
+	l.splice(other, l.root.prev)
+}
+
+// SpliceAfter moves all elements of other into l immediately after mark,
+// in order, leaving other empty. As with SpliceBack, element identities
+// are preserved. If mark is not an element of l, or other is l,
+// SpliceAfter does nothing; in strict mode a foreign mark panics.
+func (l *List[T]) SpliceAfter(other *List[T], mark *Element[T]) {
+	if mark.list != l {
+		l.misuse("SpliceAfter")
+		return
+	}
+	l.splice(other, mark)
+}
+
+// splice relinks the elements of other after mark, which must be l.root
+// or an element of l.
+func (l *List[T]) splice(other *List[T], mark *Element[T]) {
+	l.checkFrozen()
+	other.checkFrozen()
+	if other == l || other.size == 0 {
+		return
+	}
+	first, last := other.root.next, other.root.prev
+	for e := first; e != &other.root; e = e.next {
+		e.list = l
+	}
+	first.prev = mark
+	last.next = mark.next
+	mark.next.prev = last
+	mark.next = first
+	l.size += other.size
+	other.Init()
+}
@@ -0,0 +1,64 @@
+package list
+
+import "testing"
+
+func elements[T any](l *List[T]) []*Element[T] {
+	var es []*Element[T]
+	for e := l.Front(); e != nil; e = e.Next() {
+		es = append(es, e)
+	}
+	return es
+}
+
+func TestSpliceBack(t *testing.T) {
+	l := newIntList(1, 2)
+	other := newIntList(3, 4, 5)
+	want := append(elements(l), elements(other)...)
+	l.SpliceBack(other)
+	checkListPointers(t, l, want)
+	checkList(t, l, []int{1, 2, 3, 4, 5})
+	checkListPointers(t, other, []*Element[int]{})
+
+	// Moved elements belong to l now.
+	l.Remove(want[3])
+	checkList(t, l, []int{1, 2, 3, 5})
+	if other.Remove(want[2]) != 3 || l.Len() != 4 {
+		t.Errorf("Remove via the old list affected l")
+	}
+
+	// Into a zero list, from an empty list, and onto itself.
+	var z List[int]
+	z.SpliceBack(l)
+	checkList(t, &z, []int{1, 2, 3, 5})
+	z.SpliceBack(other)
+	z.SpliceBack(&z)
+	checkList(t, &z, []int{1, 2, 3, 5})
+
+	// other is reusable.
+	l.PushBack(9)
+	checkList(t, l, []int{9})
+}
+
+func TestSpliceAfter(t *testing.T) {
+	l := newIntList(1, 4)
+	other := newIntList(2, 3)
+	a, b := elements(l), elements(other)
+	l.SpliceAfter(other, a[0])
+	checkListPointers(t, l, []*Element[int]{a[0], b[0], b[1], a[1]})
+	checkListPointers(t, other, []*Element[int]{})
+
+	other = newIntList(5)
+	l.SpliceAfter(other, l.Back())
+	checkList(t, l, []int{1, 2, 3, 4, 5})
+
+	// A mark from another list is ignored.
+	m := newIntList(0)
+	other = newIntList(6)
+	l.SpliceAfter(other, m.Front())
+	checkList(t, l, []int{1, 2, 3, 4, 5})
+	checkList(t, other, []int{6})
+	checkList(t, m, []int{0})
+
+	l.SpliceAfter(l, l.Front())
+	checkList(t, l, []int{1, 2, 3, 4, 5})
+}
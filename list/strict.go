@@ -0,0 +1,23 @@
+package list
+
+// SetStrict turns strict mode on or off for l. By default, as in
+// container/list, methods given an element that does not belong to l
+// silently do nothing: InsertAfter and InsertBefore return nil, and Move
+// methods and Remove leave l unchanged. In strict mode those calls panic
+// instead, so that mixing up lists shows up as a failure rather than as
+// lost data.
+func (l *List[T]) SetStrict(strict bool) {
+	l.strict = strict
+}
+
+// Strict reports whether l is in strict mode.
+func (l *List[T]) Strict() bool {
+	return l.strict
+}
+
+// misuse reports a call to op with an element that does not belong to l.
+func (l *List[T]) misuse(op string) {
+	if l.strict {
+		panic("list: " + op + " with element not in list")
+	}
+}
@@ -0,0 +1,55 @@
+package list
+
+import "testing"
+
+func TestStrict(t *testing.T) {
+	l := newIntList(1, 2)
+	other := newIntList(9)
+	foreign := other.Front()
+	removed := l.PushBack(3)
+	l.Remove(removed)
+
+	calls := []struct {
+		name string
+		f    func()
+	}{
+		{"InsertAfter", func() { l.InsertAfter(0, foreign) }},
+		{"InsertBefore", func() { l.InsertBefore(0, foreign) }},
+		{"MoveAfter", func() { l.MoveAfter(foreign, l.Front()) }},
+		{"MoveBefore", func() { l.MoveBefore(l.Front(), foreign) }},
+		{"MoveToFront", func() { l.MoveToFront(foreign) }},
+		{"MoveToBack", func() { l.MoveToBack(foreign) }},
+		{"Remove", func() { l.Remove(foreign) }},
+		{"Remove removed", func() { l.Remove(removed) }},
+		{"SpliceAfter", func() { l.SpliceAfter(New[int](), foreign) }},
+	}
+
+	// Lax mode keeps the container/list behavior.
+	for _, c := range calls {
+		c.f()
+	}
+	checkList(t, l, []int{1, 2})
+	checkList(t, other, []int{9})
+
+	l.SetStrict(true)
+	if !l.Strict() {
+		t.Errorf("Strict() = false after SetStrict(true)")
+	}
+	for _, c := range calls {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("strict %s with a foreign element did not panic", c.name)
+				}
+			}()
+			c.f()
+		}()
+	}
+	checkList(t, l, []int{1, 2})
+	checkList(t, other, []int{9})
+
+	// Valid no-ops do not panic.
+	l.MoveToFront(l.Front())
+	l.MoveAfter(l.Front(), l.Front())
+	checkList(t, l, []int{1, 2})
+}
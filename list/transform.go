@@ -0,0 +1,49 @@
+package list
+
+// Map returns a new list holding f applied to each element value of l,
+// in order.
+func Map[T, U any](l *List[T], f func(T) U) *List[U] {
+	out := New[U]()
+	for e := l.Front(); e != nil; e = e.Next() {
+		out.PushBack(f(e.Value))
+	}
+	return out
+}
+
+// Filter returns a new list holding the element values of l for which
+// keep returns true, in order. l is unchanged.
+func Filter[T any](l *List[T], keep func(T) bool) *List[T] {
+	out := New[T]()
+	for e := l.Front(); e != nil; e = e.Next() {
+		if keep(e.Value) {
+			out.PushBack(e.Value)
+		}
+	}
+	return out
+}
+
+// Reduce folds the element values of l, front to back, into an
+// accumulator that starts as init, and returns the result.
+func Reduce[T, U any](l *List[T], init U, f func(U, T) U) U {
+	acc := init
+	for e := l.Front(); e != nil; e = e.Next() {
+		acc = f(acc, e.Value)
+	}
+	return acc
+}
+
+// RemoveIf removes every element of l whose value satisfies pred, in a
+// single front-to-back pass, and returns the number removed. pred must
+// not modify l.
+func (l *List[T]) RemoveIf(pred func(T) bool) int {
+	n := 0
+	var next *Element[T]
+	for e := l.Front(); e != nil; e = next {
+		next = e.Next()
+		if pred(e.Value) {
+			l.Remove(e)
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,52 @@
+package list
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestTransforms(t *testing.T) {
+	l := newIntList(1, 2, 3, 4, 5)
+	strs := Map(l, strconv.Itoa)
+	if !EqualOrdered(strs, FromSlice([]string{"1", "2", "3", "4", "5"})) {
+		t.Errorf("Map(l, Itoa) = %v", strs.ToSlice())
+	}
+	even := Filter(l, func(v int) bool { return v%2 == 0 })
+	checkList(t, even, []int{2, 4})
+	checkList(t, l, []int{1, 2, 3, 4, 5})
+
+	if got := Reduce(l, 0, func(acc, v int) int { return acc + v }); got != 15 {
+		t.Errorf("Reduce sum = %d, want 15", got)
+	}
+	if got := Reduce(strs, "", func(acc, s string) string { return acc + s }); got != "12345" {
+		t.Errorf("Reduce concat = %q, want 12345", got)
+	}
+
+	var zero List[int]
+	checkList(t, Map(&zero, func(v int) int { return v }), nil)
+	checkList(t, Filter(&zero, func(int) bool { return true }), nil)
+	if got := Reduce(&zero, 7, func(acc, v int) int { return acc + v }); got != 7 {
+		t.Errorf("Reduce of empty list = %d, want init 7", got)
+	}
+}
+
+func TestRemoveIf(t *testing.T) {
+	l := newIntList(1, 2, 3, 4, 5, 6)
+	front := l.Front()
+	if n := l.RemoveIf(func(v int) bool { return v%2 == 0 }); n != 3 {
+		t.Errorf("RemoveIf(even) = %d, want 3", n)
+	}
+	checkList(t, l, []int{1, 3, 5})
+	if l.Front() != front {
+		t.Errorf("RemoveIf disturbed a kept element")
+	}
+	if n := l.RemoveIf(func(int) bool { return true }); n != 3 {
+		t.Errorf("RemoveIf(all) = %d, want 3", n)
+	}
+	checkList(t, l, nil)
+
+	var zero List[int]
+	if n := zero.RemoveIf(func(int) bool { return true }); n != 0 {
+		t.Errorf("RemoveIf on zero List = %d, want 0", n)
+	}
+}
@@ -0,0 +1,190 @@
+// Package lru implements least-recently-used caches.
+package lru
+
+import (
+	"time"
+
+	"github.com/nishanths/typedcontainer/list"
+)
+
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time // zero means no expiry
+}
+
+func (e *entry[K, V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// Cache is a key-value cache that holds at most a fixed number of
+// entries. When a new key is added to a full cache, the least recently
+// used entry is evicted. Entries may also be given a time to live, after
+// which they are treated as absent. All operations except RemoveExpired
+// are O(1).
+//
+// Expired entries are dropped lazily, when they are next looked up or
+// evicted, so Len may count entries that have expired but not yet been
+// dropped. RemoveExpired drops them eagerly.
+//
+// A Cache is not safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	m       map[K]*list.Element[entry[K, V]]
+	l       list.List[entry[K, V]] // most recently used first
+	cap     int
+	onEvict func(K, V)
+	now     func() time.Time
+}
+
+// New returns an empty Cache that holds at most capacity entries. If
+// onEvict is not nil, it is called with each entry that is evicted to make
+// room or dropped because it expired, but not with entries removed by
+// Remove or replaced by Put. New panics if capacity is not positive.
+func New[K comparable, V any](capacity int, onEvict func(K, V)) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("lru: New with non-positive capacity")
+	}
+	return &Cache[K, V]{
+		m:       make(map[K]*list.Element[entry[K, V]]),
+		cap:     capacity,
+		onEvict: onEvict,
+		now:     time.Now,
+	}
+}
+
+// Len returns the number of entries in c.
+func (c *Cache[K, V]) Len() int {
+	return len(c.m)
+}
+
+// Cap returns the maximum number of entries in c.
+func (c *Cache[K, V]) Cap() int {
+	return c.cap
+}
+
+// lookup returns the live element for k, dropping it if it has expired.
+func (c *Cache[K, V]) lookup(k K) *list.Element[entry[K, V]] {
+	e, ok := c.m[k]
+	if !ok {
+		return nil
+	}
+	if e.Value.expired(c.now()) {
+		c.evict(e)
+		return nil
+	}
+	return e
+}
+
+func (c *Cache[K, V]) evict(e *list.Element[entry[K, V]]) {
+	delete(c.m, e.Value.key)
+	c.l.Remove(e)
+	if c.onEvict != nil {
+		c.onEvict(e.Value.key, e.Value.value)
+	}
+}
+
+// Get returns the value for k and marks it as most recently used. The
+// boolean is false if k is not in c or has expired.
+func (c *Cache[K, V]) Get(k K) (V, bool) {
+	e := c.lookup(k)
+	if e == nil {
+		var zero V
+		return zero, false
+	}
+	c.l.MoveToFront(e)
+	return e.Value.value, true
+}
+
+// GetOr returns the value for k, or def if k is not in c. Like Get, it
+// marks k as most recently used.
+func (c *Cache[K, V]) GetOr(k K, def V) V {
+	if v, ok := c.Get(k); ok {
+		return v
+	}
+	return def
+}
+
+// MustGet returns the value for k. It panics if k is not in c.
+func (c *Cache[K, V]) MustGet(k K) V {
+	v, ok := c.Get(k)
+	if !ok {
+		panic("lru: MustGet of missing key")
+	}
+	return v
+}
+
+// Peek returns the value for k without marking it as used. The boolean is
+// false if k is not in c or has expired.
+func (c *Cache[K, V]) Peek(k K) (V, bool) {
+	e, ok := c.m[k]
+	if !ok || e.Value.expired(c.now()) {
+		var zero V
+		return zero, false
+	}
+	return e.Value.value, true
+}
+
+// Contains reports whether k is in c and has not expired, without marking
+// it as used.
+func (c *Cache[K, V]) Contains(k K) bool {
+	_, ok := c.Peek(k)
+	return ok
+}
+
+// Put sets the value for k, with no expiry, and marks it as most recently
+// used. It reports whether an entry was evicted to make room.
+func (c *Cache[K, V]) Put(k K, v V) bool {
+	return c.put(k, v, time.Time{})
+}
+
+// PutTTL is like Put, but the entry expires after ttl.
+func (c *Cache[K, V]) PutTTL(k K, v V, ttl time.Duration) bool {
+	return c.put(k, v, c.now().Add(ttl))
+}
+
+func (c *Cache[K, V]) put(k K, v V, expires time.Time) bool {
+	if e, ok := c.m[k]; ok {
+		e.Value.value = v
+		e.Value.expires = expires
+		c.l.MoveToFront(e)
+		return false
+	}
+	evicted := false
+	if len(c.m) >= c.cap {
+		c.evict(c.l.Back())
+		evicted = true
+	}
+	c.m[k] = c.l.PushFront(entry[K, V]{key: k, value: v, expires: expires})
+	return evicted
+}
+
+// Remove removes k from c. It reports whether k was in c.
+func (c *Cache[K, V]) Remove(k K) bool {
+	e, ok := c.m[k]
+	if !ok {
+		return false
+	}
+	delete(c.m, k)
+	c.l.Remove(e)
+	return true
+}
+
+// RemoveExpired drops every expired entry and returns how many were
+// dropped. It is O(n) in the number of entries.
+func (c *Cache[K, V]) RemoveExpired() int {
+	now := c.now()
+	n := 0
+	for e := range c.l.Elements() {
+		if e.Value.expired(now) {
+			c.evict(e)
+			n++
+		}
+	}
+	return n
+}
+
+// Clear removes all entries from c without calling the eviction callback.
+func (c *Cache[K, V]) Clear() {
+	clear(c.m)
+	c.l.Init()
+}
@@ -0,0 +1,103 @@
+package lru
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func keys[K comparable, V any](c *Cache[K, V]) []K {
+	var ks []K
+	for e := c.l.Front(); e != nil; e = e.Next() {
+		ks = append(ks, e.Value.key)
+	}
+	return ks
+}
+
+func TestCacheEviction(t *testing.T) {
+	var evicted []string
+	c := New[string, int](3, func(k string, _ int) { evicted = append(evicted, k) })
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %d, %v; want 1, true", v, ok)
+	}
+	if !c.Put("d", 4) {
+		t.Errorf("Put into full cache did not report eviction")
+	}
+	if c.Contains("b") {
+		t.Errorf("least recently used entry b was not evicted")
+	}
+	if got := keys(c); !slices.Equal(got, []string{"d", "a", "c"}) {
+		t.Errorf("order = %v, want [d a c]", got)
+	}
+
+	if v, _ := c.Peek("c"); v != 3 {
+		t.Errorf("Peek(c) = %d, want 3", v)
+	}
+	c.Put("e", 5) // Peek did not promote c
+	if !slices.Equal(evicted, []string{"b", "c"}) {
+		t.Errorf("evicted = %v, want [b c]", evicted)
+	}
+
+	if c.Put("a", 10) || c.MustGet("a") != 10 {
+		t.Errorf("Put of existing key did not update in place")
+	}
+	if !c.Remove("a") || c.Remove("a") || c.Len() != 2 {
+		t.Errorf("Remove did not report removals correctly")
+	}
+	if c.GetOr("a", -1) != -1 {
+		t.Errorf("GetOr of removed key did not return default")
+	}
+	if len(evicted) != 2 {
+		t.Errorf("Remove or replacement called onEvict: %v", evicted)
+	}
+	c.Clear()
+	if c.Len() != 0 {
+		t.Errorf("Len() after Clear = %d", c.Len())
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var evicted []string
+	c := New[string, int](10, func(k string, _ int) { evicted = append(evicted, k) })
+	c.now = func() time.Time { return now }
+
+	c.PutTTL("short", 1, time.Second)
+	c.PutTTL("long", 2, time.Minute)
+	c.Put("forever", 3)
+
+	now = now.Add(time.Second)
+	if c.Contains("short") {
+		t.Errorf("Contains reported an expired entry")
+	}
+	if c.Len() != 3 {
+		t.Errorf("Len() = %d before lazy expiry, want 3", c.Len())
+	}
+	if _, ok := c.Get("short"); ok {
+		t.Errorf("Get returned an expired entry")
+	}
+	if c.Len() != 2 || !slices.Equal(evicted, []string{"short"}) {
+		t.Errorf("Len, evicted = %d, %v; want 2, [short]", c.Len(), evicted)
+	}
+
+	c.PutTTL("forever", 4, time.Second) // replacing resets the expiry
+	now = now.Add(time.Hour)
+	if n := c.RemoveExpired(); n != 2 {
+		t.Errorf("RemoveExpired() = %d, want 2", n)
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len() after RemoveExpired = %d, want 0", c.Len())
+	}
+}
+
+func TestNewPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New(0) did not panic")
+		}
+	}()
+	New[int, int](0, nil)
+}
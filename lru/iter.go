@@ -0,0 +1,102 @@
+package lru
+
+import (
+	"iter"
+
+	"github.com/nishanths/typedcontainer/list"
+)
+
+// All returns an iterator over the entries of c from most to least
+// recently used, skipping expired entries. Iterating does not count as
+// use. c must not be modified during iteration.
+func (c *Cache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		now := c.now()
+		for e := c.l.Front(); e != nil; e = e.Next() {
+			if !e.Value.expired(now) && !yield(e.Value.key, e.Value.value) {
+				return
+			}
+		}
+	}
+}
+
+// Newest returns the most recently used entry that has not expired,
+// without marking it as used. The boolean is false if there is none.
+func (c *Cache[K, V]) Newest() (K, V, bool) {
+	now := c.now()
+	for e := c.l.Front(); e != nil; e = e.Next() {
+		if !e.Value.expired(now) {
+			return e.Value.key, e.Value.value, true
+		}
+	}
+	return unpack[K, V](nil)
+}
+
+// Oldest returns the least recently used entry that has not expired,
+// without marking it as used. The boolean is false if there is none.
+func (c *Cache[K, V]) Oldest() (K, V, bool) {
+	now := c.now()
+	for e := c.l.Back(); e != nil; e = e.Prev() {
+		if !e.Value.expired(now) {
+			return e.Value.key, e.Value.value, true
+		}
+	}
+	return unpack[K, V](nil)
+}
+
+func unpack[K comparable, V any](e *list.Element[entry[K, V]]) (K, V, bool) {
+	if e == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return e.Value.key, e.Value.value, true
+}
+
+// All returns an iterator over the entries of c in retention order: the
+// protected segment from most to least recently used, then the probation
+// segment likewise, so that the last entry yielded is the next to be
+// evicted. Iterating does not count as use. c must not be modified during
+// iteration.
+func (c *Segmented[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, l := range []*list.List[segEntry[K, V]]{&c.protected, &c.probation} {
+			for e := l.Front(); e != nil; e = e.Next() {
+				if !yield(e.Value.key, e.Value.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Newest returns the first entry All would yield: the most recently used
+// protected entry, or if there is none the most recently used probation
+// entry. The boolean is false if c is empty.
+func (c *Segmented[K, V]) Newest() (K, V, bool) {
+	e := c.protected.Front()
+	if e == nil {
+		e = c.probation.Front()
+	}
+	return unpackSeg(e)
+}
+
+// Oldest returns the entry that would be evicted next: the least recently
+// used probation entry, or if there is none the least recently used
+// protected entry. The boolean is false if c is empty.
+func (c *Segmented[K, V]) Oldest() (K, V, bool) {
+	e := c.probation.Back()
+	if e == nil {
+		e = c.protected.Back()
+	}
+	return unpackSeg(e)
+}
+
+func unpackSeg[K comparable, V any](e *list.Element[segEntry[K, V]]) (K, V, bool) {
+	if e == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return e.Value.key, e.Value.value, true
+}
@@ -0,0 +1,73 @@
+package lru
+
+import (
+	"iter"
+	"slices"
+	"testing"
+	"time"
+)
+
+func collectKeys[K comparable, V any](seq iter.Seq2[K, V]) []K {
+	var ks []K
+	for k := range seq {
+		ks = append(ks, k)
+	}
+	return ks
+}
+
+func TestCacheAll(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New[string, int](5, nil)
+	c.now = func() time.Time { return now }
+	if _, _, ok := c.Oldest(); ok {
+		t.Errorf("Oldest on empty cache reported true")
+	}
+	c.PutTTL("x", 0, time.Second)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.PutTTL("y", 0, time.Second)
+	c.Put("c", 3)
+	c.Get("a")
+
+	if got := collectKeys(c.All()); !slices.Equal(got, []string{"a", "c", "y", "b", "x"}) {
+		t.Errorf("All() = %v, want [a c y b x]", got)
+	}
+	now = now.Add(time.Second)
+	if got := collectKeys(c.All()); !slices.Equal(got, []string{"a", "c", "b"}) {
+		t.Errorf("All() after expiry = %v, want [a c b]", got)
+	}
+	if k, _, _ := c.Oldest(); k != "b" {
+		t.Errorf("Oldest() = %q, want b", k)
+	}
+	if k, v, _ := c.Newest(); k != "a" || v != 1 {
+		t.Errorf("Newest() = %q, %d; want a, 1", k, v)
+	}
+	if got := collectKeys(c.All()); got[len(got)-1] != "b" {
+		t.Errorf("Oldest changed recency: All() = %v", got)
+	}
+}
+
+func TestSegmentedAll(t *testing.T) {
+	c := NewSegmented[string, int](3, 3, nil)
+	if _, _, ok := c.Newest(); ok {
+		t.Errorf("Newest on empty cache reported true")
+	}
+	c.Put("p1", 0)
+	c.Put("p2", 0)
+	if k, _, _ := c.Newest(); k != "p2" {
+		t.Errorf("Newest() with only probation = %q, want p2", k)
+	}
+	c.Put("h", 0)
+	c.Get("h")
+	if got := collectKeys(c.All()); !slices.Equal(got, []string{"h", "p2", "p1"}) {
+		t.Errorf("All() = %v, want [h p2 p1]", got)
+	}
+	if k, _, _ := c.Oldest(); k != "p1" {
+		t.Errorf("Oldest() = %q, want p1", k)
+	}
+	c.Remove("p1")
+	c.Remove("p2")
+	if k, _, _ := c.Oldest(); k != "h" {
+		t.Errorf("Oldest() with only protected = %q, want h", k)
+	}
+}
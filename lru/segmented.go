@@ -0,0 +1,204 @@
+package lru
+
+import "github.com/nishanths/typedcontainer/list"
+
+type segEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	protected bool
+}
+
+// SegmentStats describes one segment of a Segmented cache.
+type SegmentStats struct {
+	Len  int    // entries currently in the segment
+	Cap  int    // maximum entries in the segment
+	Hits uint64 // lookups that found an entry in the segment
+}
+
+// Stats describes a Segmented cache.
+type Stats struct {
+	Probation SegmentStats
+	Protected SegmentStats
+	Misses    uint64 // lookups that found no entry
+}
+
+// Segmented is a segmented LRU cache. New entries enter a probation
+// segment; an entry that is accessed again while on probation is promoted
+// to a protected segment. Each segment is an LRU list of its own: entries
+// evicted from probation leave the cache, while entries evicted from
+// protected are demoted back to probation for another chance. A one-time
+// scan of many keys therefore only churns the probation segment and
+// leaves the frequently used entries in protected alone. All operations
+// are O(1).
+//
+// A Segmented cache is not safe for concurrent use.
+type Segmented[K comparable, V any] struct {
+	m         map[K]*list.Element[segEntry[K, V]]
+	probation list.List[segEntry[K, V]] // most recently used first
+	protected list.List[segEntry[K, V]]
+	stats     Stats
+	onEvict   func(K, V)
+}
+
+// NewSegmented returns an empty Segmented cache whose probation and
+// protected segments hold at most probation and protected entries. If
+// onEvict is not nil, it is called with each entry that leaves the cache
+// to make room. NewSegmented panics if either size is not positive.
+func NewSegmented[K comparable, V any](probation, protected int, onEvict func(K, V)) *Segmented[K, V] {
+	if probation <= 0 || protected <= 0 {
+		panic("lru: NewSegmented with non-positive segment size")
+	}
+	c := &Segmented[K, V]{
+		m:       make(map[K]*list.Element[segEntry[K, V]]),
+		onEvict: onEvict,
+	}
+	c.stats.Probation.Cap = probation
+	c.stats.Protected.Cap = protected
+	return c
+}
+
+// Len returns the number of entries in c.
+func (c *Segmented[K, V]) Len() int {
+	return len(c.m)
+}
+
+// Stats returns the current segment sizes and hit counts of c.
+func (c *Segmented[K, V]) Stats() Stats {
+	s := c.stats
+	s.Probation.Len = c.probation.Len()
+	s.Protected.Len = c.protected.Len()
+	return s
+}
+
+// Resize changes the maximum sizes of the segments, demoting and evicting
+// entries as needed to fit. It panics if either size is not positive.
+func (c *Segmented[K, V]) Resize(probation, protected int) {
+	if probation <= 0 || protected <= 0 {
+		panic("lru: Resize with non-positive segment size")
+	}
+	c.stats.Probation.Cap = probation
+	c.stats.Protected.Cap = protected
+	for c.protected.Len() > protected {
+		c.demote()
+	}
+	for c.probation.Len() > probation {
+		c.evict()
+	}
+}
+
+// demote moves the least recently used protected entry to the front of
+// probation.
+func (c *Segmented[K, V]) demote() {
+	ent := c.protected.Remove(c.protected.Back())
+	ent.protected = false
+	c.m[ent.key] = c.probation.PushFront(ent)
+}
+
+// evict removes the least recently used probation entry from c.
+func (c *Segmented[K, V]) evict() {
+	ent := c.probation.Remove(c.probation.Back())
+	delete(c.m, ent.key)
+	if c.onEvict != nil {
+		c.onEvict(ent.key, ent.value)
+	}
+}
+
+// touch records an access to e, promoting it if it is on probation, and
+// returns the element now holding the entry.
+func (c *Segmented[K, V]) touch(e *list.Element[segEntry[K, V]]) *list.Element[segEntry[K, V]] {
+	if e.Value.protected {
+		c.stats.Protected.Hits++
+		c.protected.MoveToFront(e)
+		return e
+	}
+	c.stats.Probation.Hits++
+	ent := c.probation.Remove(e)
+	ent.protected = true
+	e = c.protected.PushFront(ent)
+	c.m[ent.key] = e
+	if c.protected.Len() > c.stats.Protected.Cap {
+		c.demote()
+	}
+	return e
+}
+
+// Get returns the value for k and records the access, promoting k to the
+// protected segment if it was on probation. The boolean is false if k is
+// not in c.
+func (c *Segmented[K, V]) Get(k K) (V, bool) {
+	e, ok := c.m[k]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	return c.touch(e).Value.value, true
+}
+
+// GetOr returns the value for k, or def if k is not in c. Like Get, it
+// records the access.
+func (c *Segmented[K, V]) GetOr(k K, def V) V {
+	if v, ok := c.Get(k); ok {
+		return v
+	}
+	return def
+}
+
+// MustGet returns the value for k. It panics if k is not in c.
+func (c *Segmented[K, V]) MustGet(k K) V {
+	v, ok := c.Get(k)
+	if !ok {
+		panic("lru: MustGet of missing key")
+	}
+	return v
+}
+
+// Peek returns the value for k without recording an access. The boolean
+// is false if k is not in c.
+func (c *Segmented[K, V]) Peek(k K) (V, bool) {
+	e, ok := c.m[k]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.value, true
+}
+
+// Contains reports whether k is in c, without recording an access.
+func (c *Segmented[K, V]) Contains(k K) bool {
+	_, ok := c.m[k]
+	return ok
+}
+
+// Put sets the value for k. A new key enters the probation segment; an
+// existing key is updated and treated as accessed. Put reports whether an
+// entry was evicted to make room.
+func (c *Segmented[K, V]) Put(k K, v V) bool {
+	if e, ok := c.m[k]; ok {
+		e.Value.value = v
+		c.touch(e)
+		return false
+	}
+	evicted := false
+	if c.probation.Len() >= c.stats.Probation.Cap {
+		c.evict()
+		evicted = true
+	}
+	c.m[k] = c.probation.PushFront(segEntry[K, V]{key: k, value: v})
+	return evicted
+}
+
+// Remove removes k from c. It reports whether k was in c.
+func (c *Segmented[K, V]) Remove(k K) bool {
+	e, ok := c.m[k]
+	if !ok {
+		return false
+	}
+	delete(c.m, k)
+	if e.Value.protected {
+		c.protected.Remove(e)
+	} else {
+		c.probation.Remove(e)
+	}
+	return true
+}
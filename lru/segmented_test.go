@@ -0,0 +1,131 @@
+package lru
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func segmentKeys[K comparable, V any](c *Segmented[K, V]) (probation, protected []K) {
+	for e := c.probation.Front(); e != nil; e = e.Next() {
+		probation = append(probation, e.Value.key)
+	}
+	for e := c.protected.Front(); e != nil; e = e.Next() {
+		protected = append(protected, e.Value.key)
+	}
+	return probation, protected
+}
+
+func checkSegments(t *testing.T, c *Segmented[string, int], probation, protected []string) {
+	t.Helper()
+	gotProb, gotProt := segmentKeys(c)
+	if !slices.Equal(gotProb, probation) || !slices.Equal(gotProt, protected) {
+		t.Errorf("segments = %v, %v; want %v, %v", gotProb, gotProt, probation, protected)
+	}
+}
+
+func TestSegmentedPromotion(t *testing.T) {
+	var evicted []string
+	c := NewSegmented[string, int](2, 2, func(k string, _ int) { evicted = append(evicted, k) })
+	c.Put("a", 1)
+	c.Put("b", 2)
+	checkSegments(t, c, []string{"b", "a"}, nil)
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %d, %v; want 1, true", v, ok)
+	}
+	c.Get("b")
+	checkSegments(t, c, nil, []string{"b", "a"})
+
+	c.Put("c", 3)
+	c.Get("c") // protected is full, so a is demoted
+	checkSegments(t, c, []string{"a"}, []string{"c", "b"})
+
+	c.Put("d", 4)
+	if !c.Put("e", 5) {
+		t.Errorf("Put into full probation did not report eviction")
+	}
+	checkSegments(t, c, []string{"e", "d"}, []string{"c", "b"})
+	if !slices.Equal(evicted, []string{"a"}) {
+		t.Errorf("evicted = %v, want [a]", evicted)
+	}
+
+	if c.Put("d", 40) {
+		t.Errorf("Put of existing key reported eviction")
+	}
+	checkSegments(t, c, []string{"b", "e"}, []string{"d", "c"})
+	if v, _ := c.Peek("d"); v != 40 {
+		t.Errorf("Peek(d) = %d, want 40", v)
+	}
+	if !c.Remove("c") || !c.Remove("e") || c.Remove("zz") {
+		t.Errorf("Remove did not report removals correctly")
+	}
+	checkSegments(t, c, []string{"b"}, []string{"d"})
+}
+
+func TestSegmentedGetOr(t *testing.T) {
+	c := NewSegmented[string, int](2, 2, nil)
+	c.Put("a", 1)
+	if c.GetOr("a", -1) != 1 || c.MustGet("a") != 1 {
+		t.Errorf("GetOr/MustGet(a) returned wrong values")
+	}
+	checkSegments(t, c, nil, []string{"a"})
+	if c.GetOr("x", -1) != -1 {
+		t.Errorf("GetOr of missing key did not return default")
+	}
+	if st := c.Stats(); st.Misses != 1 || st.Probation.Hits != 1 || st.Protected.Hits != 1 {
+		t.Errorf("Stats() = %+v, want 1 miss, 1 probation hit, 1 protected hit", st)
+	}
+	defer func() {
+		if r := recover(); r != "lru: MustGet of missing key" {
+			t.Errorf("MustGet of missing key recovered %v", r)
+		}
+	}()
+	c.MustGet("x")
+}
+
+func TestSegmentedScanResistance(t *testing.T) {
+	c := NewSegmented[string, int](4, 4, nil)
+	hot := []string{"h0", "h1", "h2"}
+	for _, k := range hot {
+		c.Put(k, 0)
+		c.Get(k)
+	}
+	for i := 0; i < 100; i++ {
+		c.Put(fmt.Sprint("scan", i), i)
+	}
+	for _, k := range hot {
+		if !c.Contains(k) {
+			t.Errorf("hot key %s was evicted by a scan", k)
+		}
+	}
+
+	c.Get("missing")
+	s := c.Stats()
+	want := Stats{
+		Probation: SegmentStats{Len: 4, Cap: 4, Hits: 3},
+		Protected: SegmentStats{Len: 3, Cap: 4},
+		Misses:    1,
+	}
+	if s != want {
+		t.Errorf("Stats() = %+v, want %+v", s, want)
+	}
+}
+
+func TestSegmentedResize(t *testing.T) {
+	var evicted []string
+	c := NewSegmented[string, int](3, 3, func(k string, _ int) { evicted = append(evicted, k) })
+	for _, k := range []string{"a", "b", "c"} {
+		c.Put(k, 0)
+		c.Get(k)
+	}
+	c.Put("x", 0)
+	c.Resize(2, 1)
+	checkSegments(t, c, []string{"b", "a"}, []string{"c"})
+	if !slices.Equal(evicted, []string{"x"}) {
+		t.Errorf("evicted = %v, want [x]", evicted)
+	}
+	if c.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", c.Len())
+	}
+}
@@ -0,0 +1,179 @@
+// Package median implements a running median over a stream of values.
+package median
+
+import (
+	"cmp"
+
+	"github.com/nishanths/typedcontainer/list"
+)
+
+type item[T any] struct {
+	v     T
+	inLow bool // which half the item is in
+	index int  // position in that half's heap
+}
+
+// half is a binary heap of items: a max-heap for the lower half of the
+// values and a min-heap for the upper half.
+type half[T cmp.Ordered] struct {
+	items []*item[T]
+	low   bool
+}
+
+func (h *half[T]) Len() int { return len(h.items) }
+
+func (h *half[T]) top() *item[T] { return h.items[0] }
+
+func (h *half[T]) less(i, j int) bool {
+	if h.low {
+		return cmp.Less(h.items[j].v, h.items[i].v)
+	}
+	return cmp.Less(h.items[i].v, h.items[j].v)
+}
+
+func (h *half[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *half[T]) push(it *item[T]) {
+	it.inLow = h.low
+	it.index = len(h.items)
+	h.items = append(h.items, it)
+	h.up(it.index)
+}
+
+func (h *half[T]) remove(i int) *item[T] {
+	n := len(h.items) - 1
+	if n != i {
+		h.swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	it := h.items[n]
+	h.items[n] = nil
+	h.items = h.items[:n]
+	return it
+}
+
+func (h *half[T]) up(j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+func (h *half[T]) down(i0, n int) bool {
+	i := i0
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && h.less(j2, j1) {
+			j = j2 // = 2*i + 2  // right child
+		}
+		if !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
+
+// Tracker maintains the median of the values added to it, optionally
+// restricted to the most recent values. Add is O(log n) and Median is
+// O(1).
+//
+// The zero value for Tracker is an empty, unwindowed tracker ready to use.
+type Tracker[T cmp.Ordered] struct {
+	lo     half[T] // values <= median; lo.Len() is hi.Len() or hi.Len()+1
+	hi     half[T]
+	window int                 // 0 means unbounded
+	order  list.List[*item[T]] // items in arrival order, if windowed
+}
+
+// New returns an empty Tracker over all values added to it.
+func New[T cmp.Ordered]() *Tracker[T] {
+	return new(Tracker[T])
+}
+
+// NewWindow returns an empty Tracker over the n most recently added
+// values. It panics if n is not positive.
+func NewWindow[T cmp.Ordered](n int) *Tracker[T] {
+	if n <= 0 {
+		panic("median: NewWindow with non-positive window")
+	}
+	return &Tracker[T]{window: n}
+}
+
+// Len returns the number of values the median is computed over.
+func (t *Tracker[T]) Len() int {
+	return t.lo.Len() + t.hi.Len()
+}
+
+// Add adds v. For a windowed Tracker that is already full, the oldest
+// value is dropped.
+func (t *Tracker[T]) Add(v T) {
+	t.lo.low = true // set here so that the zero Tracker is usable
+	it := &item[T]{v: v}
+	if t.lo.Len() == 0 || v <= t.lo.top().v {
+		t.lo.push(it)
+	} else {
+		t.hi.push(it)
+	}
+	if t.window > 0 {
+		t.order.PushBack(it)
+		if t.order.Len() > t.window {
+			old := t.order.Remove(t.order.Front())
+			if old.inLow {
+				t.lo.remove(old.index)
+			} else {
+				t.hi.remove(old.index)
+			}
+		}
+	}
+	t.rebalance()
+}
+
+func (t *Tracker[T]) rebalance() {
+	for t.lo.Len() > t.hi.Len()+1 {
+		t.hi.push(t.lo.remove(0))
+	}
+	for t.hi.Len() > t.lo.Len() {
+		t.lo.push(t.hi.remove(0))
+	}
+}
+
+// Median returns the median value. For an even number of values it
+// returns the lower of the two middle values; see Medians. The boolean is
+// false if there are no values.
+func (t *Tracker[T]) Median() (T, bool) {
+	if t.lo.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return t.lo.top().v, true
+}
+
+// Medians returns the two middle values, which are equal when the number
+// of values is odd. Callers with numeric values can average them. The
+// boolean is false if there are no values.
+func (t *Tracker[T]) Medians() (lower, upper T, ok bool) {
+	if t.lo.Len() == 0 {
+		return lower, upper, false
+	}
+	lower = t.lo.top().v
+	if t.lo.Len() > t.hi.Len() {
+		return lower, lower, true
+	}
+	return lower, t.hi.top().v, true
+}
@@ -0,0 +1,72 @@
+package median
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func naiveMedians(vs []int) (int, int) {
+	s := slices.Clone(vs)
+	slices.Sort(s)
+	n := len(s)
+	return s[(n-1)/2], s[n/2]
+}
+
+func TestTracker(t *testing.T) {
+	var tr Tracker[int]
+	if _, ok := tr.Median(); ok {
+		t.Errorf("Median on empty tracker reported true")
+	}
+	if _, _, ok := tr.Medians(); ok {
+		t.Errorf("Medians on empty tracker reported true")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	var vs []int
+	for i := 0; i < 500; i++ {
+		v := r.Intn(100)
+		tr.Add(v)
+		vs = append(vs, v)
+		wlo, whi := naiveMedians(vs)
+		if m, _ := tr.Median(); m != wlo {
+			t.Fatalf("after %d values, Median() = %d, want %d", len(vs), m, wlo)
+		}
+		if lo, hi, _ := tr.Medians(); lo != wlo || hi != whi {
+			t.Fatalf("after %d values, Medians() = %d, %d; want %d, %d", len(vs), lo, hi, wlo, whi)
+		}
+	}
+	if tr.Len() != len(vs) {
+		t.Errorf("Len() = %d, want %d", tr.Len(), len(vs))
+	}
+}
+
+func TestTrackerWindow(t *testing.T) {
+	const window = 7
+	tr := NewWindow[int](window)
+	r := rand.New(rand.NewSource(2))
+	var vs []int
+	for i := 0; i < 500; i++ {
+		v := r.Intn(100)
+		tr.Add(v)
+		vs = append(vs, v)
+		w := vs[max(0, len(vs)-window):]
+		if tr.Len() != len(w) {
+			t.Fatalf("Len() = %d, want %d", tr.Len(), len(w))
+		}
+		wlo, whi := naiveMedians(w)
+		if lo, hi, _ := tr.Medians(); lo != wlo || hi != whi {
+			t.Fatalf("window %v: Medians() = %d, %d; want %d, %d", w, lo, hi, wlo, whi)
+		}
+	}
+}
+
+func TestTrackerStrings(t *testing.T) {
+	tr := New[string]()
+	for _, s := range []string{"pear", "apple", "fig", "kiwi"} {
+		tr.Add(s)
+	}
+	if lo, hi, _ := tr.Medians(); lo != "fig" || hi != "kiwi" {
+		t.Errorf("Medians() = %q, %q; want fig, kiwi", lo, hi)
+	}
+}
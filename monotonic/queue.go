@@ -0,0 +1,90 @@
+// Package monotonic implements a monotonic queue, the building block of
+// sliding-window minimum and maximum computations.
+package monotonic
+
+import (
+	"cmp"
+
+	"github.com/nishanths/typedcontainer/list"
+)
+
+// Mode selects the order maintained by a Queue.
+type Mode int
+
+const (
+	// Increasing keeps elements in increasing order from front to back,
+	// so Best returns the least element.
+	Increasing Mode = iota
+	// Decreasing keeps elements in decreasing order from front to back,
+	// so Best returns the greatest element.
+	Decreasing
+)
+
+// Queue is a monotonic queue. Pushing an element discards the elements at
+// the back that can never again be the best element, so that the front of
+// the queue is always the best of the elements pushed since the last
+// expiry. Push is amortized O(1); Best is O(1).
+//
+// Elements typically carry a position or timestamp so that PopExpired can
+// drop the ones that have left the window.
+//
+// Use New or NewFunc to create a Queue.
+type Queue[T any] struct {
+	less func(a, b T) bool
+	l    list.List[T]
+}
+
+// New returns an empty Queue for an ordered type in the given mode.
+func New[T cmp.Ordered](m Mode) *Queue[T] {
+	if m == Decreasing {
+		return NewFunc(func(a, b T) bool { return cmp.Less(b, a) })
+	}
+	return NewFunc(cmp.Less[T])
+}
+
+// NewFunc returns an empty Queue whose Best element is the least element
+// according to less.
+func NewFunc[T any](less func(a, b T) bool) *Queue[T] {
+	return &Queue[T]{less: less}
+}
+
+// Len returns the number of elements retained in q.
+func (q *Queue[T]) Len() int {
+	return q.l.Len()
+}
+
+// Push adds v to the back of q, first removing every element at the back
+// that is not better than v.
+func (q *Queue[T]) Push(v T) {
+	for e := q.l.Back(); e != nil && !q.less(e.Value, v); e = q.l.Back() {
+		q.l.Remove(e)
+	}
+	q.l.PushBack(v)
+}
+
+// PopExpired removes elements from the front of q for as long as expired
+// reports true for them, and returns the number of elements removed.
+func (q *Queue[T]) PopExpired(expired func(T) bool) int {
+	n := 0
+	for e := q.l.Front(); e != nil && expired(e.Value); e = q.l.Front() {
+		q.l.Remove(e)
+		n++
+	}
+	return n
+}
+
+// Best returns the best element in q: the least for Increasing mode and
+// the greatest for Decreasing mode. The boolean is false if q is empty.
+func (q *Queue[T]) Best() (T, bool) {
+	e := q.l.Front()
+	if e == nil {
+		var zero T
+		return zero, false
+	}
+	return e.Value, true
+}
+
+// Clear removes all elements from q.
+func (q *Queue[T]) Clear() {
+	q.l.Init()
+}
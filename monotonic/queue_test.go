@@ -0,0 +1,83 @@
+package monotonic
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type item struct {
+	pos, val int
+}
+
+// windowBest computes the best of each window of size k using q.
+func windowBest(q *Queue[item], vals []int, k int) []int {
+	var out []int
+	for i, v := range vals {
+		q.Push(item{i, v})
+		q.PopExpired(func(it item) bool { return it.pos <= i-k })
+		if i >= k-1 {
+			b, _ := q.Best()
+			out = append(out, b.val)
+		}
+	}
+	return out
+}
+
+func TestQueueSlidingWindow(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	vals := make([]int, 200)
+	for i := range vals {
+		vals[i] = r.Intn(100)
+	}
+	const k = 7
+
+	less := func(a, b item) bool { return a.val < b.val }
+	more := func(a, b item) bool { return a.val > b.val }
+	gotMin := windowBest(NewFunc(less), vals, k)
+	gotMax := windowBest(NewFunc(more), vals, k)
+	for i := 0; i+k <= len(vals); i++ {
+		w := vals[i : i+k]
+		if m := slices.Min(w); gotMin[i] != m {
+			t.Errorf("min of window %d = %d, want %d", i, gotMin[i], m)
+		}
+		if m := slices.Max(w); gotMax[i] != m {
+			t.Errorf("max of window %d = %d, want %d", i, gotMax[i], m)
+		}
+	}
+}
+
+func TestQueueModes(t *testing.T) {
+	inc := New[int](Increasing)
+	dec := New[int](Decreasing)
+	if _, ok := inc.Best(); ok {
+		t.Errorf("Best on empty queue reported true")
+	}
+	for _, v := range []int{5, 3, 4, 1, 2} {
+		inc.Push(v)
+		dec.Push(v)
+	}
+	if v, _ := inc.Best(); v != 1 {
+		t.Errorf("Increasing Best() = %d, want 1", v)
+	}
+	if inc.Len() != 2 { // 1, 2
+		t.Errorf("Increasing Len() = %d, want 2", inc.Len())
+	}
+	if v, _ := dec.Best(); v != 5 {
+		t.Errorf("Decreasing Best() = %d, want 5", v)
+	}
+	if dec.Len() != 3 { // 5, 4, 2
+		t.Errorf("Decreasing Len() = %d, want 3", dec.Len())
+	}
+
+	if n := dec.PopExpired(func(v int) bool { return v >= 4 }); n != 2 {
+		t.Errorf("PopExpired removed %d, want 2", n)
+	}
+	if v, _ := dec.Best(); v != 2 {
+		t.Errorf("Decreasing Best() after expiry = %d, want 2", v)
+	}
+	dec.Clear()
+	if dec.Len() != 0 {
+		t.Errorf("Len() after Clear = %d, want 0", dec.Len())
+	}
+}
@@ -0,0 +1,229 @@
+// Package orderedindex implements a keyed collection with a user-controlled
+// order, such as a playlist or a set of tabs.
+package orderedindex
+
+import (
+	"iter"
+
+	"github.com/nishanths/typedcontainer/list"
+)
+
+type entry[K comparable, T any] struct {
+	key   K
+	value T
+}
+
+// Index is a sequence of values, each identified by a unique key. Values
+// can be looked up, repositioned, and inserted relative to one another by
+// key. All operations other than iteration are O(1).
+//
+// The zero value for Index is an empty index ready to use.
+type Index[K comparable, T any] struct {
+	m map[K]*list.Element[entry[K, T]]
+	l list.List[entry[K, T]]
+}
+
+// New returns an empty Index.
+func New[K comparable, T any]() *Index[K, T] {
+	return &Index[K, T]{m: make(map[K]*list.Element[entry[K, T]])}
+}
+
+// Len returns the number of values in x.
+func (x *Index[K, T]) Len() int {
+	return len(x.m)
+}
+
+// Contains reports whether k is in x.
+func (x *Index[K, T]) Contains(k K) bool {
+	_, ok := x.m[k]
+	return ok
+}
+
+// Get returns the value for k. The boolean is false if k is not in x.
+func (x *Index[K, T]) Get(k K) (T, bool) {
+	e, ok := x.m[k]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return e.Value.value, true
+}
+
+// GetOr returns the value for k, or def if k is not in x.
+func (x *Index[K, T]) GetOr(k K, def T) T {
+	if v, ok := x.Get(k); ok {
+		return v
+	}
+	return def
+}
+
+// MustGet returns the value for k. It panics if k is not in x.
+func (x *Index[K, T]) MustGet(k K) T {
+	v, ok := x.Get(k)
+	if !ok {
+		panic("orderedindex: MustGet of missing key")
+	}
+	return v
+}
+
+// Set replaces the value for k, keeping its position. It reports whether k
+// was in x; if not, x is unchanged.
+func (x *Index[K, T]) Set(k K, v T) bool {
+	e, ok := x.m[k]
+	if ok {
+		e.Value.value = v
+	}
+	return ok
+}
+
+// PushBack adds k with value v at the back of x. It reports whether k was
+// added; if k is already in x, x is unchanged.
+func (x *Index[K, T]) PushBack(k K, v T) bool {
+	return x.insert(k, v, func(en entry[K, T]) *list.Element[entry[K, T]] { return x.l.PushBack(en) })
+}
+
+// PushFront adds k with value v at the front of x. It reports whether k
+// was added; if k is already in x, x is unchanged.
+func (x *Index[K, T]) PushFront(k K, v T) bool {
+	return x.insert(k, v, func(en entry[K, T]) *list.Element[entry[K, T]] { return x.l.PushFront(en) })
+}
+
+// InsertAfterKey adds k with value v immediately after mark. It reports
+// whether k was added; if k is already in x or mark is not, x is
+// unchanged.
+func (x *Index[K, T]) InsertAfterKey(k K, v T, mark K) bool {
+	me, ok := x.m[mark]
+	if !ok {
+		return false
+	}
+	return x.insert(k, v, func(en entry[K, T]) *list.Element[entry[K, T]] { return x.l.InsertAfter(en, me) })
+}
+
+// InsertBeforeKey adds k with value v immediately before mark. It reports
+// whether k was added; if k is already in x or mark is not, x is
+// unchanged.
+func (x *Index[K, T]) InsertBeforeKey(k K, v T, mark K) bool {
+	me, ok := x.m[mark]
+	if !ok {
+		return false
+	}
+	return x.insert(k, v, func(en entry[K, T]) *list.Element[entry[K, T]] { return x.l.InsertBefore(en, me) })
+}
+
+func (x *Index[K, T]) insert(k K, v T, link func(entry[K, T]) *list.Element[entry[K, T]]) bool {
+	if _, ok := x.m[k]; ok {
+		return false
+	}
+	if x.m == nil {
+		x.m = make(map[K]*list.Element[entry[K, T]])
+	}
+	x.m[k] = link(entry[K, T]{k, v})
+	return true
+}
+
+// Remove removes k from x and returns its value. The boolean is false if k
+// was not in x.
+func (x *Index[K, T]) Remove(k K) (T, bool) {
+	e, ok := x.m[k]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	delete(x.m, k)
+	return x.l.Remove(e).value, true
+}
+
+// MoveToFront moves k to the front of x. It reports whether k is in x.
+func (x *Index[K, T]) MoveToFront(k K) bool {
+	e, ok := x.m[k]
+	if ok {
+		x.l.MoveToFront(e)
+	}
+	return ok
+}
+
+// MoveToBack moves k to the back of x. It reports whether k is in x.
+func (x *Index[K, T]) MoveToBack(k K) bool {
+	e, ok := x.m[k]
+	if ok {
+		x.l.MoveToBack(e)
+	}
+	return ok
+}
+
+// MoveAfterKey moves k to immediately after mark. It reports whether both
+// keys are in x.
+func (x *Index[K, T]) MoveAfterKey(k, mark K) bool {
+	e, ok1 := x.m[k]
+	me, ok2 := x.m[mark]
+	if ok1 && ok2 {
+		x.l.MoveAfter(e, me)
+	}
+	return ok1 && ok2
+}
+
+// MoveBeforeKey moves k to immediately before mark. It reports whether
+// both keys are in x.
+func (x *Index[K, T]) MoveBeforeKey(k, mark K) bool {
+	e, ok1 := x.m[k]
+	me, ok2 := x.m[mark]
+	if ok1 && ok2 {
+		x.l.MoveBefore(e, me)
+	}
+	return ok1 && ok2
+}
+
+// Front returns the first key and value in x. The boolean is false if x
+// is empty.
+func (x *Index[K, T]) Front() (K, T, bool) {
+	return unpack(x.l.Front())
+}
+
+// Back returns the last key and value in x. The boolean is false if x is
+// empty.
+func (x *Index[K, T]) Back() (K, T, bool) {
+	return unpack(x.l.Back())
+}
+
+// Next returns the key and value following k. The boolean is false if k
+// is the last key or is not in x.
+func (x *Index[K, T]) Next(k K) (K, T, bool) {
+	e, ok := x.m[k]
+	if !ok {
+		return unpack[K, T](nil)
+	}
+	return unpack(e.Next())
+}
+
+// Prev returns the key and value preceding k. The boolean is false if k
+// is the first key or is not in x.
+func (x *Index[K, T]) Prev(k K) (K, T, bool) {
+	e, ok := x.m[k]
+	if !ok {
+		return unpack[K, T](nil)
+	}
+	return unpack(e.Prev())
+}
+
+func unpack[K comparable, T any](e *list.Element[entry[K, T]]) (K, T, bool) {
+	if e == nil {
+		var k K
+		var v T
+		return k, v, false
+	}
+	return e.Value.key, e.Value.value, true
+}
+
+// All returns an iterator over the keys and values of x, front to back.
+// It is safe to remove the current key during iteration.
+func (x *Index[K, T]) All() iter.Seq2[K, T] {
+	return func(yield func(K, T) bool) {
+		var next *list.Element[entry[K, T]]
+		for e := x.l.Front(); e != nil; e = next {
+			next = e.Next()
+			if !yield(e.Value.key, e.Value.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package orderedindex
+
+import (
+	"slices"
+	"testing"
+)
+
+func checkIndex(t *testing.T, x *Index[string, int], wantKeys []string) {
+	t.Helper()
+
+	if x.Len() != len(wantKeys) {
+		t.Errorf("Len() = %d, want %d", x.Len(), len(wantKeys))
+	}
+	var keys []string
+	for k, v := range x.All() {
+		keys = append(keys, k)
+		if got, _ := x.Get(k); got != v {
+			t.Errorf("Get(%q) = %d, iteration yielded %d", k, got, v)
+		}
+	}
+	if !slices.Equal(keys, wantKeys) {
+		t.Errorf("keys = %v, want %v", keys, wantKeys)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	x := New[string, int]()
+	checkIndex(t, x, nil)
+
+	x.PushBack("b", 2)
+	x.PushFront("a", 1)
+	x.PushBack("d", 4)
+	if x.PushBack("a", 9) {
+		t.Errorf("PushBack of existing key = true")
+	}
+	if !x.InsertAfterKey("c", 3, "b") {
+		t.Errorf("InsertAfterKey(c, b) = false")
+	}
+	if x.InsertAfterKey("z", 0, "missing") {
+		t.Errorf("InsertAfterKey with missing mark = true")
+	}
+	checkIndex(t, x, []string{"a", "b", "c", "d"})
+
+	x.InsertBeforeKey("ab", 0, "b")
+	checkIndex(t, x, []string{"a", "ab", "b", "c", "d"})
+	x.Remove("ab")
+
+	x.MoveToFront("c")
+	checkIndex(t, x, []string{"c", "a", "b", "d"})
+	x.MoveToBack("c")
+	checkIndex(t, x, []string{"a", "b", "d", "c"})
+	x.MoveAfterKey("d", "c")
+	checkIndex(t, x, []string{"a", "b", "c", "d"})
+	x.MoveBeforeKey("d", "a")
+	checkIndex(t, x, []string{"d", "a", "b", "c"})
+	if x.MoveBeforeKey("d", "missing") {
+		t.Errorf("MoveBeforeKey with missing mark = true")
+	}
+
+	if !x.Set("a", 10) || x.Set("missing", 0) {
+		t.Errorf("Set reported wrong membership")
+	}
+	if v, _ := x.Get("a"); v != 10 {
+		t.Errorf("Get(a) = %d, want 10", v)
+	}
+
+	if k, _, _ := x.Front(); k != "d" {
+		t.Errorf("Front() = %q, want d", k)
+	}
+	if k, _, _ := x.Back(); k != "c" {
+		t.Errorf("Back() = %q, want c", k)
+	}
+	if k, v, _ := x.Next("a"); k != "b" || v != 2 {
+		t.Errorf("Next(a) = %q, %d; want b, 2", k, v)
+	}
+	if k, _, _ := x.Prev("a"); k != "d" {
+		t.Errorf("Prev(a) = %q, want d", k)
+	}
+	if _, _, ok := x.Next("c"); ok {
+		t.Errorf("Next of last key reported true")
+	}
+
+	if v, ok := x.Remove("b"); v != 2 || !ok {
+		t.Errorf("Remove(b) = %d, %v; want 2, true", v, ok)
+	}
+	if _, ok := x.Remove("b"); ok {
+		t.Errorf("second Remove(b) reported true")
+	}
+	checkIndex(t, x, []string{"d", "a", "c"})
+}
+
+func TestIndexZero(t *testing.T) {
+	var x Index[string, int]
+	if _, _, ok := x.Front(); ok {
+		t.Errorf("Front on zero Index reported true")
+	}
+	x.PushBack("x", 1)
+	x.PushBack("y", 2)
+	for k := range x.All() {
+		x.Remove(k)
+	}
+	checkIndex(t, &x, nil)
+}
+
+func TestIndexGetOr(t *testing.T) {
+	x := New[string, int]()
+	x.PushBack("a", 1)
+	if v := x.GetOr("a", -1); v != 1 {
+		t.Errorf("GetOr(a) = %d, want 1", v)
+	}
+	if v := x.GetOr("b", -1); v != -1 {
+		t.Errorf("GetOr(b) = %d, want -1", v)
+	}
+	if v := x.MustGet("a"); v != 1 {
+		t.Errorf("MustGet(a) = %d, want 1", v)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustGet(b) did not panic")
+		}
+	}()
+	x.MustGet("b")
+}
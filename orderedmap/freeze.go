@@ -0,0 +1,38 @@
+package orderedmap
+
+import "iter"
+
+// Reader is the read-only part of a Map.
+type Reader[K comparable, V any] interface {
+	Len() int
+	Contains(k K) bool
+	Get(k K) (V, bool)
+	GetOr(k K, def V) V
+	MustGet(k K) V
+	Oldest() (K, V, bool)
+	Newest() (K, V, bool)
+	All() iter.Seq2[K, V]
+	Backward() iter.Seq2[K, V]
+	Keys() iter.Seq[K]
+	Values() iter.Seq[V]
+}
+
+// Freeze makes m read-only and returns it as a Reader. After Freeze, Set,
+// Delete, MoveToFront, MoveToBack, and Clear panic, including when called
+// through a *Map obtained by asserting the Reader. A frozen map stays
+// frozen.
+func (m *Map[K, V]) Freeze() Reader[K, V] {
+	m.frozen = true
+	return m
+}
+
+// Frozen reports whether Freeze has been called on m.
+func (m *Map[K, V]) Frozen() bool {
+	return m.frozen
+}
+
+func (m *Map[K, V]) checkFrozen() {
+	if m.frozen {
+		panic("orderedmap: mutation of frozen Map")
+	}
+}
@@ -0,0 +1,42 @@
+package orderedmap
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	m := New[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	r := m.Freeze()
+	if !m.Frozen() || r.Len() != 2 || r.MustGet("b") != 2 {
+		t.Errorf("frozen Map: Frozen() = %v, Len() = %d", m.Frozen(), r.Len())
+	}
+	if got := slices.Collect(r.Keys()); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Keys() = %v, want [a b]", got)
+	}
+	for _, tt := range []struct {
+		name string
+		f    func()
+	}{
+		{"Set", func() { m.Set("c", 3) }},
+		{"Set existing", func() { m.Set("a", 3) }},
+		{"Delete", func() { m.Delete("a") }},
+		{"MoveToFront", func() { m.MoveToFront("b") }},
+		{"MoveToBack", func() { m.MoveToBack("a") }},
+		{"Clear", func() { m.Clear() }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s on frozen Map did not panic", tt.name)
+				}
+			}()
+			tt.f()
+		}()
+	}
+	if v, _ := m.Get("a"); v != 1 || m.Len() != 2 {
+		t.Errorf("frozen Map changed: Get(a) = %d, Len() = %d", v, m.Len())
+	}
+}
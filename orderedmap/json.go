@@ -0,0 +1,141 @@
+package orderedmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// MarshalJSON encodes m as a JSON object whose members appear in
+// iteration order, oldest first. Keys are encoded the way encoding/json
+// encodes map keys, so K must be a string or integer type or implement
+// encoding.TextMarshaler.
+func (m *Map[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		ks, err := encodeKey(e.Value.key)
+		if err != nil {
+			return nil, err
+		}
+		kb, err := json.Marshal(ks)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(kb)
+		buf.WriteByte(':')
+		vb, err := json.Marshal(e.Value.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(vb)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON replaces the contents of m with the members of a JSON
+// object, in the order they appear. A key that appears more than once
+// keeps the position of its first occurrence and the value of its last.
+// JSON null leaves m empty. If data is not a valid object, m is left
+// unchanged.
+func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		m.Clear()
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return errors.New("orderedmap: JSON value is not an object")
+	}
+	var entries []entry[K, V]
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		k, err := decodeKey[K](tok.(string))
+		if err != nil {
+			return err
+		}
+		var v V
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		entries = append(entries, entry[K, V]{k, v})
+	}
+	if _, err := dec.Token(); err != nil { // the closing '}'
+		return err
+	}
+	m.Clear()
+	for _, e := range entries {
+		m.Set(e.key, e.value)
+	}
+	return nil
+}
+
+// encodeKey converts k to an object key the way encoding/json encodes map
+// keys: strings as they are, then encoding.TextMarshaler, then integers.
+func encodeKey[K comparable](k K) (string, error) {
+	rv := reflect.ValueOf(&k).Elem()
+	if rv.Kind() == reflect.String {
+		return rv.String(), nil
+	}
+	if tm, ok := any(k).(encoding.TextMarshaler); ok {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return "", nil
+		}
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+	return "", &json.UnsupportedTypeError{Type: rv.Type()}
+}
+
+// decodeKey converts an object key to K the way encoding/json decodes map
+// keys: with encoding.TextUnmarshaler if *K implements it, and otherwise
+// as a string or integer.
+func decodeKey[K comparable](s string) (K, error) {
+	var k K
+	if tu, ok := any(&k).(encoding.TextUnmarshaler); ok {
+		err := tu.UnmarshalText([]byte(s))
+		return k, err
+	}
+	rv := reflect.ValueOf(&k).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+		return k, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || rv.OverflowInt(n) {
+			return k, &json.UnmarshalTypeError{Value: "number " + s, Type: rv.Type()}
+		}
+		rv.SetInt(n)
+		return k, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || rv.OverflowUint(n) {
+			return k, &json.UnmarshalTypeError{Value: "number " + s, Type: rv.Type()}
+		}
+		rv.SetUint(n)
+		return k, nil
+	}
+	return k, fmt.Errorf("orderedmap: unsupported JSON key type %v", rv.Type())
+}
@@ -0,0 +1,126 @@
+package orderedmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	m := New[string, int]()
+	for i, k := range []string{"z", "a", "m\"q"} {
+		m.Set(k, i)
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if want := `{"z":0,"a":1,"m\"q":2}`; string(b) != want {
+		t.Errorf("Marshal = %s, want %s", b, want)
+	}
+	if b, _ := json.Marshal(New[string, int]()); string(b) != "{}" {
+		t.Errorf("Marshal of empty map = %s, want {}", b)
+	}
+
+	var got Map[string, int]
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if keys := slices.Collect(got.Keys()); !slices.Equal(keys, []string{"z", "a", "m\"q"}) {
+		t.Errorf("Unmarshal keys = %q, want [z a m\"q]", keys)
+	}
+	if err := json.Unmarshal([]byte(`{"b":1,"a":2,"b":3}`), &got); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if keys := slices.Collect(got.Keys()); !slices.Equal(keys, []string{"b", "a"}) || got.MustGet("b") != 3 {
+		t.Errorf("Unmarshal with duplicate = %v, b = %d; want [b a], 3", keys, got.MustGet("b"))
+	}
+	if err := json.Unmarshal([]byte(`null`), &got); err != nil || got.Len() != 0 {
+		t.Errorf("Unmarshal null: err = %v, Len() = %d", err, got.Len())
+	}
+	got.Set("keep", 1)
+	for _, bad := range []string{`[1]`, `{"a":"x"}`, `{"a":1`, `{"a":1,"b":"x"}`} {
+		if err := json.Unmarshal([]byte(bad), &got); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded", bad)
+		}
+		if keys := slices.Collect(got.Keys()); !slices.Equal(keys, []string{"keep"}) {
+			t.Errorf("Unmarshal(%s) changed the map to %q", bad, keys)
+		}
+	}
+}
+
+type textKey struct{ a, b int }
+
+func (k textKey) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "%d-%d", k.a, k.b), nil
+}
+
+func (k *textKey) UnmarshalText(b []byte) error {
+	_, err := fmt.Sscanf(string(b), "%d-%d", &k.a, &k.b)
+	return err
+}
+
+type name string
+
+func TestJSONKeyKinds(t *testing.T) {
+	tm := New[textKey, bool]()
+	tm.Set(textKey{2, 1}, true)
+	tm.Set(textKey{1, 2}, false)
+	b, err := json.Marshal(tm)
+	if want := `{"2-1":true,"1-2":false}`; err != nil || string(b) != want {
+		t.Errorf("Marshal = %s, %v; want %s", b, err, want)
+	}
+	var tgot Map[textKey, bool]
+	if err := json.Unmarshal(b, &tgot); err != nil || !slices.Equal(slices.Collect(tgot.Keys()), []textKey{{2, 1}, {1, 2}}) {
+		t.Errorf("Unmarshal(%s) = %v, %v", b, slices.Collect(tgot.Keys()), err)
+	}
+
+	nm := New[name, int]()
+	nm.Set("<b>", 1)
+	b, err = json.Marshal(nm)
+	if want := `{"\u003cb\u003e":1}`; err != nil || string(b) != want {
+		t.Errorf("Marshal = %s, %v; want %s", b, err, want)
+	}
+
+	var small Map[int8, int]
+	if err := json.Unmarshal([]byte(`{"200":1}`), &small); err == nil {
+		t.Errorf("Unmarshal of out-of-range int8 key succeeded")
+	}
+	var u Map[uint16, int]
+	if err := json.Unmarshal([]byte(`{"65535":1}`), &u); err != nil || u.MustGet(65535) != 1 {
+		t.Errorf("Unmarshal of uint16 key: %v", err)
+	}
+
+	if _, err := json.Marshal(New[float64, int]()); err != nil {
+		t.Errorf("Marshal of empty float-keyed map: %v", err)
+	}
+	fm := New[float64, int]()
+	fm.Set(1.5, 1)
+	if _, err := json.Marshal(fm); err == nil {
+		t.Errorf("Marshal of float-keyed map succeeded")
+	}
+}
+
+func TestJSONIntKeys(t *testing.T) {
+	m := New[int, []string]()
+	m.Set(10, []string{"x"})
+	m.Set(-2, nil)
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if want := `{"10":["x"],"-2":null}`; string(b) != want {
+		t.Errorf("Marshal = %s, want %s", b, want)
+	}
+	var got Map[int, []string]
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if keys := slices.Collect(got.Keys()); !slices.Equal(keys, []int{10, -2}) {
+		t.Errorf("Unmarshal keys = %v, want [10 -2]", keys)
+	}
+	if err := json.Unmarshal([]byte(`{"x":null}`), &got); err == nil {
+		t.Errorf("Unmarshal with non-integer key succeeded")
+	}
+}
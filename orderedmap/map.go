@@ -0,0 +1,191 @@
+// Package orderedmap implements a map that remembers insertion order.
+package orderedmap
+
+import (
+	"iter"
+
+	"github.com/nishanths/typedcontainer/list"
+)
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Map is a map that iterates in insertion order, from the oldest key to
+// the newest. Updating the value of a key that is already present does
+// not change its position; MoveToFront and MoveToBack do. All operations
+// other than iteration are O(1).
+//
+// The zero value for Map is an empty map ready to use.
+type Map[K comparable, V any] struct {
+	m      map[K]*list.Element[entry[K, V]]
+	l      list.List[entry[K, V]] // oldest first
+	frozen bool
+}
+
+// New returns an empty Map.
+func New[K comparable, V any]() *Map[K, V] {
+	return &Map[K, V]{m: make(map[K]*list.Element[entry[K, V]])}
+}
+
+// Len returns the number of entries in m.
+func (m *Map[K, V]) Len() int {
+	return len(m.m)
+}
+
+// Contains reports whether k is in m.
+func (m *Map[K, V]) Contains(k K) bool {
+	_, ok := m.m[k]
+	return ok
+}
+
+// Get returns the value for k. The boolean is false if k is not in m.
+func (m *Map[K, V]) Get(k K) (V, bool) {
+	e, ok := m.m[k]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.value, true
+}
+
+// GetOr returns the value for k, or def if k is not in m.
+func (m *Map[K, V]) GetOr(k K, def V) V {
+	if v, ok := m.Get(k); ok {
+		return v
+	}
+	return def
+}
+
+// MustGet returns the value for k. It panics if k is not in m.
+func (m *Map[K, V]) MustGet(k K) V {
+	v, ok := m.Get(k)
+	if !ok {
+		panic("orderedmap: MustGet of missing key")
+	}
+	return v
+}
+
+// Set sets the value for k. A new key is added as the newest entry; an
+// existing key keeps its position. Set reports whether k was added.
+func (m *Map[K, V]) Set(k K, v V) bool {
+	m.checkFrozen()
+	if e, ok := m.m[k]; ok {
+		e.Value.value = v
+		return false
+	}
+	if m.m == nil {
+		m.m = make(map[K]*list.Element[entry[K, V]])
+	}
+	m.m[k] = m.l.PushBack(entry[K, V]{key: k, value: v})
+	return true
+}
+
+// Delete removes k from m. It reports whether k was in m.
+func (m *Map[K, V]) Delete(k K) bool {
+	m.checkFrozen()
+	e, ok := m.m[k]
+	if !ok {
+		return false
+	}
+	delete(m.m, k)
+	m.l.Remove(e)
+	return true
+}
+
+// MoveToFront makes k the oldest entry. It reports whether k is in m.
+func (m *Map[K, V]) MoveToFront(k K) bool {
+	m.checkFrozen()
+	e, ok := m.m[k]
+	if ok {
+		m.l.MoveToFront(e)
+	}
+	return ok
+}
+
+// MoveToBack makes k the newest entry. It reports whether k is in m.
+func (m *Map[K, V]) MoveToBack(k K) bool {
+	m.checkFrozen()
+	e, ok := m.m[k]
+	if ok {
+		m.l.MoveToBack(e)
+	}
+	return ok
+}
+
+// Oldest returns the oldest key and its value. The boolean is false if m
+// is empty.
+func (m *Map[K, V]) Oldest() (K, V, bool) {
+	return unpack(m.l.Front())
+}
+
+// Newest returns the newest key and its value. The boolean is false if m
+// is empty.
+func (m *Map[K, V]) Newest() (K, V, bool) {
+	return unpack(m.l.Back())
+}
+
+func unpack[K comparable, V any](e *list.Element[entry[K, V]]) (K, V, bool) {
+	if e == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return e.Value.key, e.Value.value, true
+}
+
+// Clear removes all entries from m.
+func (m *Map[K, V]) Clear() {
+	m.checkFrozen()
+	clear(m.m)
+	m.l.Init()
+}
+
+// All returns an iterator over the keys and values of m, oldest first.
+// It is safe to delete the current key during iteration.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := range m.l.Elements() {
+			if !yield(e.Value.key, e.Value.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the keys and values of m, newest
+// first. It is safe to delete the current key during iteration.
+func (m *Map[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var prev *list.Element[entry[K, V]]
+		for e := m.l.Back(); e != nil; e = prev {
+			prev = e.Prev()
+			if !yield(e.Value.key, e.Value.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of m, oldest first.
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of m, oldest first.
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package orderedmap
+
+import (
+	"slices"
+	"testing"
+)
+
+func checkKeys(t *testing.T, m *Map[string, int], want []string) {
+	t.Helper()
+	if got := slices.Collect(m.Keys()); !slices.Equal(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if m.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", m.Len(), len(want))
+	}
+	var back []string
+	for k := range m.Backward() {
+		back = append(back, k)
+	}
+	slices.Reverse(back)
+	if !slices.Equal(back, want) {
+		t.Errorf("Backward() reversed = %v, want %v", back, want)
+	}
+}
+
+func TestMap(t *testing.T) {
+	var m Map[string, int]
+	if _, _, ok := m.Oldest(); ok {
+		t.Errorf("Oldest on empty map reported true")
+	}
+	if !m.Set("a", 1) || !m.Set("b", 2) || !m.Set("c", 3) {
+		t.Errorf("Set of new keys reported false")
+	}
+	if m.Set("a", 10) {
+		t.Errorf("Set of existing key reported true")
+	}
+	checkKeys(t, &m, []string{"a", "b", "c"})
+	if got := slices.Collect(m.Values()); !slices.Equal(got, []int{10, 2, 3}) {
+		t.Errorf("Values() = %v, want [10 2 3]", got)
+	}
+
+	m.MoveToBack("a")
+	m.MoveToFront("c")
+	checkKeys(t, &m, []string{"c", "b", "a"})
+	if k, v, _ := m.Oldest(); k != "c" || v != 3 {
+		t.Errorf("Oldest() = %q, %d; want c, 3", k, v)
+	}
+	if k, v, _ := m.Newest(); k != "a" || v != 10 {
+		t.Errorf("Newest() = %q, %d; want a, 10", k, v)
+	}
+	if m.MoveToBack("zz") {
+		t.Errorf("MoveToBack of missing key reported true")
+	}
+
+	for k, v := range m.All() {
+		if v%2 == 0 {
+			m.Delete(k)
+		}
+	}
+	checkKeys(t, &m, []string{"c"})
+	if m.MustGet("c") != 3 || m.GetOr("b", -1) != -1 || m.Contains("a") {
+		t.Errorf("lookups after deletion are wrong")
+	}
+	if m.Delete("b") {
+		t.Errorf("Delete of missing key reported true")
+	}
+	m.Clear()
+	checkKeys(t, &m, nil)
+}
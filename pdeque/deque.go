@@ -0,0 +1,240 @@
+// Package pdeque implements a double-ended priority queue.
+package pdeque
+
+import (
+	"cmp"
+	"math/bits"
+	"slices"
+)
+
+// Deque is a double-ended priority queue: both its best element, the
+// least according to less, and its worst element, the greatest, can be
+// inspected in O(1) and removed in O(log n). Push is O(log n). Elements
+// that compare equal are returned in unspecified order.
+//
+// Deque is stored as a min-max heap: a binary heap whose even levels are
+// ordered like a min-heap and whose odd levels are ordered like a max-heap,
+// so the best element is at the root and the worst is one of its
+// children.
+//
+// The zero value for Deque is not usable; use New or NewOrdered.
+type Deque[T any] struct {
+	data []T
+	less func(a, b T) bool
+}
+
+// New returns an empty Deque ordered by less.
+func New[T any](less func(a, b T) bool) *Deque[T] {
+	return &Deque[T]{less: less}
+}
+
+// NewOrdered returns an empty Deque whose best element is the smallest.
+func NewOrdered[T cmp.Ordered]() *Deque[T] {
+	return New(cmp.Less[T])
+}
+
+// Len returns the number of elements in d.
+func (d *Deque[T]) Len() int {
+	return len(d.data)
+}
+
+// Push adds v to d.
+func (d *Deque[T]) Push(v T) {
+	d.data = append(d.data, v)
+	d.up(len(d.data) - 1)
+}
+
+// PeekBest returns the least element of d without removing it. The
+// boolean is false if d is empty.
+func (d *Deque[T]) PeekBest() (T, bool) {
+	if len(d.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.data[0], true
+}
+
+// PeekWorst returns the greatest element of d without removing it. The
+// boolean is false if d is empty.
+func (d *Deque[T]) PeekWorst() (T, bool) {
+	if len(d.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.data[d.worst()], true
+}
+
+// PopBest removes and returns the least element of d. The boolean is
+// false if d is empty.
+func (d *Deque[T]) PopBest() (T, bool) {
+	if len(d.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.remove(0), true
+}
+
+// PopWorst removes and returns the greatest element of d. The boolean is
+// false if d is empty.
+func (d *Deque[T]) PopWorst() (T, bool) {
+	if len(d.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.remove(d.worst()), true
+}
+
+// Peek is equivalent to PeekBest.
+func (d *Deque[T]) Peek() (T, bool) {
+	return d.PeekBest()
+}
+
+// Pop is equivalent to PopBest.
+func (d *Deque[T]) Pop() (T, bool) {
+	return d.PopBest()
+}
+
+// PopN removes and returns up to n of the least elements of d, in order,
+// or nil if none are removed. Popping every element sorts d once, in
+// O(n log n); popping k < d.Len() elements costs O(k log n).
+func (d *Deque[T]) PopN(n int) []T {
+	if n < len(d.data) {
+		var out []T
+		for len(out) < n {
+			out = append(out, d.remove(0))
+		}
+		return out
+	}
+	if len(d.data) == 0 {
+		return nil
+	}
+	out := d.data
+	slices.SortFunc(out, func(a, b T) int {
+		switch {
+		case d.less(a, b):
+			return -1
+		case d.less(b, a):
+			return +1
+		}
+		return 0
+	})
+	d.data = nil
+	return out
+}
+
+// PopWhile removes and returns, in order, the least elements of d for as
+// long as pred reports true for them, or nil if none are removed. It
+// costs O(k log n) for k elements.
+func (d *Deque[T]) PopWhile(pred func(T) bool) []T {
+	var out []T
+	for len(d.data) > 0 && pred(d.data[0]) {
+		out = append(out, d.remove(0))
+	}
+	return out
+}
+
+// Clear removes all elements from d.
+func (d *Deque[T]) Clear() {
+	clear(d.data)
+	d.data = d.data[:0]
+}
+
+// worst returns the index of the greatest element of the non-empty d.
+func (d *Deque[T]) worst() int {
+	switch len(d.data) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if d.less(d.data[1], d.data[2]) {
+		return 2
+	}
+	return 1
+}
+
+// remove removes and returns the element at index i, which is the root
+// of the min or max ordering of its level.
+func (d *Deque[T]) remove(i int) T {
+	n := len(d.data) - 1
+	v := d.data[i]
+	d.data[i] = d.data[n]
+	var zero T
+	d.data[n] = zero // drop the reference for the GC
+	d.data = d.data[:n]
+	if i < n {
+		d.down(i)
+	}
+	return v
+}
+
+// isMinLevel reports whether index i is on a min level of the heap.
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// before reports whether a belongs above b on a level of the given kind.
+func (d *Deque[T]) before(a, b T, minLevel bool) bool {
+	if minLevel {
+		return d.less(a, b)
+	}
+	return d.less(b, a)
+}
+
+func (d *Deque[T]) swap(i, j int) {
+	d.data[i], d.data[j] = d.data[j], d.data[i]
+}
+
+func (d *Deque[T]) up(i int) {
+	if i == 0 {
+		return
+	}
+	minLevel := isMinLevel(i)
+	p := (i - 1) / 2
+	if d.before(d.data[p], d.data[i], minLevel) {
+		// i belongs on the levels of the other kind.
+		d.swap(i, p)
+		d.upGrand(p, !minLevel)
+	} else {
+		d.upGrand(i, minLevel)
+	}
+}
+
+// upGrand moves the element at i up through its grandparents, which are on
+// levels of the same kind.
+func (d *Deque[T]) upGrand(i int, minLevel bool) {
+	for i > 2 {
+		g := ((i-1)/2 - 1) / 2
+		if !d.before(d.data[i], d.data[g], minLevel) {
+			break
+		}
+		d.swap(i, g)
+		i = g
+	}
+}
+
+func (d *Deque[T]) down(i int) {
+	minLevel := isMinLevel(i)
+	n := len(d.data)
+	for {
+		// Find the first in order among i's children and grandchildren.
+		m := -1
+		first := 2*i + 1
+		for _, c := range [...]int{first, first + 1, 2*first + 1, 2*first + 2, 2*first + 3, 2*first + 4} {
+			if c < n && (m < 0 || d.before(d.data[c], d.data[m], minLevel)) {
+				m = c
+			}
+		}
+		if m < 0 || !d.before(d.data[m], d.data[i], minLevel) {
+			return
+		}
+		d.swap(i, m)
+		if m <= first+1 {
+			return // a child has no descendants to disturb
+		}
+		if p := (m - 1) / 2; d.before(d.data[p], d.data[m], minLevel) {
+			d.swap(m, p)
+		}
+		i = m
+	}
+}
@@ -0,0 +1,114 @@
+package pdeque
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestDeque(t *testing.T) {
+	d := NewOrdered[int]()
+	if _, ok := d.PopBest(); ok {
+		t.Errorf("PopBest on empty deque reported true")
+	}
+	if _, ok := d.PeekWorst(); ok {
+		t.Errorf("PeekWorst on empty deque reported true")
+	}
+	for _, v := range []int{5, 1, 9, 3, 7} {
+		d.Push(v)
+	}
+	if v, _ := d.PeekBest(); v != 1 {
+		t.Errorf("PeekBest() = %d, want 1", v)
+	}
+	if v, _ := d.PeekWorst(); v != 9 {
+		t.Errorf("PeekWorst() = %d, want 9", v)
+	}
+	var got []int
+	for d.Len() > 0 {
+		b, _ := d.PopBest()
+		got = append(got, b)
+		if w, ok := d.PopWorst(); ok {
+			got = append(got, w)
+		}
+	}
+	if want := []int{1, 9, 3, 7, 5}; !slices.Equal(got, want) {
+		t.Errorf("alternating pops = %v, want %v", got, want)
+	}
+
+	d.Push(2)
+	d.Clear()
+	if d.Len() != 0 {
+		t.Errorf("Len() after Clear = %d, want 0", d.Len())
+	}
+}
+
+func TestDequePopN(t *testing.T) {
+	d := New(func(a, b int) bool { return a > b })
+	for _, v := range []int{5, 1, 9, 3, 7, 2} {
+		d.Push(v)
+	}
+	if got := d.PopN(2); !slices.Equal(got, []int{9, 7}) {
+		t.Errorf("PopN(2) = %v, want [9 7]", got)
+	}
+	if got := d.PopWhile(func(v int) bool { return v > 2 }); !slices.Equal(got, []int{5, 3}) {
+		t.Errorf("PopWhile(> 2) = %v, want [5 3]", got)
+	}
+	d.Push(4)
+	if got := d.PopN(10); !slices.Equal(got, []int{4, 2, 1}) || d.Len() != 0 {
+		t.Errorf("PopN(10) draining = %v, want [4 2 1]", got)
+	}
+	if got := d.PopN(1); got != nil {
+		t.Errorf("PopN on empty deque = %v, want nil", got)
+	}
+	d.Push(8)
+	if v, _ := d.PeekWorst(); v != 8 {
+		t.Errorf("PeekWorst() after drain = %d, want 8", v)
+	}
+}
+
+func TestDequeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	d := New(func(a, b int) bool { return a > b }) // best is largest
+	var ref []int                                  // sorted ascending
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(5); {
+		case op < 3:
+			v := r.Intn(100)
+			d.Push(v)
+			j, _ := slices.BinarySearch(ref, v)
+			ref = slices.Insert(ref, j, v)
+		case op == 3:
+			v, ok := d.PopBest()
+			if ok != (len(ref) > 0) {
+				t.Fatalf("PopBest() ok = %v with %d elements", ok, len(ref))
+			}
+			if ok {
+				if want := ref[len(ref)-1]; v != want {
+					t.Fatalf("PopBest() = %d, want %d", v, want)
+				}
+				ref = ref[:len(ref)-1]
+			}
+		default:
+			v, ok := d.PopWorst()
+			if ok != (len(ref) > 0) {
+				t.Fatalf("PopWorst() ok = %v with %d elements", ok, len(ref))
+			}
+			if ok {
+				if v != ref[0] {
+					t.Fatalf("PopWorst() = %d, want %d", v, ref[0])
+				}
+				ref = ref[1:]
+			}
+		}
+		if d.Len() != len(ref) {
+			t.Fatalf("Len() = %d, want %d", d.Len(), len(ref))
+		}
+		if len(ref) > 0 {
+			b, _ := d.PeekBest()
+			w, _ := d.PeekWorst()
+			if b != ref[len(ref)-1] || w != ref[0] {
+				t.Fatalf("PeekBest, PeekWorst = %d, %d; want %d, %d", b, w, ref[len(ref)-1], ref[0])
+			}
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Package pipe implements a bounded single-producer, single-consumer
+// buffer that moves values in batches.
+//
+// A channel synchronizes on every value sent. A Pipe takes its lock once
+// per batch and wakes the other side only when it is actually waiting, so
+// in pipelines that can batch, such as readers feeding parsers, the
+// per-value cost is a copy.
+package pipe
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by the send methods after Close, and by the
+// receive methods once a closed Pipe is empty.
+var ErrClosed = errors.New("pipe: closed")
+
+// Pipe is a bounded FIFO buffer between one sending goroutine and one
+// receiving goroutine. Sends wait while the buffer is full and receives
+// wait while it is empty; both can be abandoned through a context.
+//
+// At most one goroutine may send and one receive at a time. Close, Len,
+// and Cap may be called from any goroutine.
+type Pipe[T any] struct {
+	mu          sync.Mutex
+	buf         []T
+	head, n     int
+	closed      bool
+	recvWaiting bool
+	sendWaiting bool
+
+	readable chan struct{} // signalled when a waiting receiver can make progress
+	writable chan struct{} // signalled when a waiting sender can make progress
+	done     chan struct{} // closed by Close
+}
+
+// New returns an empty Pipe that buffers up to capacity values. It panics
+// if capacity is not positive.
+func New[T any](capacity int) *Pipe[T] {
+	if capacity <= 0 {
+		panic("pipe: New with non-positive capacity")
+	}
+	return &Pipe[T]{
+		buf:      make([]T, capacity),
+		readable: make(chan struct{}, 1),
+		writable: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+}
+
+// Cap returns the capacity of p.
+func (p *Pipe[T]) Cap() int {
+	return len(p.buf)
+}
+
+// Len returns the number of values buffered in p.
+func (p *Pipe[T]) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.n
+}
+
+// signal wakes the goroutine waiting on ch, if any, without blocking.
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// put copies as many values from vs as fit into the buffer and returns how
+// many it copied. p.mu must be held.
+func (p *Pipe[T]) put(vs []T) int {
+	k := min(len(vs), len(p.buf)-p.n)
+	tail := (p.head + p.n) % len(p.buf)
+	m := copy(p.buf[tail:], vs[:k])
+	copy(p.buf, vs[m:k])
+	p.n += k
+	return k
+}
+
+// take moves as many values as are available into dst and returns how
+// many it moved. p.mu must be held.
+func (p *Pipe[T]) take(dst []T) int {
+	k := min(len(dst), p.n)
+	end := p.head + k
+	if end <= len(p.buf) {
+		copy(dst, p.buf[p.head:end])
+		clear(p.buf[p.head:end])
+	} else {
+		m := copy(dst, p.buf[p.head:])
+		clear(p.buf[p.head:])
+		copy(dst[m:k], p.buf[:k-m])
+		clear(p.buf[:k-m])
+	}
+	p.head = (p.head + k) % len(p.buf)
+	p.n -= k
+	return k
+}
+
+// wait blocks until ch is signalled, p is closed, or ctx is done. waiting
+// is the flag that told the other side to signal ch; it is cleared if
+// wait returns early.
+func (p *Pipe[T]) wait(ctx context.Context, ch chan struct{}, waiting *bool) error {
+	select {
+	case <-ch:
+		return nil
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		*waiting = false
+		p.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// SendBatch appends the values of vs to p, in order, waiting for room as
+// needed. It returns the number of values sent, which is less than
+// len(vs) only if p is closed, in which case the error is ErrClosed, or
+// ctx is done, in which case it is ctx.Err(). p does not retain vs.
+func (p *Pipe[T]) SendBatch(ctx context.Context, vs []T) (int, error) {
+	sent := 0
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return sent, ErrClosed
+		}
+		k := p.put(vs[sent:])
+		sent += k
+		if k > 0 && p.recvWaiting {
+			p.recvWaiting = false
+			signal(p.readable)
+		}
+		if sent == len(vs) {
+			p.mu.Unlock()
+			return sent, nil
+		}
+		if k > 0 {
+			// Let the receiver in before waiting.
+			p.mu.Unlock()
+			continue
+		}
+		p.sendWaiting = true
+		p.mu.Unlock()
+		if err := p.wait(ctx, p.writable, &p.sendWaiting); err != nil {
+			return sent, err
+		}
+	}
+}
+
+// Send appends v to p, waiting for room if p is full. It returns ErrClosed
+// if p is closed, or ctx.Err() if ctx is done first.
+func (p *Pipe[T]) Send(ctx context.Context, v T) error {
+	_, err := p.SendBatch(ctx, []T{v})
+	return err
+}
+
+// RecvBatch waits until p has at least one value, then moves up to
+// len(dst) values into dst and returns how many it moved. It returns
+// ErrClosed once p is closed and empty, or ctx.Err() if ctx is done before
+// any value arrives. RecvBatch returns immediately if dst is empty.
+func (p *Pipe[T]) RecvBatch(ctx context.Context, dst []T) (int, error) {
+	if len(dst) == 0 {
+		return 0, nil
+	}
+	for {
+		p.mu.Lock()
+		if p.n > 0 {
+			k := p.take(dst)
+			if p.sendWaiting {
+				p.sendWaiting = false
+				signal(p.writable)
+			}
+			p.mu.Unlock()
+			return k, nil
+		}
+		if p.closed {
+			p.mu.Unlock()
+			return 0, ErrClosed
+		}
+		p.recvWaiting = true
+		p.mu.Unlock()
+		if err := p.wait(ctx, p.readable, &p.recvWaiting); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Recv removes and returns the oldest value in p, waiting for one if p is
+// empty. It returns ErrClosed once p is closed and empty, or ctx.Err() if
+// ctx is done first.
+func (p *Pipe[T]) Recv(ctx context.Context) (T, error) {
+	var v [1]T
+	_, err := p.RecvBatch(ctx, v[:])
+	return v[0], err
+}
+
+// Close closes p. Later sends fail with ErrClosed, and a send that is
+// waiting returns ErrClosed with the values it had sent so far. Values
+// already buffered can still be received. Close may be called more than
+// once.
+func (p *Pipe[T]) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		p.closed = true
+		close(p.done)
+	}
+}
@@ -0,0 +1,167 @@
+package pipe
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPipe(t *testing.T) {
+	ctx := context.Background()
+	p := New[int](4)
+	if p.Cap() != 4 {
+		t.Errorf("Cap() = %d, want 4", p.Cap())
+	}
+	if n, err := p.SendBatch(ctx, []int{1, 2, 3}); n != 3 || err != nil {
+		t.Fatalf("SendBatch = %d, %v; want 3, nil", n, err)
+	}
+	dst := make([]int, 2)
+	if n, err := p.RecvBatch(ctx, dst); n != 2 || err != nil || !slices.Equal(dst, []int{1, 2}) {
+		t.Errorf("RecvBatch = %d, %v, %v; want 2, nil, [1 2]", n, err, dst)
+	}
+	// Wrap around the end of the buffer.
+	if n, _ := p.SendBatch(ctx, []int{4, 5, 6}); n != 3 || p.Len() != 4 {
+		t.Errorf("SendBatch n = %d, Len() = %d; want 3, 4", n, p.Len())
+	}
+	dst = make([]int, 10)
+	if n, _ := p.RecvBatch(ctx, dst); !slices.Equal(dst[:n], []int{3, 4, 5, 6}) {
+		t.Errorf("RecvBatch got %v, want [3 4 5 6]", dst[:n])
+	}
+	if n, err := p.RecvBatch(ctx, nil); n != 0 || err != nil {
+		t.Errorf("RecvBatch(nil) = %d, %v", n, err)
+	}
+
+	if err := p.Send(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+	p.Close()
+	p.Close()
+	if err := p.Send(ctx, 8); err != ErrClosed {
+		t.Errorf("Send after Close = %v, want ErrClosed", err)
+	}
+	if v, err := p.Recv(ctx); v != 7 || err != nil {
+		t.Errorf("Recv after Close = %d, %v; want 7, nil", v, err)
+	}
+	if _, err := p.Recv(ctx); err != ErrClosed {
+		t.Errorf("Recv of closed, empty pipe = %v, want ErrClosed", err)
+	}
+}
+
+func TestPipeContext(t *testing.T) {
+	p := New[int](2)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := p.Recv(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Recv on empty pipe = %v, want DeadlineExceeded", err)
+	}
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel2()
+	if n, err := p.SendBatch(ctx2, []int{1, 2, 3}); n != 2 || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SendBatch into full pipe = %d, %v; want 2, DeadlineExceeded", n, err)
+	}
+	// The pipe still works after abandoned waits.
+	if v, err := p.Recv(context.Background()); v != 1 || err != nil {
+		t.Errorf("Recv = %d, %v; want 1, nil", v, err)
+	}
+}
+
+func TestPipeCloseWakesSender(t *testing.T) {
+	p := New[int](1)
+	p.Send(context.Background(), 0)
+	errc := make(chan error)
+	go func() {
+		_, err := p.SendBatch(context.Background(), []int{1, 2})
+		errc <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+	p.Close()
+	if err := <-errc; err != ErrClosed {
+		t.Errorf("blocked SendBatch after Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestPipeStream(t *testing.T) {
+	const n = 100000
+	p := New[int](64)
+	ctx := context.Background()
+	go func() {
+		batch := make([]int, 0, 37)
+		for i := 0; i < n; i++ {
+			batch = append(batch, i)
+			if len(batch) == cap(batch) || i == n-1 {
+				if _, err := p.SendBatch(ctx, batch); err != nil {
+					panic(err)
+				}
+				batch = batch[:0]
+			}
+		}
+		p.Close()
+	}()
+	want := 0
+	dst := make([]int, 50)
+	for {
+		k, err := p.RecvBatch(ctx, dst)
+		if err == ErrClosed {
+			break
+		}
+		for _, v := range dst[:k] {
+			if v != want {
+				t.Fatalf("received %d, want %d", v, want)
+			}
+			want++
+		}
+	}
+	if want != n {
+		t.Errorf("received %d values, want %d", want, n)
+	}
+}
+
+const benchBatch = 64
+
+func BenchmarkChannel(b *testing.B) {
+	ch := make(chan int, 1024)
+	go func() {
+		for i := range b.N {
+			ch <- i
+		}
+		close(ch)
+	}()
+	for range ch {
+	}
+}
+
+func BenchmarkPipe(b *testing.B) {
+	p := New[int](1024)
+	ctx := context.Background()
+	go func() {
+		batch := make([]int, benchBatch)
+		for sent := 0; sent < b.N; sent += benchBatch {
+			p.SendBatch(ctx, batch[:min(benchBatch, b.N-sent)])
+		}
+		p.Close()
+	}()
+	dst := make([]int, benchBatch)
+	for {
+		if _, err := p.RecvBatch(ctx, dst); err != nil {
+			return
+		}
+	}
+}
+
+func BenchmarkPipeSingle(b *testing.B) {
+	p := New[int](1024)
+	ctx := context.Background()
+	go func() {
+		for i := range b.N {
+			p.Send(ctx, i)
+		}
+		p.Close()
+	}()
+	for {
+		if _, err := p.Recv(ctx); err != nil {
+			return
+		}
+	}
+}
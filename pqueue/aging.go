@@ -0,0 +1,160 @@
+package pqueue
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// AgingFunc returns the effective priority of an element with base
+// priority p that has been waiting for d. It should not decrease as d
+// grows.
+type AgingFunc func(p float64, d time.Duration) float64
+
+// LinearAging returns an AgingFunc that raises priority by perSecond for
+// every second of waiting.
+func LinearAging(perSecond float64) AgingFunc {
+	return func(p float64, d time.Duration) float64 {
+		return p + perSecond*d.Seconds()
+	}
+}
+
+type agingItem[T any] struct {
+	value    T
+	prio     float64   // base priority
+	enqueued time.Time // time of Push
+	eff      float64   // effective priority as of the last Rebalance
+}
+
+// Aging is a max-priority queue in which the effective priority of an
+// element grows while it waits, so that low-priority elements are
+// eventually served. Pop removes the element with the highest effective
+// priority.
+//
+// Effective priorities are computed by the AgingFunc when an element is
+// pushed and again on every call to Rebalance, which callers should make
+// periodically (for example once per scheduling round). Push and Pop are
+// O(log n); Rebalance is O(n).
+//
+// Use NewAging to create an Aging queue.
+type Aging[T any] struct {
+	age   AgingFunc
+	items []agingItem[T]
+	ref   time.Time // time of the last Rebalance
+}
+
+// NewAging returns an empty Aging queue that uses age to compute effective
+// priorities.
+func NewAging[T any](age AgingFunc) *Aging[T] {
+	return &Aging[T]{age: age}
+}
+
+// Len returns the number of elements in q.
+func (q *Aging[T]) Len() int {
+	return len(q.items)
+}
+
+// Push adds v with base priority p, enqueued at now.
+func (q *Aging[T]) Push(v T, p float64, now time.Time) {
+	it := agingItem[T]{value: v, prio: p, enqueued: now}
+	it.eff = q.age(p, q.waited(it))
+	q.items = append(q.items, it)
+	q.up(len(q.items) - 1)
+}
+
+// Rebalance recomputes the effective priority of every element as of now
+// and restores the queue order.
+func (q *Aging[T]) Rebalance(now time.Time) {
+	q.ref = now
+	for i := range q.items {
+		it := &q.items[i]
+		it.eff = q.age(it.prio, q.waited(*it))
+	}
+	for i := len(q.items)/2 - 1; i >= 0; i-- {
+		q.down(i)
+	}
+}
+
+// Peek returns the element with the highest effective priority without
+// removing it. The boolean is false if q is empty.
+func (q *Aging[T]) Peek() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items[0].value, true
+}
+
+// Pop removes and returns the element with the highest effective priority.
+// The boolean is false if q is empty.
+func (q *Aging[T]) Pop() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	n := len(q.items) - 1
+	v := q.items[0].value
+	q.items[0] = q.items[n]
+	q.items[n] = agingItem[T]{}
+	q.items = q.items[:n]
+	q.down(0)
+	return v, true
+}
+
+// drain removes and returns all elements in decreasing order of effective
+// priority, or nil if q is empty.
+func (q *Aging[T]) drain() []T {
+	if len(q.items) == 0 {
+		return nil
+	}
+	slices.SortFunc(q.items, func(a, b agingItem[T]) int { return cmp.Compare(b.eff, a.eff) })
+	out := make([]T, len(q.items))
+	for i := range q.items {
+		out[i] = q.items[i].value
+	}
+	clear(q.items)
+	q.items = q.items[:0]
+	return out
+}
+
+// waited returns how long it has waited as of the last Rebalance.
+func (q *Aging[T]) waited(it agingItem[T]) time.Duration {
+	if d := q.ref.Sub(it.enqueued); d > 0 {
+		return d
+	}
+	return 0
+}
+
+func (q *Aging[T]) less(i, j int) bool {
+	return q.items[i].eff > q.items[j].eff
+}
+
+func (q *Aging[T]) up(j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !q.less(j, i) {
+			break
+		}
+		q.items[i], q.items[j] = q.items[j], q.items[i]
+		j = i
+	}
+}
+
+func (q *Aging[T]) down(i int) {
+	n := len(q.items)
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && q.less(j2, j1) {
+			j = j2 // = 2*i + 2  // right child
+		}
+		if !q.less(j, i) {
+			break
+		}
+		q.items[i], q.items[j] = q.items[j], q.items[i]
+		i = j
+	}
+}
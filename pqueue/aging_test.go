@@ -0,0 +1,52 @@
+package pqueue
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAging(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := NewAging[string](LinearAging(1))
+	if _, ok := q.Pop(); ok {
+		t.Errorf("Pop on empty queue reported true")
+	}
+
+	q.Push("low", 0, t0)
+	q.Push("mid", 5, t0.Add(8*time.Second))
+	q.Push("high", 10, t0.Add(8*time.Second))
+	if v, _ := q.Peek(); v != "high" {
+		t.Errorf("Peek() = %q, want high", v)
+	}
+
+	// At 20s low (effective 20) has overtaken mid (17) but not high (22).
+	q.Rebalance(t0.Add(20 * time.Second))
+	var got []string
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		got = append(got, v)
+	}
+	if want := []string{"high", "low", "mid"}; !slices.Equal(got, want) {
+		t.Errorf("pop order = %v, want %v", got, want)
+	}
+}
+
+func TestAgingStarvation(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := NewAging[int](LinearAging(1))
+	q.Push(-1, 0, t0) // the low-priority element
+	now := t0
+	for i := 0; i < 100; i++ {
+		now = now.Add(time.Second)
+		q.Push(i, 10, now)
+		q.Rebalance(now)
+		if v, _ := q.Pop(); v == -1 {
+			if i < 9 {
+				t.Fatalf("low-priority element served too early, at round %d", i)
+			}
+			return
+		}
+	}
+	t.Fatalf("low-priority element starved")
+}
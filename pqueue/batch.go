@@ -0,0 +1,253 @@
+package pqueue
+
+import (
+	"cmp"
+	"slices"
+)
+
+// popN pops up to n values using pop, which reports false once the queue
+// is empty.
+func popN[T any](n int, pop func() (T, bool)) []T {
+	if n <= 0 {
+		return nil
+	}
+	var out []T
+	for len(out) < n {
+		v, ok := pop()
+		if !ok {
+			break
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// popWhile pops values for as long as the next value satisfies pred.
+func popWhile[T any](pred func(T) bool, peek, pop func() (T, bool)) []T {
+	var out []T
+	for {
+		v, ok := peek()
+		if !ok || !pred(v) {
+			return out
+		}
+		pop()
+		out = append(out, v)
+	}
+}
+
+// sortLess sorts s by less.
+func sortLess[T any](s []T, less func(a, b T) bool) {
+	slices.SortFunc(s, func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return +1
+		}
+		return 0
+	})
+}
+
+// PopN removes and returns up to n of the least elements of h, in order,
+// or nil if none are removed. Popping every element collects the trees
+// in one traversal and sorts them once, without relinking; popping fewer
+// costs one Pop per element.
+func (h *Binomial[T]) PopN(n int) []T {
+	if n < h.size {
+		return popN(n, h.Pop)
+	}
+	if h.size == 0 {
+		return nil
+	}
+	out := make([]T, 0, h.size)
+	stack := []*binomialNode[T]{h.head}
+	for len(stack) > 0 {
+		x := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for ; x != nil; x = x.sibling {
+			out = append(out, x.value)
+			if x.child != nil {
+				stack = append(stack, x.child)
+			}
+		}
+	}
+	sortLess(out, h.less)
+	h.head, h.size = nil, 0
+	return out
+}
+
+// PopWhile removes and returns, in order, the least elements of h for as
+// long as pred reports true for them, or nil if none are removed. For
+// example, it can pop every item due before a deadline.
+func (h *Binomial[T]) PopWhile(pred func(T) bool) []T {
+	return popWhile(pred, h.Peek, h.Pop)
+}
+
+// PopN removes and returns up to n of the least elements of h, in order,
+// or nil if none are removed. Popping every element reads the tree once
+// and sorts, without the node copies that each Pop makes; popping fewer
+// costs one Pop per element.
+func (h *Leftist[T]) PopN(n int) []T {
+	if n < h.size {
+		return popN(n, h.Pop)
+	}
+	if h.size == 0 {
+		return nil
+	}
+	out := make([]T, 0, h.size)
+	stack := []*leftistNode[T]{h.root}
+	for len(stack) > 0 {
+		x := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		out = append(out, x.value)
+		if x.left != nil {
+			stack = append(stack, x.left)
+		}
+		if x.right != nil {
+			stack = append(stack, x.right)
+		}
+	}
+	sortLess(out, h.less)
+	h.root, h.size = nil, 0
+	return out
+}
+
+// PopWhile removes and returns, in order, the least elements of h for as
+// long as pred reports true for them, or nil if none are removed.
+func (h *Leftist[T]) PopWhile(pred func(T) bool) []T {
+	return popWhile(pred, h.Peek, h.Pop)
+}
+
+// PopN removes and returns up to n elements of q in decreasing order of
+// effective priority, or nil if none are removed. Draining the whole
+// queue costs a single sort rather than n separate pops.
+func (q *Aging[T]) PopN(n int) []T {
+	if n < len(q.items) {
+		return popN(n, q.Pop)
+	}
+	return q.drain()
+}
+
+// PopWhile removes and returns, in decreasing order of effective priority,
+// the elements of q for as long as pred reports true for them, or nil if
+// none are removed.
+func (q *Aging[T]) PopWhile(pred func(T) bool) []T {
+	return popWhile(pred, q.Peek, q.Pop)
+}
+
+// KeyedEntry is an entry removed from a Keyed queue by PopN or PopWhile.
+type KeyedEntry[K comparable, P cmp.Ordered, V any] struct {
+	Key      K
+	Priority P
+	Value    V
+}
+
+// PopN removes and returns up to n entries of q in increasing order of
+// priority, or nil if none are removed. Draining the whole queue costs a
+// single sort rather than n separate pops.
+func (q *Keyed[K, P, V]) PopN(n int) []KeyedEntry[K, P, V] {
+	if n < len(q.entries) {
+		return popN(n, q.popEntry)
+	}
+	if len(q.entries) == 0 {
+		return nil
+	}
+	slices.SortFunc(q.entries, func(a, b keyedEntry[K, P, V]) int { return cmp.Compare(a.prio, b.prio) })
+	out := make([]KeyedEntry[K, P, V], len(q.entries))
+	for i, e := range q.entries {
+		out[i] = KeyedEntry[K, P, V]{e.key, e.prio, e.value}
+	}
+	clear(q.entries)
+	q.entries = q.entries[:0]
+	clear(q.index)
+	return out
+}
+
+// PopWhile removes and returns, in increasing order of priority, the
+// entries of q for as long as pred reports true for them, or nil if none
+// are removed.
+func (q *Keyed[K, P, V]) PopWhile(pred func(KeyedEntry[K, P, V]) bool) []KeyedEntry[K, P, V] {
+	peek := func() (KeyedEntry[K, P, V], bool) {
+		k, p, v, ok := q.Peek()
+		return KeyedEntry[K, P, V]{k, p, v}, ok
+	}
+	return popWhile(pred, peek, q.popEntry)
+}
+
+func (q *Keyed[K, P, V]) popEntry() (KeyedEntry[K, P, V], bool) {
+	k, p, v, ok := q.Pop()
+	return KeyedEntry[K, P, V]{k, p, v}, ok
+}
+
+// PriorityEntry is an item removed from a PriorityQueue by PopN or
+// PopWhile.
+type PriorityEntry[T any, P cmp.Ordered] struct {
+	Value    T
+	Priority P
+}
+
+// PopN removes and returns up to n items of q with their priorities, in
+// the order of Pop, or nil if none are removed. Draining the whole queue
+// costs a single sort rather than n separate pops.
+func (q *PriorityQueue[T, P]) PopN(n int) []PriorityEntry[T, P] {
+	if q.Len() == 0 {
+		return nil
+	}
+	return priorityEntries(q.h.PopN(n))
+}
+
+// PopWhile removes and returns, in the order of Pop, the items of q with
+// their priorities for as long as pred reports true for them, or nil if
+// none are removed.
+func (q *PriorityQueue[T, P]) PopWhile(pred func(PriorityEntry[T, P]) bool) []PriorityEntry[T, P] {
+	if q.Len() == 0 {
+		return nil
+	}
+	return priorityEntries(q.h.PopWhile(func(h *Handle[T, P]) bool {
+		return pred(PriorityEntry[T, P]{h.value, h.prio})
+	}))
+}
+
+func priorityEntries[T any, P cmp.Ordered](hs []*Handle[T, P]) []PriorityEntry[T, P] {
+	if len(hs) == 0 {
+		return nil
+	}
+	out := make([]PriorityEntry[T, P], len(hs))
+	for i, h := range hs {
+		out[i] = PriorityEntry[T, P]{h.value, h.prio}
+	}
+	return out
+}
+
+// PopN removes and returns up to n elements of q, lowest priority first
+// and FIFO within a priority, or nil if none are removed. Each priority
+// level is copied out in one step.
+func (q *Bucket[V]) PopN(n int) []V {
+	n = min(n, q.size)
+	if n <= 0 {
+		return nil
+	}
+	out := make([]V, 0, n)
+	for len(out) < n {
+		q.advance()
+		out = q.buckets[q.min].popN(out, n-len(out))
+	}
+	q.size -= n
+	return out
+}
+
+// PopWhile removes and returns, in the order of Pop, the elements of q for
+// as long as pred reports true for them and their priority, or nil if
+// none are removed. For example, pred can stop at the first element of a
+// given priority.
+func (q *Bucket[V]) PopWhile(pred func(p int, v V) bool) []V {
+	var out []V
+	for {
+		p, v, ok := q.Peek()
+		if !ok || !pred(p, v) {
+			return out
+		}
+		q.Pop()
+		out = append(out, v)
+	}
+}
@@ -0,0 +1,216 @@
+package pqueue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nishanths/typedcontainer/heap"
+	"github.com/nishanths/typedcontainer/pdeque"
+)
+
+func TestPopNWhile(t *testing.T) {
+	for name, h := range map[string]PushQueue[int]{
+		"Binomial": NewBinomial(intLess),
+		"Leftist":  NewLeftist(intLess),
+	} {
+		for _, v := range []int{5, 1, 4, 2, 3, 9, 7} {
+			h.Push(v)
+		}
+		if got := h.PopN(0); got != nil {
+			t.Errorf("%s: PopN(0) = %v, want nil", name, got)
+		}
+		if got := h.PopN(2); !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("%s: PopN(2) = %v, want [1 2]", name, got)
+		}
+		if got := h.PopWhile(func(v int) bool { return v < 6 }); !slices.Equal(got, []int{3, 4, 5}) {
+			t.Errorf("%s: PopWhile(< 6) = %v, want [3 4 5]", name, got)
+		}
+		if got := h.PopN(10); !slices.Equal(got, []int{7, 9}) {
+			t.Errorf("%s: PopN(10) = %v, want [7 9]", name, got)
+		}
+		if got := h.PopWhile(func(int) bool { return true }); got != nil || h.Len() != 0 {
+			t.Errorf("%s: PopWhile on empty heap = %v", name, got)
+		}
+		if got := h.PopN(10); got != nil {
+			t.Errorf("%s: PopN on empty heap = %v, want nil", name, got)
+		}
+	}
+}
+
+func TestPopNDrain(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	b, l := NewBinomial(intLess), NewLeftist(intLess)
+	var want []int
+	for range 500 {
+		v := r.Intn(100)
+		b.Push(v)
+		l.Push(v)
+		want = append(want, v)
+	}
+	v, _ := l.Pop() // make the tree irregular
+	l.Push(v)
+	slices.Sort(want)
+	if got := b.PopN(b.Len()); !slices.Equal(got, want) || b.Len() != 0 {
+		t.Errorf("Binomial PopN(Len()) = %v, want %v", got, want)
+	}
+	snap := l.Snapshot()
+	if got := l.PopN(1000); !slices.Equal(got, want) || l.Len() != 0 {
+		t.Errorf("Leftist PopN(1000) = %v, want %v", got, want)
+	}
+	if snap.Len() != len(want) {
+		t.Errorf("snapshot Len() after PopN = %d, want %d", snap.Len(), len(want))
+	}
+	b.Push(3)
+	if v, _ := b.Pop(); v != 3 {
+		t.Errorf("Binomial Pop after drain = %d, want 3", v)
+	}
+}
+
+func TestKeyedPopN(t *testing.T) {
+	q := NewKeyed[string, int, int]()
+	for i, k := range []string{"e", "a", "d", "b", "c"} {
+		q.Push(k, int(k[0]-'a'), i)
+	}
+	if got := q.PopN(2); len(got) != 2 || got[0] != (KeyedEntry[string, int, int]{"a", 0, 1}) || got[1].Key != "b" {
+		t.Errorf("PopN(2) = %v", got)
+	}
+	if got := q.PopWhile(func(e KeyedEntry[string, int, int]) bool { return e.Priority < 3 }); len(got) != 1 || got[0].Key != "c" {
+		t.Errorf("PopWhile(< 3) = %v", got)
+	}
+	if got := q.PopN(5); len(got) != 2 || got[0].Key != "d" || got[1].Key != "e" {
+		t.Errorf("PopN(5) draining = %v", got)
+	}
+	if q.Contains("d") || q.Len() != 0 {
+		t.Errorf("queue not empty after drain")
+	}
+	if got := q.PopN(1); got != nil {
+		t.Errorf("PopN on empty queue = %v, want nil", got)
+	}
+	q.Push("x", 1, 0)
+	if k, _, _, _ := q.Pop(); k != "x" {
+		t.Errorf("Pop after drain = %q, want x", k)
+	}
+}
+
+func TestPriorityQueuePopN(t *testing.T) {
+	var q PriorityQueue[string, int]
+	if got := q.PopN(1); got != nil {
+		t.Errorf("PopN on zero queue = %v, want nil", got)
+	}
+	hs := map[string]*Handle[string, int]{}
+	for _, v := range []string{"c1", "a1", "c2", "b1", "a2", "d1"} {
+		hs[v] = q.Push(v, int(v[0]-'a'))
+	}
+	if got := q.PopN(2); !slices.Equal(got, []PriorityEntry[string, int]{{"a1", 0}, {"a2", 0}}) {
+		t.Errorf("PopN(2) = %v, want [{a1 0} {a2 0}]", got)
+	}
+	if got := q.PopWhile(func(e PriorityEntry[string, int]) bool { return e.Priority < 2 }); !slices.Equal(got, []PriorityEntry[string, int]{{"b1", 1}}) {
+		t.Errorf("PopWhile(< 2) = %v, want [{b1 1}]", got)
+	}
+	q.UpdatePriority(hs["d1"], 0)
+	if got := q.PopN(10); !slices.Equal(got, []PriorityEntry[string, int]{{"d1", 0}, {"c1", 2}, {"c2", 2}}) || q.Len() != 0 {
+		t.Errorf("PopN(10) draining = %v, want [{d1 0} {c1 2} {c2 2}]", got)
+	}
+	if q.Remove(hs["c1"]) || q.UpdatePriority(hs["c2"], 0) {
+		t.Errorf("handle of drained item still reported in queue")
+	}
+	if got := q.PopWhile(func(PriorityEntry[string, int]) bool { return true }); got != nil {
+		t.Errorf("PopWhile on empty queue = %v, want nil", got)
+	}
+	q.Push("x", 1)
+	if v, _, _ := q.Pop(); v != "x" {
+		t.Errorf("Pop after drain = %q, want x", v)
+	}
+}
+
+func TestBucketPopN(t *testing.T) {
+	q := NewBucket[string](3)
+	for _, v := range []string{"c1", "a1", "c2", "b1", "a2"} {
+		q.Push(int(v[0]-'a'), v)
+	}
+	if got := q.PopN(3); !slices.Equal(got, []string{"a1", "a2", "b1"}) {
+		t.Errorf("PopN(3) = %v, want [a1 a2 b1]", got)
+	}
+	if got := q.PopWhile(func(p int, _ string) bool { return p < 2 }); got != nil {
+		t.Errorf("PopWhile(p < 2) = %v, want nil", got)
+	}
+	if got := q.PopWhile(func(_ int, v string) bool { return v != "c2" }); !slices.Equal(got, []string{"c1"}) {
+		t.Errorf("PopWhile(!= c2) = %v, want [c1]", got)
+	}
+	if got := q.PopN(5); !slices.Equal(got, []string{"c2"}) || q.Len() != 0 {
+		t.Errorf("PopN(5) = %v, want [c2]", got)
+	}
+}
+
+func TestAgingPopN(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := NewAging[int](LinearAging(0))
+	for _, p := range []int{5, 1, 4, 2, 3} {
+		q.Push(p, float64(p), now)
+	}
+	if got := q.PopN(2); !slices.Equal(got, []int{5, 4}) {
+		t.Errorf("PopN(2) = %v, want [5 4]", got)
+	}
+	if got := q.PopWhile(func(v int) bool { return v > 2 }); !slices.Equal(got, []int{3}) {
+		t.Errorf("PopWhile(> 2) = %v, want [3]", got)
+	}
+	q.Push(10, 10, now)
+	if got := q.PopN(3); !slices.Equal(got, []int{10, 2, 1}) {
+		t.Errorf("PopN(3) draining = %v, want [10 2 1]", got)
+	}
+	if q.Len() != 0 {
+		t.Errorf("Len() after drain = %d, want 0", q.Len())
+	}
+	if got := q.PopN(3); got != nil {
+		t.Errorf("PopN on empty queue = %v, want nil", got)
+	}
+	q.Push(1, 1, now)
+	if v, _ := q.Pop(); v != 1 {
+		t.Errorf("Pop after drain = %d, want 1", v)
+	}
+}
+
+func BenchmarkDrain(b *testing.B) {
+	const n = 10000
+	vals := rand.New(rand.NewSource(1)).Perm(n)
+	type drainer interface {
+		Push(int)
+		Pop() (int, bool)
+		PopN(int) []int
+	}
+	for _, bm := range []struct {
+		name string
+		new  func() drainer
+	}{
+		{"Binomial", func() drainer { return NewBinomial(intLess) }},
+		{"Leftist", func() drainer { return NewLeftist(intLess) }},
+		{"heap.Heap", func() drainer { return heap.NewOrdered[int]() }},
+		{"pdeque.Deque", func() drainer { return pdeque.NewOrdered[int]() }},
+	} {
+		for _, batched := range []bool{false, true} {
+			name := bm.name + "/Pop"
+			if batched {
+				name = bm.name + "/PopN"
+			}
+			b.Run(name, func(b *testing.B) {
+				for range b.N {
+					b.StopTimer()
+					h := bm.new()
+					for _, v := range vals {
+						h.Push(v)
+					}
+					b.StartTimer()
+					if batched {
+						h.PopN(n)
+					} else {
+						for range n {
+							h.Pop()
+						}
+					}
+				}
+			})
+		}
+	}
+}
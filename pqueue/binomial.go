@@ -0,0 +1,192 @@
+package pqueue
+
+import "cmp"
+
+type binomialNode[T any] struct {
+	value   T
+	degree  int
+	child   *binomialNode[T] // highest-degree child
+	sibling *binomialNode[T]
+}
+
+// Binomial is a binomial heap ordered by a less function: Pop removes the
+// least element. Push, Pop, Peek, and Meld are O(log n), which makes
+// Binomial a good fit for workloads that merge heaps often.
+//
+// Use NewBinomial to create a Binomial heap.
+type Binomial[T any] struct {
+	less func(a, b T) bool
+	head *binomialNode[T] // roots in increasing order of degree
+	size int
+}
+
+// NewBinomial returns an empty Binomial heap ordered by less.
+func NewBinomial[T any](less func(a, b T) bool) *Binomial[T] {
+	return &Binomial[T]{less: less}
+}
+
+// NewBinomialOrdered returns an empty Binomial heap of an ordered type,
+// least value first.
+func NewBinomialOrdered[T cmp.Ordered]() *Binomial[T] {
+	return NewBinomial(cmp.Less[T])
+}
+
+// SetLess replaces the ordering of h with less and rebuilds h to match, in
+// O(n) time. The nodes are reused, so SetLess does not allocate.
+func (h *Binomial[T]) SetLess(less func(a, b T) bool) {
+	var nodes []*binomialNode[T]
+	stack := []*binomialNode[T]{h.head}
+	for len(stack) > 0 {
+		x := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for x != nil {
+			next := x.sibling
+			if x.child != nil {
+				stack = append(stack, x.child)
+			}
+			x.degree, x.child, x.sibling = 0, nil, nil
+			nodes = append(nodes, x)
+			x = next
+		}
+	}
+	h.less = less
+	h.head = nil
+	// Inserting n single nodes costs O(n) in total, like incrementing a
+	// binary counter.
+	for _, x := range nodes {
+		h.head = h.union(h.head, x)
+	}
+}
+
+// Len returns the number of elements in h.
+func (h *Binomial[T]) Len() int {
+	return h.size
+}
+
+// Push adds v to h.
+func (h *Binomial[T]) Push(v T) {
+	h.head = h.union(h.head, &binomialNode[T]{value: v})
+	h.size++
+}
+
+// Peek returns the least element of h without removing it. The boolean is
+// false if h is empty.
+func (h *Binomial[T]) Peek() (T, bool) {
+	_, m := h.min()
+	if m == nil {
+		var zero T
+		return zero, false
+	}
+	return m.value, true
+}
+
+// Pop removes and returns the least element of h. The boolean is false if
+// h is empty.
+func (h *Binomial[T]) Pop() (T, bool) {
+	prev, m := h.min()
+	if m == nil {
+		var zero T
+		return zero, false
+	}
+	if prev == nil {
+		h.head = m.sibling
+	} else {
+		prev.sibling = m.sibling
+	}
+
+	// The children of m form a list of binomial trees in decreasing order
+	// of degree; reverse it before merging it back into the root list.
+	var rev *binomialNode[T]
+	for c := m.child; c != nil; {
+		next := c.sibling
+		c.sibling = rev
+		rev = c
+		c = next
+	}
+	h.head = h.union(h.head, rev)
+	h.size--
+	return m.value, true
+}
+
+// Meld moves all elements of other into h, leaving other empty. The two
+// heaps must use equivalent less functions.
+func (h *Binomial[T]) Meld(other *Binomial[T]) {
+	if other == h {
+		return
+	}
+	h.head = h.union(h.head, other.head)
+	h.size += other.size
+	other.head = nil
+	other.size = 0
+}
+
+// min returns the root holding the least element and its predecessor in
+// the root list.
+func (h *Binomial[T]) min() (prev, m *binomialNode[T]) {
+	m = h.head
+	if m == nil {
+		return nil, nil
+	}
+	for p, x := h.head, h.head.sibling; x != nil; p, x = x, x.sibling {
+		if h.less(x.value, m.value) {
+			prev, m = p, x
+		}
+	}
+	return prev, m
+}
+
+// link makes y a child of x. Both must have the same degree.
+func (h *Binomial[T]) link(x, y *binomialNode[T]) {
+	y.sibling = x.child
+	x.child = y
+	x.degree++
+}
+
+// union merges two root lists and combines trees of equal degree.
+func (h *Binomial[T]) union(a, b *binomialNode[T]) *binomialNode[T] {
+	head := mergeRoots(a, b)
+	if head == nil {
+		return nil
+	}
+	var prev *binomialNode[T]
+	x := head
+	next := x.sibling
+	for next != nil {
+		if x.degree != next.degree || (next.sibling != nil && next.sibling.degree == x.degree) {
+			prev, x = x, next
+		} else if !h.less(next.value, x.value) {
+			x.sibling = next.sibling
+			h.link(x, next)
+		} else {
+			if prev == nil {
+				head = next
+			} else {
+				prev.sibling = next
+			}
+			h.link(next, x)
+			x = next
+		}
+		next = x.sibling
+	}
+	return head
+}
+
+// mergeRoots merges two root lists sorted by degree into one.
+func mergeRoots[T any](a, b *binomialNode[T]) *binomialNode[T] {
+	var head binomialNode[T]
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			tail.sibling, a = a, a.sibling
+		} else {
+			tail.sibling, b = b, b.sibling
+		}
+		tail = tail.sibling
+	}
+	if a != nil {
+		tail.sibling = a
+	} else {
+		tail.sibling = b
+	}
+	return head.sibling
+}
@@ -0,0 +1,111 @@
+package pqueue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+func drainBinomial(h *Binomial[int]) []int {
+	var s []int
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		s = append(s, v)
+	}
+	return s
+}
+
+func TestBinomial(t *testing.T) {
+	h := NewBinomial(intLess)
+	if _, ok := h.Pop(); ok {
+		t.Errorf("Pop on empty heap reported true")
+	}
+	if _, ok := h.Peek(); ok {
+		t.Errorf("Peek on empty heap reported true")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	var want []int
+	for i := 0; i < 200; i++ {
+		v := r.Intn(50)
+		h.Push(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+	if v, _ := h.Peek(); v != want[0] {
+		t.Errorf("Peek() = %d, want %d", v, want[0])
+	}
+	if got := drainBinomial(h); !slices.Equal(got, want) {
+		t.Errorf("pop order = %v, want %v", got, want)
+	}
+}
+
+func TestBinomialMeld(t *testing.T) {
+	a := NewBinomial(intLess)
+	b := NewBinomial(intLess)
+	for i := 0; i < 10; i++ {
+		a.Push(2 * i)
+		b.Push(2*i + 1)
+	}
+	a.Meld(b)
+	if a.Len() != 20 || b.Len() != 0 {
+		t.Errorf("after Meld: a.Len() = %d, b.Len() = %d; want 20, 0", a.Len(), b.Len())
+	}
+	a.Meld(a)
+	got := drainBinomial(a)
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("pop order = %v", got)
+		}
+	}
+}
+
+func TestBinomialInterleaved(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	h := NewBinomial(intLess)
+	var ref []int
+	for i := 0; i < 2000; i++ {
+		if r.Intn(3) > 0 || len(ref) == 0 {
+			v := r.Intn(1000)
+			h.Push(v)
+			ref = append(ref, v)
+			continue
+		}
+		slices.Sort(ref)
+		v, _ := h.Pop()
+		if v != ref[0] {
+			t.Fatalf("Pop() = %d, want %d", v, ref[0])
+		}
+		ref = ref[1:]
+	}
+	if h.Len() != len(ref) {
+		t.Errorf("Len() = %d, want %d", h.Len(), len(ref))
+	}
+}
+
+func TestBinomialSetLess(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	h := NewBinomialOrdered[int]()
+	var want []int
+	for range 200 {
+		v := r.Intn(1000)
+		h.Push(v)
+		want = append(want, v)
+	}
+	h.Pop() // leave an irregular forest
+	slices.Sort(want)
+	want = want[1:]
+	h.SetLess(func(a, b int) bool { return a > b })
+	slices.Reverse(want)
+	if got := drainBinomial(h); !slices.Equal(got, want) {
+		t.Errorf("drain after SetLess(>) = %v, want %v", got, want)
+	}
+	h.SetLess(intLess) // empty heap
+	h.Push(2)
+	h.Push(1)
+	if v, _ := h.Peek(); v != 1 {
+		t.Errorf("Peek() = %d, want 1", v)
+	}
+}
@@ -0,0 +1,128 @@
+package pqueue
+
+// fifo is a queue stored in a slice. Popped slots before head are reused
+// once the queue empties, or reclaimed when they make up most of it.
+type fifo[V any] struct {
+	items []V
+	head  int
+}
+
+func (f *fifo[V]) len() int {
+	return len(f.items) - f.head
+}
+
+func (f *fifo[V]) push(v V) {
+	if f.head > 0 && f.head >= len(f.items)/2 && len(f.items) == cap(f.items) {
+		// Slide the live items down instead of growing.
+		n := copy(f.items, f.items[f.head:])
+		clear(f.items[n:])
+		f.items = f.items[:n]
+		f.head = 0
+	}
+	f.items = append(f.items, v)
+}
+
+func (f *fifo[V]) front() V {
+	return f.items[f.head]
+}
+
+func (f *fifo[V]) pop() V {
+	var zero V
+	v := f.items[f.head]
+	f.items[f.head] = zero // drop the reference for the GC
+	f.head++
+	if f.head == len(f.items) {
+		f.items = f.items[:0]
+		f.head = 0
+	}
+	return v
+}
+
+// popN appends up to n items to dst and returns the extended slice.
+func (f *fifo[V]) popN(dst []V, n int) []V {
+	end := f.head + min(n, f.len())
+	dst = append(dst, f.items[f.head:end]...)
+	clear(f.items[f.head:end])
+	f.head = end
+	if f.head == len(f.items) {
+		f.items = f.items[:0]
+		f.head = 0
+	}
+	return dst
+}
+
+// Bucket is a priority queue for small integer priorities in the range
+// [0, n). Pop removes an element with the lowest priority; elements with
+// equal priority are removed in FIFO order. Push is O(1) and Pop is O(n)
+// in the number of priority levels, which is effectively O(1) for the
+// handful of levels typical in schedulers. Each level is a slice, so
+// pushing allocates only when a level's storage grows.
+//
+// Use NewBucket to create a Bucket queue.
+type Bucket[V any] struct {
+	buckets []fifo[V]
+	min     int // no bucket below min is non-empty
+	size    int
+}
+
+// NewBucket returns an empty Bucket queue for priorities in [0, n).
+func NewBucket[V any](n int) *Bucket[V] {
+	if n <= 0 {
+		panic("pqueue: NewBucket with non-positive number of priorities")
+	}
+	return &Bucket[V]{buckets: make([]fifo[V], n), min: n}
+}
+
+// Len returns the number of elements in q.
+func (q *Bucket[V]) Len() int {
+	return q.size
+}
+
+// Levels returns the number of priority levels in q.
+func (q *Bucket[V]) Levels() int {
+	return len(q.buckets)
+}
+
+// Push adds v with priority p. It panics if p is not in [0, q.Levels()).
+func (q *Bucket[V]) Push(p int, v V) {
+	if p < 0 || p >= len(q.buckets) {
+		panic("pqueue: Bucket priority out of range")
+	}
+	q.buckets[p].push(v)
+	if p < q.min {
+		q.min = p
+	}
+	q.size++
+}
+
+// Peek returns the oldest element with the lowest priority without
+// removing it. The boolean is false if q is empty.
+func (q *Bucket[V]) Peek() (int, V, bool) {
+	if q.size == 0 {
+		var zero V
+		return 0, zero, false
+	}
+	q.advance()
+	return q.min, q.buckets[q.min].front(), true
+}
+
+// Pop removes and returns the oldest element with the lowest priority.
+// The boolean is false if q is empty.
+func (q *Bucket[V]) Pop() (int, V, bool) {
+	if q.size == 0 {
+		var zero V
+		return 0, zero, false
+	}
+	q.advance()
+	v := q.buckets[q.min].pop()
+	q.size--
+	return q.min, v, true
+}
+
+// advance moves min forward to the first non-empty bucket. q must not be
+// empty.
+func (q *Bucket[V]) advance() {
+	for q.buckets[q.min].len() == 0 {
+		q.min++
+	}
+}
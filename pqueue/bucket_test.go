@@ -0,0 +1,96 @@
+package pqueue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestBucket(t *testing.T) {
+	q := NewBucket[string](3)
+	if _, _, ok := q.Pop(); ok {
+		t.Errorf("Pop on empty queue reported true")
+	}
+
+	q.Push(2, "c1")
+	q.Push(0, "a1")
+	q.Push(2, "c2")
+	q.Push(1, "b1")
+	q.Push(0, "a2")
+	if q.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", q.Len())
+	}
+	if p, v, ok := q.Peek(); p != 0 || v != "a1" || !ok {
+		t.Errorf("Peek() = %d, %q, %v; want 0, a1, true", p, v, ok)
+	}
+
+	var got []string
+	for i := 0; i < 3; i++ {
+		_, v, _ := q.Pop()
+		got = append(got, v)
+	}
+	// Pushing below the current minimum must be honored.
+	q.Push(0, "a3")
+	for q.Len() > 0 {
+		_, v, _ := q.Pop()
+		got = append(got, v)
+	}
+	want := []string{"a1", "a2", "b1", "a3", "c1", "c2"}
+	if !slices.Equal(got, want) {
+		t.Errorf("pop order = %v, want %v", got, want)
+	}
+}
+
+func TestBucketOutOfRange(t *testing.T) {
+	q := NewBucket[int](2)
+	for _, p := range []int{-1, 2} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Push(%d, ...) did not panic", p)
+				}
+			}()
+			q.Push(p, 0)
+		}()
+	}
+}
+
+func TestBucketRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	q := NewBucket[int](4)
+	ref := make([][]int, 4)
+	for i := 0; i < 5000; i++ {
+		if r.Intn(3) > 0 {
+			p := r.Intn(4)
+			q.Push(p, i)
+			ref[p] = append(ref[p], i)
+			continue
+		}
+		p, v, ok := q.Pop()
+		j := slices.IndexFunc(ref, func(b []int) bool { return len(b) > 0 })
+		if ok != (j >= 0) || ok && (p != j || v != ref[j][0]) {
+			t.Fatalf("Pop() = %d, %d, %v; reference level %d", p, v, ok, j)
+		}
+		if ok {
+			ref[j] = ref[j][1:]
+		}
+	}
+	var want []int
+	for _, b := range ref {
+		want = append(want, b...)
+	}
+	if got := q.PopN(q.Len()); !slices.Equal(got, want) {
+		t.Errorf("PopN(Len()) = %v, want %v", got, want)
+	}
+}
+
+func BenchmarkBucket(b *testing.B) {
+	q := NewBucket[int](8)
+	b.ReportAllocs()
+	for i := range b.N {
+		q.Push(i%8, i)
+		if q.Len() > 64 {
+			q.Pop()
+		}
+	}
+}
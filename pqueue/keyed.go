@@ -0,0 +1,170 @@
+// Package pqueue implements priority queues.
+package pqueue
+
+import "cmp"
+
+type keyedEntry[K comparable, P cmp.Ordered, V any] struct {
+	key   K
+	prio  P
+	value V
+}
+
+// Keyed is a min-priority queue in which each key appears at most once.
+// Pushing a key that is already present updates its priority and value
+// instead of adding a second entry. Push, Pop, and Remove are O(log n);
+// Contains and Peek are O(1).
+//
+// The zero value for Keyed is an empty queue ready to use.
+type Keyed[K comparable, P cmp.Ordered, V any] struct {
+	entries []keyedEntry[K, P, V]
+	index   map[K]int // key -> position in entries
+}
+
+// NewKeyed returns an empty Keyed queue.
+func NewKeyed[K comparable, P cmp.Ordered, V any]() *Keyed[K, P, V] {
+	return &Keyed[K, P, V]{index: make(map[K]int)}
+}
+
+// Len returns the number of entries in q.
+func (q *Keyed[K, P, V]) Len() int {
+	return len(q.entries)
+}
+
+// Contains reports whether k is in q.
+func (q *Keyed[K, P, V]) Contains(k K) bool {
+	_, ok := q.index[k]
+	return ok
+}
+
+// Get returns the priority and value for k. The boolean is false if k is
+// not in q.
+func (q *Keyed[K, P, V]) Get(k K) (P, V, bool) {
+	i, ok := q.index[k]
+	if !ok {
+		var p P
+		var v V
+		return p, v, false
+	}
+	e := &q.entries[i]
+	return e.prio, e.value, true
+}
+
+// Push adds k with the given priority and value. If k is already in q, its
+// priority and value are replaced and its position is fixed up.
+func (q *Keyed[K, P, V]) Push(k K, p P, v V) {
+	if i, ok := q.index[k]; ok {
+		q.entries[i].prio = p
+		q.entries[i].value = v
+		q.fix(i)
+		return
+	}
+	if q.index == nil {
+		q.index = make(map[K]int)
+	}
+	q.entries = append(q.entries, keyedEntry[K, P, V]{key: k, prio: p, value: v})
+	i := len(q.entries) - 1
+	q.index[k] = i
+	q.up(i)
+}
+
+// Peek returns the entry with the lowest priority without removing it.
+// The boolean is false if q is empty.
+func (q *Keyed[K, P, V]) Peek() (K, P, V, bool) {
+	if len(q.entries) == 0 {
+		var k K
+		var p P
+		var v V
+		return k, p, v, false
+	}
+	e := &q.entries[0]
+	return e.key, e.prio, e.value, true
+}
+
+// Pop removes and returns the entry with the lowest priority. The boolean
+// is false if q is empty.
+func (q *Keyed[K, P, V]) Pop() (K, P, V, bool) {
+	if len(q.entries) == 0 {
+		var k K
+		var p P
+		var v V
+		return k, p, v, false
+	}
+	e := q.removeAt(0)
+	return e.key, e.prio, e.value, true
+}
+
+// Remove removes k from q and returns its priority and value. The boolean
+// is false if k is not in q.
+func (q *Keyed[K, P, V]) Remove(k K) (P, V, bool) {
+	i, ok := q.index[k]
+	if !ok {
+		var p P
+		var v V
+		return p, v, false
+	}
+	e := q.removeAt(i)
+	return e.prio, e.value, true
+}
+
+func (q *Keyed[K, P, V]) removeAt(i int) keyedEntry[K, P, V] {
+	n := len(q.entries) - 1
+	e := q.entries[i]
+	if i != n {
+		q.swap(i, n)
+	}
+	q.entries[n] = keyedEntry[K, P, V]{} // drop references for the GC
+	q.entries = q.entries[:n]
+	delete(q.index, e.key)
+	if i != n {
+		q.fix(i)
+	}
+	return e
+}
+
+func (q *Keyed[K, P, V]) less(i, j int) bool {
+	return cmp.Less(q.entries[i].prio, q.entries[j].prio)
+}
+
+func (q *Keyed[K, P, V]) swap(i, j int) {
+	q.entries[i], q.entries[j] = q.entries[j], q.entries[i]
+	q.index[q.entries[i].key] = i
+	q.index[q.entries[j].key] = j
+}
+
+func (q *Keyed[K, P, V]) fix(i int) {
+	if !q.down(i) {
+		q.up(i)
+	}
+}
+
+func (q *Keyed[K, P, V]) up(j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !q.less(j, i) {
+			break
+		}
+		q.swap(i, j)
+		j = i
+	}
+}
+
+func (q *Keyed[K, P, V]) down(i0 int) bool {
+	n := len(q.entries)
+	i := i0
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && q.less(j2, j1) {
+			j = j2 // = 2*i + 2  // right child
+		}
+		if !q.less(j, i) {
+			break
+		}
+		q.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
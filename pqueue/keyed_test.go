@@ -0,0 +1,112 @@
+package pqueue
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func verifyKeyed[K comparable, P cmp.Ordered, V any](t *testing.T, q *Keyed[K, P, V]) {
+	t.Helper()
+
+	if len(q.index) != len(q.entries) {
+		t.Fatalf("len(index) = %d, len(entries) = %d", len(q.index), len(q.entries))
+	}
+	for i, e := range q.entries {
+		if j := q.index[e.key]; j != i {
+			t.Errorf("index[%v] = %d, want %d", e.key, j, i)
+		}
+		if i > 0 && q.entries[(i-1)/2].prio > e.prio {
+			t.Errorf("heap invariant violated at %d", i)
+		}
+	}
+}
+
+func drainKeyed[K comparable, P cmp.Ordered, V any](q *Keyed[K, P, V]) []K {
+	var keys []K
+	for q.Len() > 0 {
+		k, _, _, _ := q.Pop()
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestKeyed(t *testing.T) {
+	q := NewKeyed[string, int, int]()
+	if _, _, _, ok := q.Pop(); ok {
+		t.Errorf("Pop on empty queue reported true")
+	}
+
+	q.Push("c", 3, 30)
+	q.Push("a", 1, 10)
+	q.Push("b", 2, 20)
+	verifyKeyed(t, q)
+
+	if k, p, v, ok := q.Peek(); k != "a" || p != 1 || v != 10 || !ok {
+		t.Errorf("Peek() = %v, %v, %v, %v; want a, 1, 10, true", k, p, v, ok)
+	}
+
+	// Updating an existing key must not add an entry.
+	q.Push("a", 5, 11)
+	verifyKeyed(t, q)
+	if q.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", q.Len())
+	}
+	if p, v, ok := q.Get("a"); p != 5 || v != 11 || !ok {
+		t.Errorf("Get(a) = %v, %v, %v; want 5, 11, true", p, v, ok)
+	}
+
+	if !q.Contains("b") {
+		t.Errorf("Contains(b) = false")
+	}
+	if p, v, ok := q.Remove("b"); p != 2 || v != 20 || !ok {
+		t.Errorf("Remove(b) = %v, %v, %v; want 2, 20, true", p, v, ok)
+	}
+	if _, _, ok := q.Remove("b"); ok {
+		t.Errorf("second Remove(b) reported true")
+	}
+	verifyKeyed(t, q)
+
+	if got, want := drainKeyed(q), []string{"c", "a"}; !slices.Equal(got, want) {
+		t.Errorf("pop order = %v, want %v", got, want)
+	}
+}
+
+func TestKeyedRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var q Keyed[int, int, struct{}]
+	prio := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		k := r.Intn(100)
+		switch r.Intn(3) {
+		case 0, 1:
+			p := r.Intn(1000)
+			q.Push(k, p, struct{}{})
+			prio[k] = p
+		case 2:
+			_, _, ok := q.Remove(k)
+			_, want := prio[k]
+			if ok != want {
+				t.Fatalf("Remove(%d) = %v, want %v", k, ok, want)
+			}
+			delete(prio, k)
+		}
+		verifyKeyed(t, &q)
+	}
+	last := -1
+	for q.Len() > 0 {
+		k, p, _, _ := q.Pop()
+		if p < last {
+			t.Fatalf("Pop returned priority %d after %d", p, last)
+		}
+		if prio[k] != p {
+			t.Fatalf("Pop returned priority %d for %d, want %d", p, k, prio[k])
+		}
+		delete(prio, k)
+		last = p
+	}
+	if len(prio) != 0 {
+		t.Errorf("%d keys not popped", len(prio))
+	}
+}
@@ -0,0 +1,204 @@
+package pqueue
+
+import "cmp"
+
+// leftistNode is never modified once created, so subtrees may be shared
+// freely between heaps.
+type leftistNode[T any] struct {
+	value       T
+	rank        int // length of the right spine
+	left, right *leftistNode[T]
+}
+
+func (n *leftistNode[T]) rankOf() int {
+	if n == nil {
+		return 0
+	}
+	return n.rank
+}
+
+// mergeLeftist merges a and b, copying only the nodes along the merged
+// right spines, which is O(log n).
+func mergeLeftist[T any](less func(a, b T) bool, a, b *leftistNode[T]) *leftistNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if less(b.value, a.value) {
+		a, b = b, a
+	}
+	l, r := a.left, mergeLeftist(less, a.right, b)
+	if l.rankOf() < r.rankOf() {
+		l, r = r, l
+	}
+	return &leftistNode[T]{value: a.value, rank: r.rankOf() + 1, left: l, right: r}
+}
+
+// Leftist is a leftist heap ordered by a less function: Pop removes the
+// least element. Push, Pop, and Meld are O(log n); Peek is O(1).
+//
+// Nodes are shared rather than mutated, so Snapshot returns an immutable
+// Persistent copy of the heap in O(1).
+//
+// Use NewLeftist to create a Leftist heap.
+type Leftist[T any] struct {
+	less func(a, b T) bool
+	root *leftistNode[T]
+	size int
+}
+
+// NewLeftist returns an empty Leftist heap ordered by less.
+func NewLeftist[T any](less func(a, b T) bool) *Leftist[T] {
+	return &Leftist[T]{less: less}
+}
+
+// NewLeftistOrdered returns an empty Leftist heap of an ordered type,
+// least value first.
+func NewLeftistOrdered[T cmp.Ordered]() *Leftist[T] {
+	return NewLeftist(cmp.Less[T])
+}
+
+// SetLess replaces the ordering of h with less and rebuilds h to match, in
+// O(n) time. Snapshots taken earlier keep the old ordering.
+func (h *Leftist[T]) SetLess(less func(a, b T) bool) {
+	// Nodes may be shared with snapshots, so build new ones: merge
+	// singleton heaps in pairs, round by round, which is O(n) in total.
+	var queue []*leftistNode[T]
+	stack := []*leftistNode[T]{h.root}
+	for len(stack) > 0 {
+		x := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if x == nil {
+			continue
+		}
+		queue = append(queue, &leftistNode[T]{value: x.value, rank: 1})
+		stack = append(stack, x.left, x.right)
+	}
+	for len(queue) > 1 {
+		queue = append(queue[2:], mergeLeftist(less, queue[0], queue[1]))
+	}
+	h.less = less
+	h.root = nil
+	if len(queue) == 1 {
+		h.root = queue[0]
+	}
+}
+
+// Len returns the number of elements in h.
+func (h *Leftist[T]) Len() int {
+	return h.size
+}
+
+// Push adds v to h.
+func (h *Leftist[T]) Push(v T) {
+	h.root = mergeLeftist(h.less, h.root, &leftistNode[T]{value: v, rank: 1})
+	h.size++
+}
+
+// Peek returns the least element of h without removing it. The boolean is
+// false if h is empty.
+func (h *Leftist[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+// Pop removes and returns the least element of h. The boolean is false if
+// h is empty.
+func (h *Leftist[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	v := h.root.value
+	h.root = mergeLeftist(h.less, h.root.left, h.root.right)
+	h.size--
+	return v, true
+}
+
+// Meld moves all elements of other into h, leaving other empty. The two
+// heaps must use equivalent less functions.
+func (h *Leftist[T]) Meld(other *Leftist[T]) {
+	if other == h {
+		return
+	}
+	h.root = mergeLeftist(h.less, h.root, other.root)
+	h.size += other.size
+	other.root = nil
+	other.size = 0
+}
+
+// Snapshot returns a Persistent heap holding the current elements of h.
+// Later changes to h do not affect the snapshot.
+func (h *Leftist[T]) Snapshot() Persistent[T] {
+	return Persistent[T]{less: h.less, root: h.root, size: h.size}
+}
+
+// Persistent is an immutable leftist heap. Push, Pop, and Meld return new
+// heaps that share structure with the receiver, which is left unchanged.
+// Costs match those of Leftist.
+//
+// Use NewPersistent or Leftist.Snapshot to create a Persistent heap.
+type Persistent[T any] struct {
+	less func(a, b T) bool
+	root *leftistNode[T]
+	size int
+}
+
+// NewPersistent returns an empty Persistent heap ordered by less.
+func NewPersistent[T any](less func(a, b T) bool) Persistent[T] {
+	return Persistent[T]{less: less}
+}
+
+// NewPersistentOrdered returns an empty Persistent heap of an ordered
+// type, least value first.
+func NewPersistentOrdered[T cmp.Ordered]() Persistent[T] {
+	return NewPersistent(cmp.Less[T])
+}
+
+// Len returns the number of elements in h.
+func (h Persistent[T]) Len() int {
+	return h.size
+}
+
+// Push returns a heap holding the elements of h and v.
+func (h Persistent[T]) Push(v T) Persistent[T] {
+	h.root = mergeLeftist(h.less, h.root, &leftistNode[T]{value: v, rank: 1})
+	h.size++
+	return h
+}
+
+// Peek returns the least element of h. The boolean is false if h is empty.
+func (h Persistent[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+// Pop returns the least element of h and a heap holding the remaining
+// elements. The boolean is false if h is empty, in which case the returned
+// heap is h.
+func (h Persistent[T]) Pop() (T, Persistent[T], bool) {
+	if h.root == nil {
+		var zero T
+		return zero, h, false
+	}
+	v := h.root.value
+	h.root = mergeLeftist(h.less, h.root.left, h.root.right)
+	h.size--
+	return v, h, true
+}
+
+// Meld returns a heap holding the elements of both h and other. The two
+// heaps must use equivalent less functions.
+func (h Persistent[T]) Meld(other Persistent[T]) Persistent[T] {
+	h.root = mergeLeftist(h.less, h.root, other.root)
+	h.size += other.size
+	return h
+}
@@ -0,0 +1,129 @@
+package pqueue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func drainPersistent(h Persistent[int]) []int {
+	var s []int
+	for {
+		v, next, ok := h.Pop()
+		if !ok {
+			return s
+		}
+		s = append(s, v)
+		h = next
+	}
+}
+
+func TestLeftist(t *testing.T) {
+	h := NewLeftist(intLess)
+	if _, ok := h.Pop(); ok {
+		t.Errorf("Pop on empty heap reported true")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	var want []int
+	for i := 0; i < 200; i++ {
+		v := r.Intn(50)
+		h.Push(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+	if v, _ := h.Peek(); v != want[0] {
+		t.Errorf("Peek() = %d, want %d", v, want[0])
+	}
+
+	snap := h.Snapshot()
+	var got []int
+	for h.Len() > 0 {
+		v, _ := h.Pop()
+		got = append(got, v)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("pop order = %v, want %v", got, want)
+	}
+	if snap.Len() != len(want) {
+		t.Errorf("snapshot Len() = %d, want %d", snap.Len(), len(want))
+	}
+	if got := drainPersistent(snap); !slices.Equal(got, want) {
+		t.Errorf("snapshot pop order = %v, want %v", got, want)
+	}
+}
+
+func TestLeftistMeld(t *testing.T) {
+	a := NewLeftist(intLess)
+	b := NewLeftist(intLess)
+	for i := 0; i < 10; i++ {
+		a.Push(2 * i)
+		b.Push(2*i + 1)
+	}
+	a.Meld(b)
+	if a.Len() != 20 || b.Len() != 0 {
+		t.Errorf("after Meld: a.Len() = %d, b.Len() = %d; want 20, 0", a.Len(), b.Len())
+	}
+	if got := drainPersistent(a.Snapshot()); len(got) != 20 || !slices.IsSorted(got) {
+		t.Errorf("pop order = %v", got)
+	}
+}
+
+func TestPersistent(t *testing.T) {
+	h0 := NewPersistent(intLess)
+	h1 := h0.Push(3).Push(1)
+	h2 := h1.Push(2)
+
+	if h0.Len() != 0 {
+		t.Errorf("h0.Len() = %d, want 0", h0.Len())
+	}
+	if got := drainPersistent(h1); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("h1 = %v, want [1 3]", got)
+	}
+	if got := drainPersistent(h2); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("h2 = %v, want [1 2 3]", got)
+	}
+
+	v, h3, ok := h2.Pop()
+	if v != 1 || !ok {
+		t.Errorf("h2.Pop() = %d, %v; want 1, true", v, ok)
+	}
+	if got := drainPersistent(h3); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("h3 = %v, want [2 3]", got)
+	}
+	if got := drainPersistent(h2); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("h2 after Pop = %v, want [1 2 3]", got)
+	}
+
+	m := h1.Meld(h3)
+	if got := drainPersistent(m); !slices.Equal(got, []int{1, 2, 3, 3}) {
+		t.Errorf("h1.Meld(h3) = %v, want [1 2 3 3]", got)
+	}
+	if got := drainPersistent(h1); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("h1 after Meld = %v, want [1 3]", got)
+	}
+}
+
+func TestLeftistSetLess(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	h := NewLeftistOrdered[int]()
+	var want []int
+	for range 200 {
+		v := r.Intn(1000)
+		h.Push(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+	snap := h.Snapshot()
+	h.SetLess(func(a, b int) bool { return a > b })
+	if got := drainPersistent(snap); !slices.Equal(got, want) {
+		t.Errorf("snapshot drain after SetLess = %v, want ascending", got)
+	}
+	slices.Reverse(want)
+	if got := popN(h.Len(), h.Pop); !slices.Equal(got, want) {
+		t.Errorf("drain after SetLess(>) = %v, want %v", got, want)
+	}
+	if got := drainPersistent(NewPersistentOrdered[int]().Push(2).Push(1)); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("NewPersistentOrdered drain = %v, want [1 2]", got)
+	}
+}
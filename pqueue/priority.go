@@ -0,0 +1,143 @@
+package pqueue
+
+import (
+	"cmp"
+
+	"github.com/nishanths/typedcontainer/heap"
+)
+
+// Handle refers to an item in a PriorityQueue. It is returned by Push and
+// passed to UpdatePriority and Remove.
+type Handle[T any, P cmp.Ordered] struct {
+	value T
+	prio  P
+	seq   uint64 // insertion order, for stability
+	index int    // position in the heap, or -1 once removed
+}
+
+// Value returns the item h refers to.
+func (h *Handle[T, P]) Value() T {
+	return h.value
+}
+
+// Priority returns the current priority of the item h refers to.
+func (h *Handle[T, P]) Priority() P {
+	return h.prio
+}
+
+// PriorityQueue is a min-priority queue that returns handles to its items
+// so that their priorities can be changed later. Items with equal
+// priorities are popped in the order they were pushed. Push, Pop,
+// UpdatePriority, and Remove are O(log n); Peek is O(1).
+//
+// The zero value for PriorityQueue is an empty queue ready to use.
+type PriorityQueue[T any, P cmp.Ordered] struct {
+	h    *heap.Heap[*Handle[T, P]]
+	seq  uint64
+	less func(a, b P) bool // nil means cmp.Less
+}
+
+// NewPriorityQueue returns an empty PriorityQueue.
+func NewPriorityQueue[T any, P cmp.Ordered]() *PriorityQueue[T, P] {
+	q := new(PriorityQueue[T, P])
+	q.lazyInit()
+	return q
+}
+
+func (q *PriorityQueue[T, P]) lazyInit() {
+	if q.h == nil {
+		q.h = heap.NewIndexed(q.handleLess, func(h *Handle[T, P], i int) { h.index = i })
+	}
+}
+
+// handleLess orders handles by priority and then by insertion order.
+func (q *PriorityQueue[T, P]) handleLess(a, b *Handle[T, P]) bool {
+	if q.less == nil {
+		if c := cmp.Compare(a.prio, b.prio); c != 0 {
+			return c < 0
+		}
+	} else if q.less(a.prio, b.prio) {
+		return true
+	} else if q.less(b.prio, a.prio) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+// SetLess replaces the ordering of priorities with less, so that Pop
+// returns the item whose priority is least according to less, and
+// reorders q in O(n). Items with equal priorities keep their push order,
+// and existing handles remain valid.
+func (q *PriorityQueue[T, P]) SetLess(less func(a, b P) bool) {
+	q.lazyInit()
+	q.less = less
+	q.h.SetLess(q.handleLess)
+}
+
+// Len returns the number of items in q.
+func (q *PriorityQueue[T, P]) Len() int {
+	if q.h == nil {
+		return 0
+	}
+	return q.h.Len()
+}
+
+// Push adds item with priority p and returns a handle to it.
+func (q *PriorityQueue[T, P]) Push(item T, p P) *Handle[T, P] {
+	q.lazyInit()
+	h := &Handle[T, P]{value: item, prio: p, seq: q.seq}
+	q.seq++
+	q.h.Push(h)
+	return h
+}
+
+// Peek returns the item with the least priority, and that priority,
+// without removing it. The boolean is false if q is empty.
+func (q *PriorityQueue[T, P]) Peek() (T, P, bool) {
+	if q.Len() == 0 {
+		var v T
+		var p P
+		return v, p, false
+	}
+	h, _ := q.h.Peek()
+	return h.value, h.prio, true
+}
+
+// Pop removes and returns the item with the least priority, and that
+// priority. The boolean is false if q is empty.
+func (q *PriorityQueue[T, P]) Pop() (T, P, bool) {
+	if q.Len() == 0 {
+		var v T
+		var p P
+		return v, p, false
+	}
+	h, _ := q.h.Pop()
+	return h.value, h.prio, true
+}
+
+// contains reports whether h refers to an item currently in q.
+func (q *PriorityQueue[T, P]) contains(h *Handle[T, P]) bool {
+	return h.index >= 0 && h.index < q.Len() && q.h.At(h.index) == h
+}
+
+// UpdatePriority changes the priority of the item h refers to. The item
+// keeps its original place among items of equal priority. UpdatePriority
+// reports whether h is in q; it does nothing for a handle that has been
+// popped or removed.
+func (q *PriorityQueue[T, P]) UpdatePriority(h *Handle[T, P], p P) bool {
+	if !q.contains(h) {
+		return false
+	}
+	h.prio = p
+	q.h.Fix(h.index)
+	return true
+}
+
+// Remove removes the item h refers to. It reports whether h was in q.
+func (q *PriorityQueue[T, P]) Remove(h *Handle[T, P]) bool {
+	if !q.contains(h) {
+		return false
+	}
+	q.h.Remove(h.index)
+	return true
+}
@@ -0,0 +1,122 @@
+package pqueue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestPriorityQueueStable(t *testing.T) {
+	var q PriorityQueue[string, int]
+	if _, _, ok := q.Pop(); ok {
+		t.Errorf("Pop on empty queue reported true")
+	}
+	q.Push("b1", 2)
+	q.Push("a1", 1)
+	q.Push("b2", 2)
+	q.Push("a2", 1)
+	q.Push("b3", 2)
+	if v, p, _ := q.Peek(); v != "a1" || p != 1 {
+		t.Errorf("Peek() = %q, %d; want a1, 1", v, p)
+	}
+	var got []string
+	for q.Len() > 0 {
+		v, _, _ := q.Pop()
+		got = append(got, v)
+	}
+	if want := []string{"a1", "a2", "b1", "b2", "b3"}; !slices.Equal(got, want) {
+		t.Errorf("pop order = %v, want %v", got, want)
+	}
+}
+
+func TestPriorityQueueHandles(t *testing.T) {
+	q := NewPriorityQueue[string, int]()
+	a := q.Push("a", 10)
+	b := q.Push("b", 20)
+	c := q.Push("c", 30)
+
+	if !q.UpdatePriority(c, 5) || c.Priority() != 5 {
+		t.Errorf("UpdatePriority(c, 5) failed")
+	}
+	if v, _, _ := q.Peek(); v != "c" {
+		t.Errorf("Peek() after decrease = %q, want c", v)
+	}
+	q.UpdatePriority(c, 20) // ties with b, which was pushed first
+	if !q.Remove(a) || q.Remove(a) {
+		t.Errorf("Remove(a) did not report removal correctly")
+	}
+	if q.UpdatePriority(a, 0) {
+		t.Errorf("UpdatePriority of removed handle reported true")
+	}
+	if v, _, _ := q.Pop(); v != b.Value() {
+		t.Errorf("Pop() = %q, want b", v)
+	}
+	if q.UpdatePriority(b, 0) {
+		t.Errorf("UpdatePriority of popped handle reported true")
+	}
+	if v, p, _ := q.Pop(); v != "c" || p != 20 {
+		t.Errorf("Pop() = %q, %d; want c, 20", v, p)
+	}
+
+	other := NewPriorityQueue[string, int]()
+	x := other.Push("x", 1)
+	q.Push("y", 1)
+	if q.Remove(x) {
+		t.Errorf("Remove of another queue's handle reported true")
+	}
+}
+
+func TestPriorityQueueSetLess(t *testing.T) {
+	var q PriorityQueue[string, int]
+	a := q.Push("a", 1)
+	q.Push("b", 2)
+	c := q.Push("c", 3)
+	q.Push("c2", 3)
+	q.SetLess(func(x, y int) bool { return x > y })
+	if v, p, _ := q.Peek(); v != "c" || p != 3 {
+		t.Errorf("Peek() after SetLess(>) = %q, %d; want c, 3", v, p)
+	}
+	if !q.UpdatePriority(a, 10) {
+		t.Errorf("UpdatePriority of handle kept across SetLess reported false")
+	}
+	if !q.Remove(c) {
+		t.Errorf("Remove of handle kept across SetLess reported false")
+	}
+	var got []string
+	for q.Len() > 0 {
+		v, _, _ := q.Pop()
+		got = append(got, v)
+	}
+	if want := []string{"a", "c2", "b"}; !slices.Equal(got, want) {
+		t.Errorf("pop order = %v, want %v", got, want)
+	}
+}
+
+func TestPriorityQueueRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	q := NewPriorityQueue[int, int]()
+	var hs []*Handle[int, int]
+	for i := 0; i < 300; i++ {
+		hs = append(hs, q.Push(i, r.Intn(50)))
+	}
+	for i := 0; i < 200; i++ {
+		h := hs[r.Intn(len(hs))]
+		if r.Intn(4) == 0 {
+			q.Remove(h)
+		} else {
+			q.UpdatePriority(h, r.Intn(50))
+		}
+	}
+	var last *Handle[int, int]
+	for q.Len() > 0 {
+		v, p, _ := q.Pop()
+		h := hs[v]
+		if h.Priority() != p {
+			t.Fatalf("popped priority %d, handle says %d", p, h.Priority())
+		}
+		if last != nil && (last.prio > h.prio || last.prio == h.prio && last.seq > h.seq) {
+			t.Fatalf("popped %d (p=%d) after %d (p=%d)", v, p, last.value, last.prio)
+		}
+		last = h
+	}
+}
@@ -0,0 +1,35 @@
+package pqueue
+
+// Queue is the method set for removing values shared by the queues in
+// this module that hold plain values: Binomial, Leftist and Aging,
+// heap.Heap, and pdeque.Deque (through its best end). It leaves out Push
+// because Aging takes a priority and a time along with the value; see
+// PushQueue.
+//
+// Keyed, Bucket, and PriorityQueue return a key or priority alongside
+// each value, so they do not implement Queue.
+type Queue[T any] interface {
+	// Len returns the number of elements in the queue.
+	Len() int
+	// Peek returns the next element without removing it. The boolean is
+	// false if the queue is empty.
+	Peek() (T, bool)
+	// Pop removes and returns the next element. The boolean is false if
+	// the queue is empty.
+	Pop() (T, bool)
+	// PopN removes and returns up to n elements in order, or nil if none
+	// are removed.
+	PopN(n int) []T
+	// PopWhile removes and returns elements in order for as long as pred
+	// reports true for them, or nil if none are removed.
+	PopWhile(pred func(T) bool) []T
+}
+
+// PushQueue is a Queue that values can be pushed onto directly. Binomial,
+// Leftist, heap.Heap, and pdeque.Deque implement it, so code that builds
+// and drains a queue can be written once and given any of them.
+type PushQueue[T any] interface {
+	Queue[T]
+	// Push adds v to the queue.
+	Push(v T)
+}
@@ -0,0 +1,126 @@
+package pqueue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nishanths/typedcontainer/heap"
+	"github.com/nishanths/typedcontainer/pdeque"
+)
+
+var (
+	_ Queue[int] = (*Aging[int])(nil)
+
+	_ PushQueue[int] = (*Binomial[int])(nil)
+	_ PushQueue[int] = (*Leftist[int])(nil)
+	_ PushQueue[int] = (*heap.Heap[int])(nil)
+	_ PushQueue[int] = (*pdeque.Deque[int])(nil)
+)
+
+func TestQueue(t *testing.T) {
+	in := []int{5, 1, 4, 2, 3, 9, 7}
+	queues := map[string]Queue[int]{}
+
+	b, l := NewBinomialOrdered[int](), NewLeftistOrdered[int]()
+	h, d := heap.NewOrdered[int](), pdeque.NewOrdered[int]()
+	a := NewAging[int](LinearAging(0))
+	for _, v := range in {
+		b.Push(v)
+		l.Push(v)
+		h.Push(v)
+		d.Push(v)
+		a.Push(v, -float64(v), time.Time{})
+	}
+	queues["Binomial"], queues["Leftist"], queues["Aging"] = b, l, a
+	queues["heap.Heap"], queues["pdeque.Deque"] = h, d
+
+	for name, q := range queues {
+		if v, ok := q.Peek(); v != 1 || !ok {
+			t.Errorf("%s: Peek() = %d, %v; want 1, true", name, v, ok)
+		}
+		if v, ok := q.Pop(); v != 1 || !ok {
+			t.Errorf("%s: Pop() = %d, %v; want 1, true", name, v, ok)
+		}
+		if got := q.PopN(2); !slices.Equal(got, []int{2, 3}) {
+			t.Errorf("%s: PopN(2) = %v, want [2 3]", name, got)
+		}
+		if got := q.PopWhile(func(v int) bool { return v < 6 }); !slices.Equal(got, []int{4, 5}) {
+			t.Errorf("%s: PopWhile(< 6) = %v, want [4 5]", name, got)
+		}
+		if got := q.PopN(10); !slices.Equal(got, []int{7, 9}) || q.Len() != 0 {
+			t.Errorf("%s: PopN(10) = %v, Len() = %d; want [7 9], 0", name, got, q.Len())
+		}
+		if got := q.PopN(1); got != nil {
+			t.Errorf("%s: PopN on empty queue = %v, want nil", name, got)
+		}
+		if got := q.PopWhile(func(int) bool { return true }); got != nil {
+			t.Errorf("%s: PopWhile on empty queue = %v, want nil", name, got)
+		}
+		if _, ok := q.Pop(); ok {
+			t.Errorf("%s: Pop on empty queue reported true", name)
+		}
+	}
+}
+
+// TestPushQueue runs the same random script of pushes and pops against
+// every PushQueue and checks them against each other.
+func TestPushQueue(t *testing.T) {
+	queues := []struct {
+		name string
+		q    PushQueue[int]
+	}{
+		{"Binomial", NewBinomialOrdered[int]()},
+		{"Leftist", NewLeftistOrdered[int]()},
+		{"heap.Heap", heap.NewOrdered[int]()},
+		{"pdeque.Deque", pdeque.NewOrdered[int]()},
+	}
+	r := rand.New(rand.NewSource(1))
+	var ref []int // sorted
+	for i := range 3000 {
+		op, v, n := r.Intn(10), r.Intn(200), r.Intn(5)
+		var want []int
+		switch {
+		case op < 6:
+			j, _ := slices.BinarySearch(ref, v)
+			ref = slices.Insert(ref, j, v)
+		case op < 8:
+			n = min(n, len(ref))
+			want, ref = ref[:n:n], ref[n:]
+		default:
+			j, _ := slices.BinarySearch(ref, v)
+			want, ref = ref[:j:j], ref[j:]
+		}
+		for _, tc := range queues {
+			var got []int
+			switch {
+			case op < 6:
+				tc.q.Push(v)
+			case op == 6:
+				got = tc.q.PopN(n)
+			case op == 7:
+				for range n {
+					x, _ := tc.q.Pop()
+					got = append(got, x)
+				}
+			default:
+				got = tc.q.PopWhile(func(x int) bool { return x < v })
+			}
+			if !slices.Equal(got, want) {
+				t.Fatalf("%s: step %d (op %d) = %v, want %v", tc.name, i, op, got, want)
+			}
+			if tc.q.Len() != len(ref) {
+				t.Fatalf("%s: step %d: Len() = %d, want %d", tc.name, i, tc.q.Len(), len(ref))
+			}
+			if x, ok := tc.q.Peek(); ok != (len(ref) > 0) || ok && x != ref[0] {
+				t.Fatalf("%s: step %d: Peek() = %d, %v", tc.name, i, x, ok)
+			}
+		}
+	}
+	for _, tc := range queues {
+		if got := tc.q.PopN(tc.q.Len()); !slices.Equal(got, ref) {
+			t.Errorf("%s: final PopN = %v, want %v", tc.name, got, ref)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package psortedmap
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Then returns a comparison function that orders keys by the first of
+// cmps, breaking ties with the second, and so on. It is used to build an
+// order on composite keys, for example by series and then by timestamp:
+//
+//	bySeries := func(a, b Point) int { return strings.Compare(a.Series, b.Series) }
+//	byTime := func(a, b Point) int { return a.Time.Compare(b.Time) }
+//	m := psortedmap.NewFunc[Point, float64](psortedmap.Then(bySeries, byTime))
+func Then[K any](cmps ...func(a, b K) int) func(a, b K) int {
+	return func(a, b K) int {
+		for _, f := range cmps {
+			if c := f(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// Pair is a two-part composite key.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// ComparePair orders pairs by First and then by Second.
+func ComparePair[A, B cmp.Ordered](x, y Pair[A, B]) int {
+	if c := cmp.Compare(x.First, y.First); c != 0 {
+		return c
+	}
+	return cmp.Compare(x.Second, y.Second)
+}
+
+// CompareFirst orders pairs by First only. It is the prefix comparison to
+// pass to PrefixRange for a map ordered by ComparePair.
+func CompareFirst[A cmp.Ordered, B any](x, y Pair[A, B]) int {
+	return cmp.Compare(x.First, y.First)
+}
+
+// PrefixRange returns an iterator over the entries of m whose keys share
+// a prefix with k, in increasing key order. A key shares the prefix if
+// prefix(key, k) == 0. prefix must be a coarsening of m's order, such as
+// the leading comparisons of a Then chain, so that matching keys are
+// contiguous; only the fields of k that prefix examines need be set.
+func (m Map[K, V]) PrefixRange(k K, prefix func(a, b K) int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		ascendBand(m.root, func(key K) int { return prefix(key, k) }, yield)
+	}
+}
+
+// ascendBand yields, in order, the entries of n for which rel returns 0.
+// rel must be negative for keys before the band and positive for keys
+// after it. It reports whether iteration should continue.
+func ascendBand[K, V any](n *node[K, V], rel func(K) int, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	c := rel(n.key)
+	if c >= 0 && !ascendBand(n.left, rel, yield) {
+		return false
+	}
+	if c == 0 && !yield(n.key, n.value) {
+		return false
+	}
+	if c <= 0 {
+		return ascendBand(n.right, rel, yield)
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package psortedmap
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+type point struct {
+	series string
+	ts     int
+}
+
+func TestThenPrefixRange(t *testing.T) {
+	bySeries := func(a, b point) int { return cmp.Compare(a.series, b.series) }
+	byTime := func(a, b point) int { return cmp.Compare(a.ts, b.ts) }
+	m := NewFunc[point, int](Then(bySeries, byTime))
+	for _, p := range []point{{"mem", 2}, {"cpu", 3}, {"disk", 1}, {"cpu", 1}, {"mem", 1}, {"cpu", 2}} {
+		m = m.Put(p, p.ts*10)
+	}
+
+	var got []point
+	for k := range m.All() {
+		got = append(got, k)
+	}
+	want := []point{{"cpu", 1}, {"cpu", 2}, {"cpu", 3}, {"disk", 1}, {"mem", 1}, {"mem", 2}}
+	if !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+
+	for _, tt := range []struct {
+		series string
+		want   []int
+	}{
+		{"cpu", []int{10, 20, 30}},
+		{"disk", []int{10}},
+		{"mem", []int{10, 20}},
+		{"net", nil},
+		{"a", nil},
+	} {
+		var vs []int
+		for _, v := range m.PrefixRange(point{series: tt.series}, bySeries) {
+			vs = append(vs, v)
+		}
+		if !slices.Equal(vs, tt.want) {
+			t.Errorf("PrefixRange(%q) = %v, want %v", tt.series, vs, tt.want)
+		}
+	}
+
+	n := 0
+	for range m.PrefixRange(point{series: "cpu"}, bySeries) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("PrefixRange did not stop after break")
+	}
+}
+
+func TestComparePair(t *testing.T) {
+	m := NewFunc[Pair[string, int], bool](ComparePair[string, int])
+	for _, p := range []Pair[string, int]{{"b", 1}, {"a", 2}, {"a", 1}, {"b", 0}} {
+		m = m.Put(p, true)
+	}
+	var got []Pair[string, int]
+	for k := range m.PrefixRange(Pair[string, int]{First: "b"}, CompareFirst[string, int]) {
+		got = append(got, k)
+	}
+	if want := []Pair[string, int]{{"b", 0}, {"b", 1}}; !slices.Equal(got, want) {
+		t.Errorf("PrefixRange(b) = %v, want %v", got, want)
+	}
+	if k, _, _ := m.Min(); k != (Pair[string, int]{"a", 1}) {
+		t.Errorf("Min() = %v, want {a 1}", k)
+	}
+}
@@ -0,0 +1,71 @@
+package psortedmap
+
+// A Cursor steps through the entries of a Map in increasing key order.
+// Its position can be saved as a Token and later restored on the same Map
+// or on any other version of it.
+type Cursor[K, V any] struct {
+	m     Map[K, V]
+	stack []*node[K, V] // nodes whose key and right subtree are unvisited
+	tok   Token[K]
+}
+
+// A Token records the position of a Cursor: the last key it returned.
+// Tokens hold only a key, so they remain meaningful across unrelated
+// insertions and deletions, and can be encoded (for example as JSON) to
+// resume a scan across API pagination boundaries.
+type Token[K any] struct {
+	Last    K    // the last key returned
+	Started bool // whether any key was returned
+}
+
+// Cursor returns a Cursor positioned before the least key of m.
+func (m Map[K, V]) Cursor() *Cursor[K, V] {
+	c := &Cursor[K, V]{m: m}
+	c.pushLeft(m.root)
+	return c
+}
+
+// Restore returns a Cursor over m positioned at tok: the next call to Next
+// returns the least key of m greater than tok.Last. Restore may be used
+// with a token saved from a different version of the Map.
+func (m Map[K, V]) Restore(tok Token[K]) *Cursor[K, V] {
+	if !tok.Started {
+		return m.Cursor()
+	}
+	c := &Cursor[K, V]{m: m, tok: tok}
+	for n := m.root; n != nil; {
+		if m.cmp(n.key, tok.Last) > 0 {
+			c.stack = append(c.stack, n)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return c
+}
+
+// Next returns the next entry in key order and advances c. The boolean is
+// false when there are no more entries.
+func (c *Cursor[K, V]) Next() (K, V, bool) {
+	if len(c.stack) == 0 {
+		var k K
+		var v V
+		return k, v, false
+	}
+	n := c.stack[len(c.stack)-1]
+	c.stack = c.stack[:len(c.stack)-1]
+	c.pushLeft(n.right)
+	c.tok = Token[K]{Last: n.key, Started: true}
+	return n.key, n.value, true
+}
+
+// Save returns a Token for the current position of c.
+func (c *Cursor[K, V]) Save() Token[K] {
+	return c.tok
+}
+
+func (c *Cursor[K, V]) pushLeft(n *node[K, V]) {
+	for ; n != nil; n = n.left {
+		c.stack = append(c.stack, n)
+	}
+}
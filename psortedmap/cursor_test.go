@@ -0,0 +1,87 @@
+package psortedmap
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func page(c *Cursor[int, int], n int) []int {
+	var keys []int
+	for len(keys) < n {
+		k, _, ok := c.Next()
+		if !ok {
+			break
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestCursor(t *testing.T) {
+	m := New[int, int]()
+	if _, _, ok := m.Cursor().Next(); ok {
+		t.Errorf("Next on empty map reported true")
+	}
+	for i := 0; i < 10; i++ {
+		m = m.Put(i*10, i)
+	}
+
+	c := m.Cursor()
+	var all []int
+	for {
+		k, v, ok := c.Next()
+		if !ok {
+			break
+		}
+		if v != k/10 {
+			t.Errorf("value for %d = %d, want %d", k, v, k/10)
+		}
+		all = append(all, k)
+	}
+	if want := []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}; !slices.Equal(all, want) {
+		t.Errorf("cursor keys = %v, want %v", all, want)
+	}
+}
+
+func TestCursorSaveRestore(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 10; i++ {
+		m = m.Put(i*10, i)
+	}
+
+	c := m.Cursor()
+	if got := page(c, 3); !slices.Equal(got, []int{0, 10, 20}) {
+		t.Errorf("first page = %v", got)
+	}
+	tok := c.Save()
+
+	// The token survives encoding and unrelated mutations, including
+	// deletion of the last returned key.
+	b, err := json.Marshal(tok)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tok2 Token[int]
+	if err := json.Unmarshal(b, &tok2); err != nil {
+		t.Fatal(err)
+	}
+	m2 := m.Delete(20).Delete(30).Put(25, 0).Put(5, 0)
+
+	if got := page(m2.Restore(tok2), 3); !slices.Equal(got, []int{25, 40, 50}) {
+		t.Errorf("page after restore on new version = %v, want [25 40 50]", got)
+	}
+	if got := page(m.Restore(tok2), 3); !slices.Equal(got, []int{30, 40, 50}) {
+		t.Errorf("page after restore on old version = %v, want [30 40 50]", got)
+	}
+
+	if got := page(m.Restore(Token[int]{}), 2); !slices.Equal(got, []int{0, 10}) {
+		t.Errorf("restore of zero token = %v, want [0 10]", got)
+	}
+	if got := page(m.Restore(Token[int]{Last: 90, Started: true}), 2); len(got) != 0 {
+		t.Errorf("restore past the end = %v, want []", got)
+	}
+	if tok := m.Cursor().Save(); tok.Started {
+		t.Errorf("Save before Next reported Started")
+	}
+}
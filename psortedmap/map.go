@@ -0,0 +1,262 @@
+// Package psortedmap implements a persistent sorted map.
+//
+// A Map is immutable: Put and Delete return a new Map and leave the
+// receiver unchanged. The new and old maps share all nodes that were not
+// on the modified path, so each update costs O(log n) time and space.
+// Maps may be read concurrently from multiple goroutines, which makes them
+// suitable for snapshot (MVCC-style) reads of an ordered index.
+package psortedmap
+
+import (
+	"cmp"
+	"iter"
+)
+
+// node is never modified once it is reachable from a Map.
+type node[K, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+	height      int
+	size        int
+}
+
+func (n *node[K, V]) heightOf() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *node[K, V]) sizeOf() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func mk[K, V any](k K, v V, l, r *node[K, V]) *node[K, V] {
+	return &node[K, V]{
+		key:    k,
+		value:  v,
+		left:   l,
+		right:  r,
+		height: max(l.heightOf(), r.heightOf()) + 1,
+		size:   l.sizeOf() + r.sizeOf() + 1,
+	}
+}
+
+// balance returns a new AVL-balanced node for k, v with children l and r,
+// whose heights differ by at most two.
+func balance[K, V any](k K, v V, l, r *node[K, V]) *node[K, V] {
+	switch lh, rh := l.heightOf(), r.heightOf(); {
+	case lh > rh+1:
+		if l.left.heightOf() >= l.right.heightOf() {
+			return mk(l.key, l.value, l.left, mk(k, v, l.right, r))
+		}
+		lr := l.right
+		return mk(lr.key, lr.value, mk(l.key, l.value, l.left, lr.left), mk(k, v, lr.right, r))
+	case rh > lh+1:
+		if r.right.heightOf() >= r.left.heightOf() {
+			return mk(r.key, r.value, mk(k, v, l, r.left), r.right)
+		}
+		rl := r.left
+		return mk(rl.key, rl.value, mk(k, v, l, rl.left), mk(r.key, r.value, rl.right, r.right))
+	}
+	return mk(k, v, l, r)
+}
+
+// Map is a persistent sorted map from K to V.
+//
+// The zero value for Map is not usable; use New or NewFunc.
+type Map[K, V any] struct {
+	cmp  func(a, b K) int
+	root *node[K, V]
+}
+
+// New returns an empty Map for an ordered key type.
+func New[K cmp.Ordered, V any]() Map[K, V] {
+	return NewFunc[K, V](cmp.Compare[K])
+}
+
+// NewFunc returns an empty Map ordered by cmp, which returns a negative
+// number when a < b, a positive number when a > b, and zero when a == b.
+func NewFunc[K, V any](cmp func(a, b K) int) Map[K, V] {
+	return Map[K, V]{cmp: cmp}
+}
+
+// Len returns the number of entries in m.
+func (m Map[K, V]) Len() int {
+	return m.root.sizeOf()
+}
+
+// Get returns the value for k. The boolean is false if k is not in m.
+func (m Map[K, V]) Get(k K) (V, bool) {
+	for n := m.root; n != nil; {
+		switch c := m.cmp(k, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// GetOr returns the value for k, or def if k is not in m.
+func (m Map[K, V]) GetOr(k K, def V) V {
+	if v, ok := m.Get(k); ok {
+		return v
+	}
+	return def
+}
+
+// MustGet returns the value for k. It panics if k is not in m.
+func (m Map[K, V]) MustGet(k K) V {
+	v, ok := m.Get(k)
+	if !ok {
+		panic("psortedmap: MustGet of missing key")
+	}
+	return v
+}
+
+// Contains reports whether k is in m.
+func (m Map[K, V]) Contains(k K) bool {
+	_, ok := m.Get(k)
+	return ok
+}
+
+// Put returns a map in which k is mapped to v and all other entries are
+// those of m.
+func (m Map[K, V]) Put(k K, v V) Map[K, V] {
+	m.root = m.put(m.root, k, v)
+	return m
+}
+
+func (m Map[K, V]) put(n *node[K, V], k K, v V) *node[K, V] {
+	if n == nil {
+		return mk[K, V](k, v, nil, nil)
+	}
+	switch c := m.cmp(k, n.key); {
+	case c < 0:
+		return balance(n.key, n.value, m.put(n.left, k, v), n.right)
+	case c > 0:
+		return balance(n.key, n.value, n.left, m.put(n.right, k, v))
+	}
+	return mk(k, v, n.left, n.right)
+}
+
+// Delete returns a map holding the entries of m except the one for k.
+// If k is not in m, Delete returns m.
+func (m Map[K, V]) Delete(k K) Map[K, V] {
+	if root, ok := m.delete(m.root, k); ok {
+		m.root = root
+	}
+	return m
+}
+
+func (m Map[K, V]) delete(n *node[K, V], k K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	switch c := m.cmp(k, n.key); {
+	case c < 0:
+		l, ok := m.delete(n.left, k)
+		if !ok {
+			return n, false
+		}
+		return balance(n.key, n.value, l, n.right), true
+	case c > 0:
+		r, ok := m.delete(n.right, k)
+		if !ok {
+			return n, false
+		}
+		return balance(n.key, n.value, n.left, r), true
+	}
+	if n.left == nil {
+		return n.right, true
+	}
+	if n.right == nil {
+		return n.left, true
+	}
+	succ := n.right
+	for succ.left != nil {
+		succ = succ.left
+	}
+	return balance(succ.key, succ.value, n.left, deleteMin(n.right)), true
+}
+
+func deleteMin[K, V any](n *node[K, V]) *node[K, V] {
+	if n.left == nil {
+		return n.right
+	}
+	return balance(n.key, n.value, deleteMin(n.left), n.right)
+}
+
+// Min returns the entry with the least key. The boolean is false if m is
+// empty.
+func (m Map[K, V]) Min() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, n.value, true
+}
+
+// Max returns the entry with the greatest key. The boolean is false if m
+// is empty.
+func (m Map[K, V]) Max() (K, V, bool) {
+	n := m.root
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+// All returns an iterator over the entries of m in increasing key order.
+func (m Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.ascend(m.root, nil, nil, yield)
+	}
+}
+
+// Range returns an iterator over the entries of m with keys in [lo, hi),
+// in increasing key order.
+func (m Map[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.ascend(m.root, &lo, &hi, yield)
+	}
+}
+
+// ascend yields the entries of n with keys in [lo, hi) in order; nil bounds
+// are unbounded. It reports whether iteration should continue.
+func (m Map[K, V]) ascend(n *node[K, V], lo, hi *K, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	aboveLo := lo == nil || m.cmp(n.key, *lo) >= 0
+	belowHi := hi == nil || m.cmp(n.key, *hi) < 0
+	if aboveLo && !m.ascend(n.left, lo, hi, yield) {
+		return false
+	}
+	if aboveLo && belowHi && !yield(n.key, n.value) {
+		return false
+	}
+	if belowHi {
+		return m.ascend(n.right, lo, hi, yield)
+	}
+	return true
+}
@@ -0,0 +1,163 @@
+package psortedmap
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func verify[K, V any](t *testing.T, m Map[K, V]) {
+	t.Helper()
+
+	var walk func(n *node[K, V]) (height, size int)
+	walk = func(n *node[K, V]) (int, int) {
+		if n == nil {
+			return 0, 0
+		}
+		if n.left != nil && m.cmp(n.left.key, n.key) >= 0 ||
+			n.right != nil && m.cmp(n.right.key, n.key) <= 0 {
+			t.Fatalf("order invariant violated at %v", n.key)
+		}
+		lh, ls := walk(n.left)
+		rh, rs := walk(n.right)
+		if lh-rh > 1 || rh-lh > 1 {
+			t.Fatalf("balance invariant violated at %v: %d, %d", n.key, lh, rh)
+		}
+		if h := max(lh, rh) + 1; n.height != h {
+			t.Fatalf("height of %v = %d, want %d", n.key, n.height, h)
+		}
+		if s := ls + rs + 1; n.size != s {
+			t.Fatalf("size of %v = %d, want %d", n.key, n.size, s)
+		}
+		return n.height, n.size
+	}
+	walk(m.root)
+}
+
+func checkMap(t *testing.T, m Map[int, string], want map[int]string) {
+	t.Helper()
+
+	verify(t, m)
+	if m.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", m.Len(), len(want))
+	}
+	keys := slices.Collect(maps.Keys(want))
+	slices.Sort(keys)
+	var got []int
+	for k, v := range m.All() {
+		got = append(got, k)
+		if want[k] != v {
+			t.Errorf("value for %d = %q, want %q", k, v, want[k])
+		}
+	}
+	if !slices.Equal(got, keys) {
+		t.Errorf("keys = %v, want %v", got, keys)
+	}
+}
+
+func TestMap(t *testing.T) {
+	m0 := New[int, string]()
+	if _, ok := m0.Get(1); ok {
+		t.Errorf("Get on empty map reported true")
+	}
+	if _, _, ok := m0.Min(); ok {
+		t.Errorf("Min on empty map reported true")
+	}
+
+	m1 := m0.Put(2, "b").Put(1, "a").Put(3, "c")
+	m2 := m1.Put(2, "B").Delete(1)
+	checkMap(t, m0, map[int]string{})
+	checkMap(t, m1, map[int]string{1: "a", 2: "b", 3: "c"})
+	checkMap(t, m2, map[int]string{2: "B", 3: "c"})
+
+	if v, ok := m1.Get(2); v != "b" || !ok {
+		t.Errorf("m1.Get(2) = %q, %v; want b, true", v, ok)
+	}
+	if !m2.Contains(3) || m2.Contains(1) {
+		t.Errorf("m2.Contains reports wrong membership")
+	}
+	if k, v, _ := m1.Min(); k != 1 || v != "a" {
+		t.Errorf("m1.Min() = %d, %q; want 1, a", k, v)
+	}
+	if k, v, _ := m1.Max(); k != 3 || v != "c" {
+		t.Errorf("m1.Max() = %d, %q; want 3, c", k, v)
+	}
+	if m3 := m2.Delete(42); m3.root != m2.root {
+		t.Errorf("Delete of missing key changed the map")
+	}
+}
+
+func TestMapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := New[int, string]()
+	ref := map[int]string{}
+	var snaps []Map[int, string]
+	var refs []map[int]string
+	for i := 0; i < 2000; i++ {
+		k := r.Intn(200)
+		if r.Intn(3) == 0 {
+			m = m.Delete(k)
+			delete(ref, k)
+		} else {
+			v := string(rune('a' + r.Intn(26)))
+			m = m.Put(k, v)
+			ref[k] = v
+		}
+		if i%250 == 0 {
+			snaps = append(snaps, m)
+			refs = append(refs, maps.Clone(ref))
+		}
+	}
+	checkMap(t, m, ref)
+	for i := range snaps {
+		checkMap(t, snaps[i], refs[i])
+	}
+}
+
+func TestRange(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 100; i += 2 {
+		m = m.Put(i, i*i)
+	}
+	var got []int
+	for k, v := range m.Range(11, 21) {
+		if v != k*k {
+			t.Errorf("value for %d = %d, want %d", k, v, k*k)
+		}
+		got = append(got, k)
+	}
+	if want := []int{12, 14, 16, 18, 20}; !slices.Equal(got, want) {
+		t.Errorf("Range(11, 21) = %v, want %v", got, want)
+	}
+
+	got = got[:0]
+	for k := range m.Range(90, 1000) {
+		got = append(got, k)
+		if k == 94 {
+			break
+		}
+	}
+	if want := []int{90, 92, 94}; !slices.Equal(got, want) {
+		t.Errorf("Range(90, 1000) with break = %v, want %v", got, want)
+	}
+}
+
+func TestGetOr(t *testing.T) {
+	m := New[string, int]().Put("a", 1)
+	if v := m.GetOr("a", -1); v != 1 {
+		t.Errorf("GetOr(a) = %d, want 1", v)
+	}
+	if v := m.GetOr("b", -1); v != -1 {
+		t.Errorf("GetOr(b) = %d, want -1", v)
+	}
+	if v := m.MustGet("a"); v != 1 {
+		t.Errorf("MustGet(a) = %d, want 1", v)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustGet(b) did not panic")
+		}
+	}()
+	m.MustGet("b")
+}
@@ -0,0 +1,66 @@
+package psortedmap
+
+import "math"
+
+// Rank returns the number of keys in m that are less than k. If k is in
+// m, Rank(k) is its zero-based position in key order. Rank is O(log n).
+func (m Map[K, V]) Rank(k K) int {
+	r := 0
+	for n := m.root; n != nil; {
+		switch c := m.cmp(k, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			r += n.left.sizeOf() + 1
+			n = n.right
+		default:
+			return r + n.left.sizeOf()
+		}
+	}
+	return r
+}
+
+// KthSmallest returns the entry at zero-based position i in key order.
+// The boolean is false if i is not in [0, m.Len()). KthSmallest is
+// O(log n).
+func (m Map[K, V]) KthSmallest(i int) (K, V, bool) {
+	if i < 0 || i >= m.Len() {
+		var k K
+		var v V
+		return k, v, false
+	}
+	n := m.root
+	for {
+		l := n.left.sizeOf()
+		switch {
+		case i < l:
+			n = n.left
+		case i > l:
+			i -= l + 1
+			n = n.right
+		default:
+			return n.key, n.value, true
+		}
+	}
+}
+
+// Percentile returns the entry at fraction p of the way through m in key
+// order, using the nearest-rank method: the entry at one-based rank
+// ceil(p*n), where n = m.Len(). So p = 0 and any p <= 1/n give the least
+// key, p = 1 the greatest, and p = 0.5 the lower median. The boolean is
+// false if m is empty or p is not in [0, 1].
+func (m Map[K, V]) Percentile(p float64) (K, V, bool) {
+	if m.Len() == 0 || !(p >= 0 && p <= 1) {
+		var k K
+		var v V
+		return k, v, false
+	}
+	// p*n can land just above an integer through rounding, as 0.07*100
+	// does, so shave a relative epsilon off before taking the ceiling.
+	x := p * float64(m.Len())
+	rank := int(math.Ceil(x - x*percentileEpsilon))
+	return m.KthSmallest(max(0, rank-1))
+}
+
+// percentileEpsilon is the relative error in p*n that Percentile ignores.
+const percentileEpsilon = 1e-9
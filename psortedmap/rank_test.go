@@ -0,0 +1,96 @@
+package psortedmap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRankKth(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := New[int, int]()
+	var keys []int
+	for i := 0; i < 500; i++ {
+		k := r.Intn(2000) * 2 // even keys, so odd keys are absent
+		if !m.Contains(k) {
+			keys = append(keys, k)
+		}
+		m = m.Put(k, -k)
+	}
+	for i := 0; i < 100; i++ {
+		j := r.Intn(len(keys))
+		m = m.Delete(keys[j])
+		keys = slices.Delete(keys, j, j+1)
+	}
+	slices.Sort(keys)
+
+	for i, k := range keys {
+		if got := m.Rank(k); got != i {
+			t.Fatalf("Rank(%d) = %d, want %d", k, got, i)
+		}
+		if got := m.Rank(k + 1); got != i+1 {
+			t.Fatalf("Rank(%d) of absent key = %d, want %d", k+1, got, i+1)
+		}
+		if gk, gv, ok := m.KthSmallest(i); !ok || gk != k || gv != -k {
+			t.Fatalf("KthSmallest(%d) = %d, %d, %v; want %d, %d, true", i, gk, gv, ok, k, -k)
+		}
+	}
+	if m.Rank(-1) != 0 {
+		t.Errorf("Rank below all keys = %d, want 0", m.Rank(-1))
+	}
+	if _, _, ok := m.KthSmallest(len(keys)); ok {
+		t.Errorf("KthSmallest(Len()) reported true")
+	}
+	if _, _, ok := m.KthSmallest(-1); ok {
+		t.Errorf("KthSmallest(-1) reported true")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	m := New[int, string]()
+	if _, _, ok := m.Percentile(0.5); ok {
+		t.Errorf("Percentile on empty map reported true")
+	}
+	for i := 1; i <= 4; i++ {
+		m = m.Put(i*10, "")
+	}
+	for _, tt := range []struct {
+		p    float64
+		want int
+	}{
+		{0, 10}, {0.25, 10}, {0.26, 20}, {0.5, 20}, {0.75, 30}, {0.9, 40}, {1, 40},
+	} {
+		if k, _, _ := m.Percentile(tt.p); k != tt.want {
+			t.Errorf("Percentile(%v) with 4 keys = %d, want %d", tt.p, k, tt.want)
+		}
+	}
+	m = m.Put(50, "")
+	for _, tt := range []struct {
+		p    float64
+		want int
+	}{
+		{0, 10}, {0.2, 10}, {0.21, 20}, {0.5, 30}, {0.8, 40}, {0.9, 50}, {1, 50},
+	} {
+		if k, _, _ := m.Percentile(tt.p); k != tt.want {
+			t.Errorf("Percentile(%v) with 5 keys = %d, want %d", tt.p, k, tt.want)
+		}
+	}
+	if _, _, ok := m.Percentile(1.5); ok {
+		t.Errorf("Percentile(1.5) reported true")
+	}
+
+	m = New[int, string]()
+	for i := 1; i <= 100; i++ {
+		m = m.Put(i, "")
+	}
+	for _, tt := range []struct {
+		p    float64
+		want int
+	}{
+		{0.07, 7}, {0.29, 29}, {0.57, 57}, {0.58, 58}, {0.071, 8}, {0.999, 100},
+	} {
+		if k, _, _ := m.Percentile(tt.p); k != tt.want {
+			t.Errorf("Percentile(%v) with 100 keys = %d, want %d", tt.p, k, tt.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Package queue implements a FIFO queue.
+package queue
+
+import (
+	"github.com/nishanths/typedcontainer/deque"
+	"github.com/nishanths/typedcontainer/list"
+)
+
+// Queue is a first-in, first-out queue. By default it is backed by a
+// slice-based ring buffer (a deque.Deque); NewLinked returns a Queue
+// backed by a list.List instead, which never copies elements when it
+// grows but allocates for every Enqueue. All operations are O(1),
+// amortized for the ring buffer backing.
+//
+// The zero value for Queue is an empty, ring-buffer-backed queue ready to
+// use.
+type Queue[T any] struct {
+	d deque.Deque[T]
+	l *list.List[T] // non-nil if list-backed
+}
+
+// New returns an empty ring-buffer-backed Queue.
+func New[T any]() *Queue[T] {
+	return new(Queue[T])
+}
+
+// NewLinked returns an empty Queue backed by a list.List.
+func NewLinked[T any]() *Queue[T] {
+	return &Queue[T]{l: list.New[T]()}
+}
+
+// Len returns the number of elements in q.
+func (q *Queue[T]) Len() int {
+	if q.l != nil {
+		return q.l.Len()
+	}
+	return q.d.Len()
+}
+
+// Enqueue adds v to the back of q.
+func (q *Queue[T]) Enqueue(v T) {
+	if q.l != nil {
+		q.l.PushBack(v)
+		return
+	}
+	q.d.PushBack(v)
+}
+
+// Peek returns the element at the front of q without removing it. The
+// boolean is false if q is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	if q.l != nil {
+		if e := q.l.Front(); e != nil {
+			return e.Value, true
+		}
+		var zero T
+		return zero, false
+	}
+	return q.d.Front()
+}
+
+// Dequeue removes and returns the element at the front of q. The boolean
+// is false if q is empty.
+func (q *Queue[T]) Dequeue() (T, bool) {
+	if q.l != nil {
+		if e := q.l.Front(); e != nil {
+			return q.l.Remove(e), true
+		}
+		var zero T
+		return zero, false
+	}
+	return q.d.PopFront()
+}
+
+// DequeueN removes and returns up to n elements from the front of q, in
+// order. It returns nil if q is empty or n is not positive.
+func (q *Queue[T]) DequeueN(n int) []T {
+	if q.l == nil {
+		return q.d.PopFrontN(n)
+	}
+	n = min(n, q.l.Len())
+	if n <= 0 {
+		return nil
+	}
+	out := make([]T, n)
+	q.DrainTo(out, n)
+	return out
+}
+
+// DrainTo moves elements from the front of q into dst, in order, until
+// dst is full, limit elements have been moved, or q is empty, and returns
+// how many were moved. A negative limit means no limit other than
+// len(dst).
+func (q *Queue[T]) DrainTo(dst []T, limit int) int {
+	if q.l == nil {
+		return q.d.DrainTo(dst, limit)
+	}
+	if limit >= 0 && limit < len(dst) {
+		dst = dst[:limit]
+	}
+	i := 0
+	for ; i < len(dst); i++ {
+		e := q.l.Front()
+		if e == nil {
+			break
+		}
+		dst[i] = q.l.Remove(e)
+	}
+	return i
+}
+
+// DrainFunc removes elements from the front of q and calls f with each,
+// stopping when q is empty or after f returns false.
+func (q *Queue[T]) DrainFunc(f func(T) bool) {
+	for {
+		v, ok := q.Dequeue()
+		if !ok || !f(v) {
+			return
+		}
+	}
+}
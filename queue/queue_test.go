@@ -0,0 +1,74 @@
+package queue
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestQueue(t *testing.T) {
+	for name, q := range map[string]*Queue[int]{
+		"zero":   new(Queue[int]),
+		"New":    New[int](),
+		"linked": NewLinked[int](),
+	} {
+		if _, ok := q.Dequeue(); ok {
+			t.Errorf("%s: Dequeue on empty queue reported true", name)
+		}
+		if _, ok := q.Peek(); ok {
+			t.Errorf("%s: Peek on empty queue reported true", name)
+		}
+		next := 1
+		for i := 1; i <= 100; i++ {
+			q.Enqueue(i)
+			if i%3 == 0 {
+				if v, _ := q.Dequeue(); v != next {
+					t.Fatalf("%s: Dequeue() = %d, want %d", name, v, next)
+				}
+				next++
+			}
+		}
+		if v, _ := q.Peek(); v != next || q.Len() != 100-next+1 {
+			t.Errorf("%s: Peek, Len = %d, %d; want %d, %d", name, v, q.Len(), next, 100-next+1)
+		}
+		for ; next <= 100; next++ {
+			if v, ok := q.Dequeue(); !ok || v != next {
+				t.Fatalf("%s: Dequeue() = %d, %v; want %d, true", name, v, ok, next)
+			}
+		}
+		if q.Len() != 0 {
+			t.Errorf("%s: Len() after draining = %d", name, q.Len())
+		}
+	}
+}
+
+func TestQueueDrain(t *testing.T) {
+	for name, q := range map[string]*Queue[int]{
+		"ring":   New[int](),
+		"linked": NewLinked[int](),
+	} {
+		for i := 0; i < 10; i++ {
+			q.Enqueue(i)
+		}
+		if got := q.DequeueN(3); !slices.Equal(got, []int{0, 1, 2}) {
+			t.Errorf("%s: DequeueN(3) = %v, want [0 1 2]", name, got)
+		}
+		buf := make([]int, 4)
+		if n := q.DrainTo(buf, 2); n != 2 || !slices.Equal(buf[:n], []int{3, 4}) {
+			t.Errorf("%s: DrainTo(buf, 2) = %d, %v", name, n, buf[:n])
+		}
+		if n := q.DrainTo(buf, -1); n != 4 || !slices.Equal(buf, []int{5, 6, 7, 8}) {
+			t.Errorf("%s: DrainTo(buf, -1) = %d, %v", name, n, buf)
+		}
+		var seen []int
+		q.DrainFunc(func(v int) bool {
+			seen = append(seen, v)
+			return true
+		})
+		if !slices.Equal(seen, []int{9}) || q.Len() != 0 {
+			t.Errorf("%s: DrainFunc saw %v, Len() = %d", name, seen, q.Len())
+		}
+		if got := q.DequeueN(1); got != nil {
+			t.Errorf("%s: DequeueN on empty queue = %v, want nil", name, got)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Package ratewindow implements a sliding-window rate limiter.
+package ratewindow
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter allows at most N events in any window of the configured
+// duration. It remembers the times of the last N allowed events in a
+// circular buffer, so Allow is O(1) and memory use is fixed.
+//
+// A Limiter is safe for concurrent use by multiple goroutines.
+type Limiter struct {
+	window time.Duration
+
+	mu    sync.Mutex
+	times []time.Time // circular buffer of allowed event times
+	head  int         // index of the oldest event
+	n     int         // number of events in times
+}
+
+// New returns a Limiter that allows at most n events per window.
+// It panics if n is not positive.
+func New(n int, window time.Duration) *Limiter {
+	if n <= 0 {
+		panic("ratewindow: New with non-positive n")
+	}
+	return &Limiter{window: window, times: make([]time.Time, n)}
+}
+
+// Allow reports whether an event may happen at now, and if so records it.
+// Times passed to Allow should be non-decreasing.
+func (l *Limiter) Allow(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.n < len(l.times) {
+		l.times[(l.head+l.n)%len(l.times)] = now
+		l.n++
+		return true
+	}
+	if now.Sub(l.times[l.head]) < l.window {
+		return false
+	}
+	// The oldest event has left the window; its slot becomes the newest.
+	l.times[l.head] = now
+	l.head = (l.head + 1) % len(l.times)
+	return true
+}
+
+// Remaining returns the number of events that would be allowed at now.
+func (l *Limiter) Remaining(now time.Time) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	inWindow := 0
+	for i := 0; i < l.n; i++ {
+		if now.Sub(l.times[(l.head+i)%len(l.times)]) < l.window {
+			inWindow++
+		}
+	}
+	return len(l.times) - inWindow
+}
+
+// Reset forgets all recorded events.
+func (l *Limiter) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.head = 0
+	l.n = 0
+}
@@ -0,0 +1,66 @@
+package ratewindow
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+
+	l := New(3, time.Second)
+	tests := []struct {
+		ms    int
+		allow bool
+	}{
+		{0, true},
+		{100, true},
+		{200, true},
+		{300, false},
+		{999, false},
+		{1000, true}, // event at 0 has left the window
+		{1050, false},
+		{1100, true},
+		{1200, true},
+		{1201, false},
+		{5000, true},
+	}
+	for _, tt := range tests {
+		if got := l.Allow(at(tt.ms)); got != tt.allow {
+			t.Errorf("Allow(%dms) = %v, want %v", tt.ms, got, tt.allow)
+		}
+	}
+}
+
+func TestLimiterRemaining(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := New(2, time.Minute)
+	if n := l.Remaining(base); n != 2 {
+		t.Errorf("Remaining() = %d, want 2", n)
+	}
+	l.Allow(base)
+	if n := l.Remaining(base); n != 1 {
+		t.Errorf("Remaining() = %d, want 1", n)
+	}
+	l.Allow(base.Add(30 * time.Second))
+	if n := l.Remaining(base.Add(time.Minute)); n != 1 {
+		t.Errorf("Remaining() after first expiry = %d, want 1", n)
+	}
+	l.Reset()
+	if n := l.Remaining(base); n != 2 {
+		t.Errorf("Remaining() after Reset = %d, want 2", n)
+	}
+	if !l.Allow(base) || !l.Allow(base) || l.Allow(base) {
+		t.Errorf("Allow after Reset does not honor the limit")
+	}
+}
+
+func TestNewPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New(0, ...) did not panic")
+		}
+	}()
+	New(0, time.Second)
+}
@@ -0,0 +1,154 @@
+// Package ringbuf implements a fixed-capacity circular buffer, for keeping
+// the most recent N items.
+package ringbuf
+
+import "iter"
+
+// Policy determines what Push does when the buffer is full.
+type Policy int
+
+const (
+	// Reject makes Push leave the buffer unchanged and report false.
+	Reject Policy = iota
+	// Overwrite makes Push discard the oldest item to make room.
+	Overwrite
+)
+
+// Buffer is a FIFO queue of fixed capacity stored in a ring. Unlike
+// deque.Deque it never grows: once full, Push either rejects new items or
+// overwrites the oldest, according to the Buffer's Policy. Push and Pop
+// are O(1) and never allocate.
+//
+// A Buffer is not safe for concurrent use.
+//
+// The zero value for Buffer is not usable; use New.
+type Buffer[T any] struct {
+	buf    []T
+	head   int // index of the oldest item
+	n      int
+	policy Policy
+}
+
+// New returns an empty Buffer that holds up to capacity items. It panics
+// if capacity is not positive.
+func New[T any](capacity int, policy Policy) *Buffer[T] {
+	if capacity <= 0 {
+		panic("ringbuf: New with non-positive capacity")
+	}
+	return &Buffer[T]{buf: make([]T, capacity), policy: policy}
+}
+
+// Len returns the number of items in b.
+func (b *Buffer[T]) Len() int {
+	return b.n
+}
+
+// Cap returns the capacity of b.
+func (b *Buffer[T]) Cap() int {
+	return len(b.buf)
+}
+
+// Full reports whether b holds Cap items.
+func (b *Buffer[T]) Full() bool {
+	return b.n == len(b.buf)
+}
+
+// Policy returns the policy b was created with.
+func (b *Buffer[T]) Policy() Policy {
+	return b.policy
+}
+
+func (b *Buffer[T]) index(i int) int {
+	i += b.head
+	if i >= len(b.buf) {
+		i -= len(b.buf)
+	}
+	return i
+}
+
+// Push appends v as the newest item of b. It reports whether v was
+// stored, which is false only if b is full and its policy is Reject.
+func (b *Buffer[T]) Push(v T) bool {
+	if b.n < len(b.buf) {
+		b.buf[b.index(b.n)] = v
+		b.n++
+		return true
+	}
+	if b.policy == Reject {
+		return false
+	}
+	b.buf[b.head] = v
+	b.head = b.index(1)
+	return true
+}
+
+// Pop removes and returns the oldest item of b. The boolean is false if b
+// is empty.
+func (b *Buffer[T]) Pop() (T, bool) {
+	var zero T
+	if b.n == 0 {
+		return zero, false
+	}
+	v := b.buf[b.head]
+	b.buf[b.head] = zero
+	b.head = b.index(1)
+	b.n--
+	return v, true
+}
+
+// Peek returns the oldest item of b without removing it. The boolean is
+// false if b is empty.
+func (b *Buffer[T]) Peek() (T, bool) {
+	if b.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return b.buf[b.head], true
+}
+
+// Newest returns the most recently pushed item of b that is still in b.
+// The boolean is false if b is empty.
+func (b *Buffer[T]) Newest() (T, bool) {
+	if b.n == 0 {
+		var zero T
+		return zero, false
+	}
+	return b.buf[b.index(b.n-1)], true
+}
+
+// Clear removes all items from b.
+func (b *Buffer[T]) Clear() {
+	clear(b.buf)
+	b.head, b.n = 0, 0
+}
+
+// ToSlice returns a new slice holding the items of b, oldest first. It
+// returns nil if b is empty.
+func (b *Buffer[T]) ToSlice() []T {
+	if b.n == 0 {
+		return nil
+	}
+	return b.AppendTo(make([]T, 0, b.n))
+}
+
+// AppendTo appends the items of b, oldest first, to dst and returns the
+// extended slice.
+func (b *Buffer[T]) AppendTo(dst []T) []T {
+	if end := b.head + b.n; end <= len(b.buf) {
+		return append(dst, b.buf[b.head:end]...)
+	}
+	dst = append(dst, b.buf[b.head:]...)
+	return append(dst, b.buf[:b.index(b.n)]...)
+}
+
+// All returns an iterator over the items of b, oldest first. b must not
+// be modified during iteration.
+func (b *Buffer[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range b.n {
+			if !yield(b.buf[b.index(i)]) {
+				return
+			}
+		}
+	}
+}
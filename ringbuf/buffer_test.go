@@ -0,0 +1,108 @@
+package ringbuf
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func checkBuffer(t *testing.T, b *Buffer[int], want []int) {
+	t.Helper()
+	if b.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", b.Len(), len(want))
+	}
+	if got := b.ToSlice(); !slices.Equal(got, want) {
+		t.Errorf("ToSlice() = %v, want %v", got, want)
+	}
+	if got := slices.Collect(b.All()); !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+}
+
+func TestBufferReject(t *testing.T) {
+	b := New[int](3, Reject)
+	if _, ok := b.Pop(); ok {
+		t.Errorf("Pop on empty buffer reported true")
+	}
+	for i := range 3 {
+		if !b.Push(i) {
+			t.Errorf("Push(%d) reported false", i)
+		}
+	}
+	if b.Push(3) {
+		t.Errorf("Push on full buffer reported true")
+	}
+	if !b.Full() {
+		t.Errorf("Full() = false, want true")
+	}
+	checkBuffer(t, b, []int{0, 1, 2})
+	if v, _ := b.Pop(); v != 0 {
+		t.Errorf("Pop() = %d, want 0", v)
+	}
+	b.Push(4) // wraps around
+	checkBuffer(t, b, []int{1, 2, 4})
+	if v, _ := b.Peek(); v != 1 {
+		t.Errorf("Peek() = %d, want 1", v)
+	}
+	if v, _ := b.Newest(); v != 4 {
+		t.Errorf("Newest() = %d, want 4", v)
+	}
+	b.Clear()
+	checkBuffer(t, b, nil)
+}
+
+func TestBufferOverwrite(t *testing.T) {
+	b := New[int](3, Overwrite)
+	for i := range 10 {
+		if !b.Push(i) {
+			t.Errorf("Push(%d) reported false", i)
+		}
+	}
+	checkBuffer(t, b, []int{7, 8, 9})
+	if b.Cap() != 3 {
+		t.Errorf("Cap() = %d, want 3", b.Cap())
+	}
+	if got := b.AppendTo([]int{-1}); !slices.Equal(got, []int{-1, 7, 8, 9}) {
+		t.Errorf("AppendTo([-1]) = %v, want [-1 7 8 9]", got)
+	}
+}
+
+func TestBufferRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, policy := range []Policy{Reject, Overwrite} {
+		b := New[int](7, policy)
+		var ref []int
+		for i := 0; i < 2000; i++ {
+			if r.Intn(3) == 0 {
+				v, ok := b.Pop()
+				if ok != (len(ref) > 0) || ok && v != ref[0] {
+					t.Fatalf("Pop() = %d, %v; ref %v", v, ok, ref)
+				}
+				if ok {
+					ref = ref[1:]
+				}
+				continue
+			}
+			want := len(ref) < 7 || policy == Overwrite
+			if got := b.Push(i); got != want {
+				t.Fatalf("Push(%d) = %v, want %v", i, got, want)
+			}
+			if want {
+				ref = append(ref, i)
+				if len(ref) > 7 {
+					ref = ref[1:]
+				}
+			}
+		}
+		checkBuffer(t, b, ref)
+	}
+}
+
+func TestNewPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New(0) did not panic")
+		}
+	}()
+	New[int](0, Reject)
+}
@@ -0,0 +1,237 @@
+// Package seqring implements a sequenced ring buffer in the style of the
+// LMAX Disruptor: a single producer publishes items into a fixed ring and
+// any number of consumers read every item, each tracking its own sequence.
+//
+// Coordination is done with atomic sequence counters rather than locks or
+// channels. Waiting goroutines spin, yielding the processor between
+// checks, which trades CPU for latency.
+package seqring
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrClosed is returned by Claim after Close, and by a consumer's Wait
+// once a closed ring has no more items for it.
+var ErrClosed = errors.New("seqring: ring closed")
+
+// Ring is a fixed-size ring of slots addressed by a monotonically
+// increasing sequence number. The slot for sequence s is reused for
+// sequence s+Cap() once every consumer has released s.
+//
+// Claim, Set, Publish, Send, and Close must be called by a single producer
+// goroutine. Subscribe and the Consumer methods are safe for concurrent
+// use, with each Consumer used by one goroutine at a time.
+type Ring[T any] struct {
+	buf  []T
+	mask uint64
+
+	published atomic.Uint64 // sequences below this are readable
+	claimed   uint64        // sequences below this are claimed; producer only
+	closed    atomic.Bool
+
+	mu        sync.Mutex                     // serializes changes to consumers
+	consumers atomic.Pointer[[]*Consumer[T]] // copy on write
+}
+
+// New returns an empty Ring with size slots. It panics if size is not a
+// positive power of two.
+func New[T any](size int) *Ring[T] {
+	if size <= 0 || size&(size-1) != 0 {
+		panic("seqring: New with size not a positive power of two")
+	}
+	r := &Ring[T]{buf: make([]T, size), mask: uint64(size - 1)}
+	r.consumers.Store(new([]*Consumer[T]))
+	return r
+}
+
+// Cap returns the number of slots in r.
+func (r *Ring[T]) Cap() int {
+	return len(r.buf)
+}
+
+// Published returns the number of items published to r, which is also the
+// sequence number the next published item will have.
+func (r *Ring[T]) Published() uint64 {
+	return r.published.Load()
+}
+
+// gate returns the lowest sequence not yet released by every consumer, or
+// limit if there are no consumers or all have released everything below
+// limit.
+func (r *Ring[T]) gate(limit uint64) uint64 {
+	min := limit
+	for _, c := range *r.consumers.Load() {
+		if s := c.next.Load(); s < min {
+			min = s
+		}
+	}
+	return min
+}
+
+// Claim reserves the next n sequences for writing and returns the first.
+// The claimed items are written with Set and made visible to consumers
+// with Publish. Claim waits until the slowest consumer has released enough
+// slots, or until ctx is done. It panics if n is not in [1, Cap()].
+func (r *Ring[T]) Claim(ctx context.Context, n int) (uint64, error) {
+	if n < 1 || n > len(r.buf) {
+		panic("seqring: Claim with n out of range")
+	}
+	lo := r.claimed
+	hi := lo + uint64(n)
+	for spin := 0; ; spin++ {
+		if r.closed.Load() {
+			return 0, ErrClosed
+		}
+		if hi-r.gate(lo) <= uint64(len(r.buf)) {
+			break
+		}
+		if err := pause(ctx, spin); err != nil {
+			return 0, err
+		}
+	}
+	r.claimed = hi
+	return lo, nil
+}
+
+// Set stores v in the slot for the claimed sequence seq.
+func (r *Ring[T]) Set(seq uint64, v T) {
+	r.buf[seq&r.mask] = v
+}
+
+// Publish makes every claimed sequence below end visible to consumers.
+// Publish panics if end is beyond the claimed sequences.
+func (r *Ring[T]) Publish(end uint64) {
+	if end > r.claimed {
+		panic("seqring: Publish of unclaimed sequence")
+	}
+	if end > r.published.Load() {
+		r.published.Store(end)
+	}
+}
+
+// Send claims a single slot, stores v in it, and publishes it.
+func (r *Ring[T]) Send(ctx context.Context, v T) error {
+	seq, err := r.Claim(ctx, 1)
+	if err != nil {
+		return err
+	}
+	r.Set(seq, v)
+	r.Publish(seq + 1)
+	return nil
+}
+
+// Close marks r closed. Consumers can still read every published item;
+// subsequent calls to Claim return ErrClosed.
+func (r *Ring[T]) Close() {
+	r.closed.Store(true)
+}
+
+// Subscribe returns a new Consumer that reads every item published after
+// the call. Until the consumer is closed, the producer will not overwrite
+// items it has not released.
+func (r *Ring[T]) Subscribe() *Consumer[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := &Consumer[T]{r: r}
+	c.next.Store(r.published.Load())
+	old := *r.consumers.Load()
+	cs := make([]*Consumer[T], len(old), len(old)+1)
+	copy(cs, old)
+	cs = append(cs, c)
+	r.consumers.Store(&cs)
+	return c
+}
+
+func (r *Ring[T]) unsubscribe(c *Consumer[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	old := *r.consumers.Load()
+	cs := make([]*Consumer[T], 0, len(old))
+	for _, o := range old {
+		if o != c {
+			cs = append(cs, o)
+		}
+	}
+	r.consumers.Store(&cs)
+}
+
+// Consumer reads from a Ring at its own pace.
+type Consumer[T any] struct {
+	r    *Ring[T]
+	next atomic.Uint64 // lowest sequence not yet released
+}
+
+// Next returns the sequence number of the next item c will read.
+func (c *Consumer[T]) Next() uint64 {
+	return c.next.Load()
+}
+
+// Wait waits until at least one item at or after Next is published and
+// returns the end of the readable range: sequences in [Next(), end) may be
+// read with Get and must then be released with Release. Wait returns
+// ErrClosed if the ring is closed and c has read everything, or ctx.Err()
+// if ctx is done first.
+func (c *Consumer[T]) Wait(ctx context.Context) (uint64, error) {
+	next := c.next.Load()
+	for spin := 0; ; spin++ {
+		// Load closed before published so that a ring closed after its
+		// last publish is never reported closed with items unread.
+		closed := c.r.closed.Load()
+		if end := c.r.published.Load(); end > next {
+			return end, nil
+		}
+		if closed {
+			return 0, ErrClosed
+		}
+		if err := pause(ctx, spin); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Get returns the item with sequence seq. seq must be in the range
+// reported by Wait and not yet released.
+func (c *Consumer[T]) Get(seq uint64) T {
+	return c.r.buf[seq&c.r.mask]
+}
+
+// Release marks every sequence below end as read, allowing the producer
+// to reuse their slots.
+func (c *Consumer[T]) Release(end uint64) {
+	if end > c.next.Load() {
+		c.next.Store(end)
+	}
+}
+
+// Recv waits for, reads, and releases the next item.
+func (c *Consumer[T]) Recv(ctx context.Context) (T, error) {
+	if _, err := c.Wait(ctx); err != nil {
+		var zero T
+		return zero, err
+	}
+	seq := c.next.Load()
+	v := c.Get(seq)
+	c.Release(seq + 1)
+	return v, nil
+}
+
+// Close detaches c from its ring. The producer no longer waits for c.
+func (c *Consumer[T]) Close() {
+	c.r.unsubscribe(c)
+}
+
+// pause backs off a spinning waiter and reports whether ctx is done.
+func pause(ctx context.Context, spin int) error {
+	if spin%64 == 63 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	runtime.Gosched()
+	return nil
+}
@@ -0,0 +1,130 @@
+package seqring
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRingBroadcast(t *testing.T) {
+	const n = 10000
+	r := New[int](64)
+	ctx := context.Background()
+
+	consumers := make([]*Consumer[int], 3)
+	for i := range consumers {
+		consumers[i] = r.Subscribe()
+	}
+	results := make([][]int, len(consumers))
+	var wg sync.WaitGroup
+	for i, c := range consumers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				end, err := c.Wait(ctx)
+				if errors.Is(err, ErrClosed) {
+					return
+				}
+				if err != nil {
+					t.Errorf("Wait: %v", err)
+					return
+				}
+				for seq := c.Next(); seq < end; seq++ {
+					results[i] = append(results[i], c.Get(seq))
+				}
+				c.Release(end)
+			}
+		}()
+	}
+
+	for i := 0; i < n; {
+		batch := min(1+i%7, n-i)
+		lo, err := r.Claim(ctx, batch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < batch; j++ {
+			r.Set(lo+uint64(j), i+j)
+		}
+		r.Publish(lo + uint64(batch))
+		i += batch
+	}
+	r.Close()
+	wg.Wait()
+
+	for i, got := range results {
+		if len(got) != n {
+			t.Errorf("consumer %d received %d items, want %d", i, len(got), n)
+			continue
+		}
+		for j, v := range got {
+			if v != j {
+				t.Errorf("consumer %d item %d = %d", i, j, v)
+				break
+			}
+		}
+	}
+	if r.Published() != n {
+		t.Errorf("Published() = %d, want %d", r.Published(), n)
+	}
+}
+
+func TestRingBackpressure(t *testing.T) {
+	r := New[string](4)
+	c := r.Subscribe()
+	ctx := context.Background()
+	for _, s := range []string{"a", "b", "c", "d"} {
+		if err := r.Send(ctx, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := r.Send(tctx, "e"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Send on full ring = %v, want DeadlineExceeded", err)
+	}
+
+	if v, err := c.Recv(ctx); v != "a" || err != nil {
+		t.Errorf("Recv() = %q, %v; want a, nil", v, err)
+	}
+	if err := r.Send(ctx, "e"); err != nil {
+		t.Errorf("Send after Release = %v", err)
+	}
+
+	late := r.Subscribe()
+	c.Close()
+	for i := 0; i < 3; i++ {
+		if err := r.Send(ctx, "x"); err != nil {
+			t.Fatalf("Send with closed slow consumer = %v", err)
+		}
+	}
+	if v, _ := late.Recv(ctx); v != "x" {
+		t.Errorf("late subscriber first item = %q, want x", v)
+	}
+
+	r.Close()
+	if err := r.Send(ctx, "y"); !errors.Is(err, ErrClosed) {
+		t.Errorf("Send after Close = %v, want ErrClosed", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := late.Recv(ctx); err != nil {
+			t.Errorf("Recv of remaining item = %v", err)
+		}
+	}
+	if _, err := late.Recv(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("Recv on drained closed ring = %v, want ErrClosed", err)
+	}
+}
+
+func TestNewPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New(3) did not panic")
+		}
+	}()
+	New[int](3)
+}
@@ -0,0 +1,41 @@
+package set
+
+// Freeze makes s read-only and returns it as a Reader. After Freeze, Add,
+// Remove, Clear, and UnmarshalJSON panic, including when called through a
+// *Set obtained by asserting the Reader. A frozen set stays frozen; use
+// Clone to get a mutable copy.
+func (s *Set[T]) Freeze() Reader[T] {
+	s.frozen = true
+	return s
+}
+
+// Frozen reports whether Freeze has been called on s.
+func (s *Set[T]) Frozen() bool {
+	return s.frozen
+}
+
+func (s *Set[T]) checkFrozen() {
+	if s.frozen {
+		panic("set: mutation of frozen Set")
+	}
+}
+
+// Freeze makes s read-only and returns it as a Reader. After Freeze, every
+// method that can change the elements or their order panics, including
+// when called through a *Linked obtained by asserting the Reader. A
+// frozen set stays frozen.
+func (s *Linked[T]) Freeze() Reader[T] {
+	s.frozen = true
+	return s
+}
+
+// Frozen reports whether Freeze has been called on s.
+func (s *Linked[T]) Frozen() bool {
+	return s.frozen
+}
+
+func (s *Linked[T]) checkFrozen() {
+	if s.frozen {
+		panic("set: mutation of frozen Linked set")
+	}
+}
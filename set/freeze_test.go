@@ -0,0 +1,58 @@
+package set
+
+import "testing"
+
+func mustPanic(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s on frozen set did not panic", name)
+		}
+	}()
+	f()
+}
+
+func TestFreeze(t *testing.T) {
+	s := New(1, 2, 3)
+	r := s.Freeze()
+	if !s.Frozen() || r.Len() != 3 || !r.Contains(2) {
+		t.Errorf("frozen Set: Frozen() = %v, Len() = %d, Contains(2) = %v", s.Frozen(), r.Len(), r.Contains(2))
+	}
+	mustPanic(t, "Add", func() { s.Add(4) })
+	mustPanic(t, "Add existing", func() { s.Add(1) })
+	mustPanic(t, "Remove", func() { s.Remove(1) })
+	mustPanic(t, "Clear", func() { s.Clear() })
+	mustPanic(t, "UnmarshalJSON", func() { s.UnmarshalJSON([]byte("[5]")) })
+	if s.Len() != 3 {
+		t.Errorf("Len() = %d after failed mutations, want 3", s.Len())
+	}
+	u := s.Union(New(4))
+	c := s.Clone()
+	if !c.Add(5) || !u.Add(6) || c.Frozen() {
+		t.Errorf("Clone or Union of a frozen set is not mutable")
+	}
+}
+
+func TestFreezeLinked(t *testing.T) {
+	s := linkedOf(1, 2, 3)
+	r := s.Freeze()
+	checkLinked(t, s, []int{1, 2, 3})
+	if r.Len() != 3 || !r.Contains(3) {
+		t.Errorf("Reader Len() = %d, Contains(3) = %v", r.Len(), r.Contains(3))
+	}
+	for _, tt := range []struct {
+		name string
+		f    func()
+	}{
+		{"Add", func() { s.Add(4) }},
+		{"Remove", func() { s.Remove(1) }},
+		{"MoveToFront", func() { s.MoveToFront(3) }},
+		{"MoveToBack", func() { s.MoveToBack(1) }},
+		{"PopFront", func() { s.PopFront() }},
+		{"PopBack", func() { s.PopBack() }},
+		{"Clear", func() { s.Clear() }},
+	} {
+		mustPanic(t, tt.name, tt.f)
+	}
+	checkLinked(t, s, []int{1, 2, 3})
+}
@@ -0,0 +1,138 @@
+package set
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// MarshalJSON encodes s as a JSON array of its elements in ascending
+// order, so that equal sets encode identically. Elements whose underlying
+// type is an integer, floating-point, or string type are ordered by
+// value; other elements are ordered by their JSON encoding. Use
+// MarshalJSONFunc for a different order.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	vs := make([]T, 0, s.Len())
+	for v := range s.m {
+		vs = append(vs, v)
+	}
+	return marshalElems(vs, !sortOrdered(vs))
+}
+
+// UnmarshalJSON replaces the contents of s with the elements of a JSON
+// array. Duplicate elements are ignored.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var vs []T
+	if err := json.Unmarshal(data, &vs); err != nil {
+		return err
+	}
+	s.Clear()
+	for _, v := range vs {
+		s.Add(v)
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The text is the JSON
+// array produced by MarshalJSON.
+func (s *Set[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by decoding text as
+// UnmarshalJSON does.
+func (s *Set[T]) UnmarshalText(text []byte) error {
+	return s.UnmarshalJSON(text)
+}
+
+// MarshalJSON encodes s as a JSON array of its elements in iteration order.
+func (s *Linked[T]) MarshalJSON() ([]byte, error) {
+	vs := make([]T, 0, s.Len())
+	for e := s.l.Front(); e != nil; e = e.Next() {
+		vs = append(vs, e.Value)
+	}
+	return marshalElems(vs, false)
+}
+
+// UnmarshalJSON replaces the contents of s with the elements of a JSON
+// array, in array order. Duplicate elements are kept at the position of
+// their first occurrence.
+func (s *Linked[T]) UnmarshalJSON(data []byte) error {
+	var vs []T
+	if err := json.Unmarshal(data, &vs); err != nil {
+		return err
+	}
+	s.Clear()
+	for _, v := range vs {
+		s.Add(v)
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The text is the JSON
+// array produced by MarshalJSON.
+func (s *Linked[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by decoding text as
+// UnmarshalJSON does.
+func (s *Linked[T]) UnmarshalText(text []byte) error {
+	return s.UnmarshalJSON(text)
+}
+
+// MarshalJSONFunc encodes s as a JSON array of its elements sorted by
+// cmp, for callers that need an order other than the one s encodes in by
+// itself.
+func MarshalJSONFunc[T comparable](s Reader[T], cmp func(a, b T) int) ([]byte, error) {
+	vs := make([]T, 0, s.Len())
+	for v := range s.All() {
+		vs = append(vs, v)
+	}
+	slices.SortFunc(vs, cmp)
+	return marshalElems(vs, false)
+}
+
+// sortOrdered sorts vs by value and reports true if the underlying type of
+// T is an integer, floating-point, or string type. Otherwise it leaves vs
+// unchanged and reports false.
+func sortOrdered[T any](vs []T) bool {
+	rv := reflect.ValueOf(vs)
+	var less func(i, j int) bool
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		less = func(i, j int) bool { return rv.Index(i).Int() < rv.Index(j).Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		less = func(i, j int) bool { return rv.Index(i).Uint() < rv.Index(j).Uint() }
+	case reflect.Float32, reflect.Float64:
+		less = func(i, j int) bool { return cmp.Less(rv.Index(i).Float(), rv.Index(j).Float()) }
+	case reflect.String:
+		less = func(i, j int) bool { return rv.Index(i).String() < rv.Index(j).String() }
+	default:
+		return false
+	}
+	sort.Slice(vs, less)
+	return true
+}
+
+// marshalElems encodes vs as a JSON array, element by element, so that a
+// []byte-like vs is not encoded as a base64 string. If byEncoding is true,
+// the elements are sorted by their encodings.
+func marshalElems[T any](vs []T, byEncoding bool) ([]byte, error) {
+	elems := make([][]byte, len(vs))
+	for i, v := range vs {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = b
+	}
+	if byEncoding {
+		slices.SortFunc(elems, bytes.Compare)
+	}
+	b := append([]byte{'['}, bytes.Join(elems, []byte{','})...)
+	return append(b, ']'), nil
+}
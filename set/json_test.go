@@ -0,0 +1,163 @@
+package set
+
+import (
+	"encoding"
+	"encoding/json"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Set[int])(nil)
+	_ encoding.TextUnmarshaler = (*Set[int])(nil)
+	_ encoding.TextMarshaler   = (*Linked[int])(nil)
+	_ encoding.TextUnmarshaler = (*Linked[int])(nil)
+)
+
+func TestSetJSON(t *testing.T) {
+	var s Set[string]
+	if err := json.Unmarshal([]byte(`["b","a","b"]`), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 2 || !s.Contains("a") || !s.Contains("b") {
+		t.Errorf("UnmarshalJSON produced %v", slices.Collect(s.All()))
+	}
+	data, err := json.Marshal(&s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Set[string]
+	if err := json.Unmarshal(data, &back); err != nil || back.Len() != 2 {
+		t.Errorf("round trip through %s = %v, %v", data, slices.Collect(back.All()), err)
+	}
+}
+
+func TestLinkedJSON(t *testing.T) {
+	s := NewLinked[string]()
+	for _, v := range []string{"b", "a", "c"} {
+		s.Add(v)
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if string(b) != `["b","a","c"]` {
+		t.Errorf("Marshal = %s, want [\"b\",\"a\",\"c\"]", b)
+	}
+
+	var empty Linked[string]
+	if b, _ := json.Marshal(&empty); string(b) != "[]" {
+		t.Errorf("Marshal of empty set = %s, want []", b)
+	}
+
+	s2 := NewLinked[string]()
+	s2.Add("stale")
+	if err := json.Unmarshal([]byte(`["x","y","x","z","y"]`), s2); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	checkLinked(t, s2, []string{"x", "y", "z"})
+
+	var v struct{ S *Linked[int] }
+	if err := json.Unmarshal([]byte(`{"S":[3,1,3]}`), &v); err != nil {
+		t.Fatalf("Unmarshal into field error: %v", err)
+	}
+	checkLinked(t, v.S, []int{3, 1})
+
+	if err := json.Unmarshal([]byte(`{}`), s2); err == nil {
+		t.Errorf("Unmarshal of object returned nil error")
+	}
+}
+
+type label string
+
+type point struct{ X, Y int }
+
+func TestSetJSONOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	check := func(name string, marshal func() ([]byte, error), want string) {
+		t.Helper()
+		for range 20 {
+			b, err := marshal()
+			if err != nil || string(b) != want {
+				t.Fatalf("%s: Marshal = %s, %v; want %s", name, b, err, want)
+			}
+		}
+	}
+
+	ints := []int{10, -3, 7, 0, 9, 100, 2}
+	var want []byte
+	for range 20 {
+		r.Shuffle(len(ints), func(i, j int) { ints[i], ints[j] = ints[j], ints[i] })
+		b, err := json.Marshal(New(ints...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want == nil {
+			want = b
+		} else if string(b) != string(want) {
+			t.Fatalf("Marshal = %s, earlier %s", b, want)
+		}
+	}
+	if string(want) != "[-3,0,2,7,9,10,100]" {
+		t.Errorf("Marshal of ints = %s, want ascending", want)
+	}
+
+	check("label", New[label]("b", "c", "a").MarshalJSON, `["a","b","c"]`)
+	check("uint8", New[uint8](3, 1, 2).MarshalJSON, `[1,2,3]`)
+	var bs Set[uint8]
+	if err := json.Unmarshal([]byte(`[2,1,2]`), &bs); err != nil || bs.Len() != 2 {
+		t.Errorf("Unmarshal of uint8 set = %v, %v", slices.Collect(bs.All()), err)
+	}
+	check("float64", New(2.5, -1, 0.5).MarshalJSON, `[-1,0.5,2.5]`)
+	check("struct", New(point{2, 1}, point{1, 2}, point{1, 1}).MarshalJSON,
+		`[{"X":1,"Y":1},{"X":1,"Y":2},{"X":2,"Y":1}]`)
+	check("bool", New(true, false).MarshalJSON, `[false,true]`)
+	check("empty", new(Set[point]).MarshalJSON, `[]`)
+
+	desc := func(a, b int) int { return b - a }
+	check("MarshalJSONFunc Set", func() ([]byte, error) {
+		return MarshalJSONFunc[int](New(1, 3, 2), desc)
+	}, `[3,2,1]`)
+	l := NewLinked[int]()
+	for _, v := range []int{2, 3, 1} {
+		l.Add(v)
+	}
+	check("MarshalJSONFunc Linked", func() ([]byte, error) {
+		return MarshalJSONFunc[int](l, desc)
+	}, `[3,2,1]`)
+	check("Linked", l.MarshalJSON, `[2,3,1]`)
+	lb := NewLinked[byte]()
+	lb.Add(2)
+	lb.Add(1)
+	check("Linked[byte]", lb.MarshalJSON, `[2,1]`)
+}
+
+func TestText(t *testing.T) {
+	s := New("b", "a")
+	text, err := s.MarshalText()
+	if err != nil || string(text) != `["a","b"]` {
+		t.Errorf("Set.MarshalText = %s, %v; want [\"a\",\"b\"]", text, err)
+	}
+	var back Set[string]
+	if err := back.UnmarshalText([]byte(`["c","a","c"]`)); err != nil || !slices.Equal(slices.Sorted(back.All()), []string{"a", "c"}) {
+		t.Errorf("Set.UnmarshalText = %v, %v", slices.Collect(back.All()), err)
+	}
+	if err := back.UnmarshalText([]byte(`a,b`)); err == nil {
+		t.Errorf("Set.UnmarshalText of non-array succeeded")
+	}
+
+	l := NewLinked[int]()
+	for _, v := range []int{3, 1, 2} {
+		l.Add(v)
+	}
+	text, err = l.MarshalText()
+	if err != nil || string(text) != "[3,1,2]" {
+		t.Errorf("Linked.MarshalText = %s, %v; want [3,1,2]", text, err)
+	}
+	var lback Linked[int]
+	if err := lback.UnmarshalText(text); err != nil {
+		t.Fatalf("Linked.UnmarshalText error: %v", err)
+	}
+	checkLinked(t, &lback, []int{3, 1, 2})
+}
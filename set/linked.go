@@ -0,0 +1,138 @@
+// Package set implements set types.
+package set
+
+import (
+	"iter"
+
+	"github.com/nishanths/typedcontainer/list"
+)
+
+// Linked is a set that iterates in a predictable order: by default the
+// order in which elements were first added. The order can be changed with
+// MoveToFront and MoveToBack. All operations are O(1).
+//
+// The zero value for Linked is an empty set ready to use.
+type Linked[T comparable] struct {
+	m      map[T]*list.Element[T]
+	l      list.List[T]
+	frozen bool
+}
+
+// NewLinked returns an empty Linked set.
+func NewLinked[T comparable]() *Linked[T] {
+	return &Linked[T]{m: make(map[T]*list.Element[T])}
+}
+
+// Len returns the number of elements in s.
+func (s *Linked[T]) Len() int {
+	return len(s.m)
+}
+
+// Contains reports whether v is in s.
+func (s *Linked[T]) Contains(v T) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+// Add adds v to the back of s. If v is already in s, its position is
+// unchanged. Add reports whether v was added.
+func (s *Linked[T]) Add(v T) bool {
+	s.checkFrozen()
+	if _, ok := s.m[v]; ok {
+		return false
+	}
+	if s.m == nil {
+		s.m = make(map[T]*list.Element[T])
+	}
+	s.m[v] = s.l.PushBack(v)
+	return true
+}
+
+// Remove removes v from s. It reports whether v was in s.
+func (s *Linked[T]) Remove(v T) bool {
+	s.checkFrozen()
+	e, ok := s.m[v]
+	if !ok {
+		return false
+	}
+	delete(s.m, v)
+	s.l.Remove(e)
+	return true
+}
+
+// MoveToFront moves v to the front of s. It reports whether v was in s.
+func (s *Linked[T]) MoveToFront(v T) bool {
+	s.checkFrozen()
+	e, ok := s.m[v]
+	if !ok {
+		return false
+	}
+	s.l.MoveToFront(e)
+	return true
+}
+
+// MoveToBack moves v to the back of s. It reports whether v was in s.
+func (s *Linked[T]) MoveToBack(v T) bool {
+	s.checkFrozen()
+	e, ok := s.m[v]
+	if !ok {
+		return false
+	}
+	s.l.MoveToBack(e)
+	return true
+}
+
+// Front returns the first element of s. The boolean is false if s is empty.
+func (s *Linked[T]) Front() (T, bool) {
+	e := s.l.Front()
+	if e == nil {
+		var zero T
+		return zero, false
+	}
+	return e.Value, true
+}
+
+// Back returns the last element of s. The boolean is false if s is empty.
+func (s *Linked[T]) Back() (T, bool) {
+	e := s.l.Back()
+	if e == nil {
+		var zero T
+		return zero, false
+	}
+	return e.Value, true
+}
+
+// PopFront removes and returns the first element of s. The boolean is
+// false if s is empty.
+func (s *Linked[T]) PopFront() (T, bool) {
+	s.checkFrozen()
+	v, ok := s.Front()
+	if ok {
+		s.Remove(v)
+	}
+	return v, ok
+}
+
+// PopBack removes and returns the last element of s. The boolean is false
+// if s is empty.
+func (s *Linked[T]) PopBack() (T, bool) {
+	s.checkFrozen()
+	v, ok := s.Back()
+	if ok {
+		s.Remove(v)
+	}
+	return v, ok
+}
+
+// Clear removes all elements from s.
+func (s *Linked[T]) Clear() {
+	s.checkFrozen()
+	clear(s.m)
+	s.l.Init()
+}
+
+// All returns an iterator over the elements of s, front to back.
+// It is safe to remove the current element during iteration.
+func (s *Linked[T]) All() iter.Seq[T] {
+	return s.l.All()
+}
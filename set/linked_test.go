@@ -0,0 +1,101 @@
+package set
+
+import (
+	"slices"
+	"testing"
+)
+
+func checkLinked[T comparable](t *testing.T, s *Linked[T], want []T) {
+	t.Helper()
+
+	if n := s.Len(); n != len(want) {
+		t.Errorf("s.Len() = %d, want %d", n, len(want))
+	}
+	if got := slices.Collect(s.All()); !slices.Equal(got, want) {
+		t.Errorf("s.All() = %v, want %v", got, want)
+	}
+	for _, v := range want {
+		if !s.Contains(v) {
+			t.Errorf("s.Contains(%v) = false, want true", v)
+		}
+	}
+}
+
+func TestLinked(t *testing.T) {
+	s := NewLinked[string]()
+	checkLinked(t, s, []string{})
+
+	if !s.Add("a") {
+		t.Errorf("s.Add(a) = false, want true")
+	}
+	s.Add("b")
+	s.Add("c")
+	if s.Add("a") {
+		t.Errorf("s.Add(a) = true, want false for existing element")
+	}
+	checkLinked(t, s, []string{"a", "b", "c"})
+
+	s.MoveToFront("c")
+	checkLinked(t, s, []string{"c", "a", "b"})
+	s.MoveToBack("c")
+	checkLinked(t, s, []string{"a", "b", "c"})
+	if s.MoveToFront("z") || s.MoveToBack("z") {
+		t.Errorf("Move of missing element reported true")
+	}
+
+	if !s.Remove("b") {
+		t.Errorf("s.Remove(b) = false, want true")
+	}
+	if s.Remove("b") {
+		t.Errorf("s.Remove(b) = true, want false after removal")
+	}
+	if s.Contains("b") {
+		t.Errorf("s.Contains(b) = true after removal")
+	}
+	checkLinked(t, s, []string{"a", "c"})
+
+	if v, ok := s.Front(); v != "a" || !ok {
+		t.Errorf("s.Front() = %q, %v, want a, true", v, ok)
+	}
+	if v, ok := s.Back(); v != "c" || !ok {
+		t.Errorf("s.Back() = %q, %v, want c, true", v, ok)
+	}
+
+	if v, ok := s.PopFront(); v != "a" || !ok {
+		t.Errorf("s.PopFront() = %q, %v, want a, true", v, ok)
+	}
+	if v, ok := s.PopBack(); v != "c" || !ok {
+		t.Errorf("s.PopBack() = %q, %v, want c, true", v, ok)
+	}
+	checkLinked(t, s, []string{})
+	if _, ok := s.PopFront(); ok {
+		t.Errorf("s.PopFront() on empty set reported true")
+	}
+}
+
+func TestLinkedZero(t *testing.T) {
+	var s Linked[int]
+	if s.Contains(1) || s.Remove(1) {
+		t.Errorf("zero Linked reported containing 1")
+	}
+	s.Add(1)
+	s.Add(2)
+	checkLinked(t, &s, []int{1, 2})
+	s.Clear()
+	checkLinked(t, &s, []int{})
+	s.Add(3)
+	checkLinked(t, &s, []int{3})
+}
+
+func TestLinkedRemoveDuringIteration(t *testing.T) {
+	s := NewLinked[int]()
+	for i := range 5 {
+		s.Add(i)
+	}
+	for v := range s.All() {
+		if v%2 == 0 {
+			s.Remove(v)
+		}
+	}
+	checkLinked(t, s, []int{1, 3})
+}
@@ -0,0 +1,70 @@
+package set
+
+import "iter"
+
+// Reader is the read-only part of a set, implemented by the set types in
+// this package.
+type Reader[T comparable] interface {
+	Len() int
+	Contains(v T) bool
+	All() iter.Seq[T]
+}
+
+// UnionSeq returns an iterator over the elements of a followed by the
+// elements of b that are not in a. The union is computed lazily, without
+// building a new set.
+func UnionSeq[T comparable](a, b Reader[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range a.All() {
+			if !yield(v) {
+				return
+			}
+		}
+		for v := range b.All() {
+			if !a.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// IntersectSeq returns an iterator over the elements of a that are also in
+// b, computed lazily.
+func IntersectSeq[T comparable](a, b Reader[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range a.All() {
+			if b.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DiffSeq returns an iterator over the elements of a that are not in b,
+// computed lazily.
+func DiffSeq[T comparable](a, b Reader[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range a.All() {
+			if !b.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SymDiffSeq returns an iterator over the elements of a that are not in b
+// followed by the elements of b that are not in a, computed lazily.
+func SymDiffSeq[T comparable](a, b Reader[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range DiffSeq(a, b) {
+			if !yield(v) {
+				return
+			}
+		}
+		for v := range DiffSeq(b, a) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
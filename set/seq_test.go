@@ -0,0 +1,56 @@
+package set
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+func linkedOf[T comparable](vs ...T) *Linked[T] {
+	s := NewLinked[T]()
+	for _, v := range vs {
+		s.Add(v)
+	}
+	return s
+}
+
+func TestSeqOps(t *testing.T) {
+	a := linkedOf(1, 2, 3, 4)
+	b := linkedOf(3, 4, 5, 6)
+
+	tests := []struct {
+		name string
+		seq  iter.Seq[int]
+		want []int
+	}{
+		{"UnionSeq", UnionSeq(a, b), []int{1, 2, 3, 4, 5, 6}},
+		{"IntersectSeq", IntersectSeq(a, b), []int{3, 4}},
+		{"DiffSeq", DiffSeq(a, b), []int{1, 2}},
+		{"DiffSeq reversed", DiffSeq(b, a), []int{5, 6}},
+		{"SymDiffSeq", SymDiffSeq(a, b), []int{1, 2, 5, 6}},
+		{"DiffSeq empty", DiffSeq(a, linkedOf[int]()), []int{1, 2, 3, 4}},
+		{"IntersectSeq empty", IntersectSeq(linkedOf[int](), b), nil},
+	}
+	for _, tt := range tests {
+		if got := slices.Collect(tt.seq); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSeqOpsEarlyStop(t *testing.T) {
+	a := linkedOf(1, 2, 3)
+	b := linkedOf(4, 5, 6)
+	for _, seq := range []iter.Seq[int]{UnionSeq(a, b), SymDiffSeq(a, b)} {
+		var got []int
+		for v := range seq {
+			got = append(got, v)
+			if v == 4 {
+				break
+			}
+		}
+		if !slices.Equal(got, []int{1, 2, 3, 4}) {
+			t.Errorf("got %v before break, want [1 2 3 4]", got)
+		}
+	}
+}
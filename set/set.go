@@ -0,0 +1,119 @@
+package set
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Set is an unordered set backed by a map. Contains, Add, and Remove are
+// O(1); the binary operations return a new Set and accept any Reader, so a
+// Set can be combined with a Linked set.
+//
+// The zero value for Set is an empty set ready to use.
+type Set[T comparable] struct {
+	m      map[T]struct{}
+	frozen bool
+}
+
+// New returns a Set containing vs.
+func New[T comparable](vs ...T) *Set[T] {
+	s := &Set[T]{m: make(map[T]struct{}, len(vs))}
+	for _, v := range vs {
+		s.m[v] = struct{}{}
+	}
+	return s
+}
+
+// Len returns the number of elements in s.
+func (s *Set[T]) Len() int {
+	return len(s.m)
+}
+
+// Contains reports whether v is in s.
+func (s *Set[T]) Contains(v T) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+// Add adds v to s. It reports whether v was added.
+func (s *Set[T]) Add(v T) bool {
+	s.checkFrozen()
+	if _, ok := s.m[v]; ok {
+		return false
+	}
+	if s.m == nil {
+		s.m = make(map[T]struct{})
+	}
+	s.m[v] = struct{}{}
+	return true
+}
+
+// Remove removes v from s. It reports whether v was in s.
+func (s *Set[T]) Remove(v T) bool {
+	s.checkFrozen()
+	if _, ok := s.m[v]; !ok {
+		return false
+	}
+	delete(s.m, v)
+	return true
+}
+
+// Clear removes all elements from s.
+func (s *Set[T]) Clear() {
+	s.checkFrozen()
+	clear(s.m)
+}
+
+// Clone returns a copy of s.
+func (s *Set[T]) Clone() *Set[T] {
+	c := maps.Clone(s.m)
+	if c == nil {
+		c = make(map[T]struct{})
+	}
+	return &Set[T]{m: c}
+}
+
+// All returns an iterator over the elements of s in unspecified order.
+// It is safe to remove the current element during iteration.
+func (s *Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s.m)
+}
+
+// Union returns a new Set with the elements that are in s or in o.
+func (s *Set[T]) Union(o Reader[T]) *Set[T] {
+	u := s.Clone()
+	for v := range o.All() {
+		u.m[v] = struct{}{}
+	}
+	return u
+}
+
+// Intersection returns a new Set with the elements of s that are also in
+// o.
+func (s *Set[T]) Intersection(o Reader[T]) *Set[T] {
+	return Collect(IntersectSeq[T](s, o))
+}
+
+// Difference returns a new Set with the elements of s that are not in o.
+func (s *Set[T]) Difference(o Reader[T]) *Set[T] {
+	return Collect(DiffSeq[T](s, o))
+}
+
+// SymmetricDifference returns a new Set with the elements that are in
+// exactly one of s and o.
+func (s *Set[T]) SymmetricDifference(o Reader[T]) *Set[T] {
+	return Collect(SymDiffSeq[T](s, o))
+}
+
+// Collect returns a new Set holding the values yielded by seq. Duplicate
+// values are stored once. Collect buffers the values so that the set can
+// be allocated at its final size, avoiding repeated growth.
+func Collect[T comparable](seq iter.Seq[T]) *Set[T] {
+	vs := slices.Collect(seq)
+	s := &Set[T]{m: make(map[T]struct{}, len(vs))}
+	for _, v := range vs {
+		s.m[v] = struct{}{}
+	}
+	return s
+}
@@ -0,0 +1,94 @@
+package set
+
+import (
+	"slices"
+	"testing"
+)
+
+func sorted(s *Set[int]) []int {
+	return slices.Sorted(s.All())
+}
+
+func TestSet(t *testing.T) {
+	var s Set[int]
+	if s.Contains(1) || s.Len() != 0 {
+		t.Errorf("zero Set is not empty")
+	}
+	if !s.Add(1) || s.Add(1) || !s.Add(2) {
+		t.Errorf("Add did not report additions correctly")
+	}
+	if s.Len() != 2 || !s.Contains(2) {
+		t.Errorf("Len() = %d, want 2", s.Len())
+	}
+	if !s.Remove(1) || s.Remove(1) || s.Contains(1) {
+		t.Errorf("Remove did not report removals correctly")
+	}
+
+	c := s.Clone()
+	c.Add(3)
+	if s.Contains(3) {
+		t.Errorf("Clone shares storage with the original")
+	}
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("Len() after Clear = %d", s.Len())
+	}
+}
+
+func TestSetOps(t *testing.T) {
+	a := New(1, 2, 3, 4)
+	b := New(3, 4, 5, 6)
+	tests := []struct {
+		name string
+		got  *Set[int]
+		want []int
+	}{
+		{"Union", a.Union(b), []int{1, 2, 3, 4, 5, 6}},
+		{"Intersection", a.Intersection(b), []int{3, 4}},
+		{"Difference", a.Difference(b), []int{1, 2}},
+		{"SymmetricDifference", a.SymmetricDifference(b), []int{1, 2, 5, 6}},
+		{"Union with Linked", a.Union(linkedOf(9, 1)), []int{1, 2, 3, 4, 9}},
+		{"Intersection with empty", a.Intersection(New[int]()), nil},
+	}
+	for _, tt := range tests {
+		if got := sorted(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := sorted(a); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("operations modified the receiver: %v", got)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	s := Collect(slices.Values([]int{3, 1, 3, 2}))
+	if got := sorted(s); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Collect = %v, want [1 2 3]", got)
+	}
+	if !s.Add(4) {
+		t.Errorf("Add to collected set failed")
+	}
+	if Collect(slices.Values([]int(nil))).Len() != 0 {
+		t.Errorf("Collect of empty sequence is not empty")
+	}
+}
+
+func BenchmarkCollect(b *testing.B) {
+	vs := make([]int, 10000)
+	for i := range vs {
+		vs[i] = i % 5000
+	}
+	b.Run("Add", func(b *testing.B) {
+		for range b.N {
+			var s Set[int]
+			for _, v := range vs {
+				s.Add(v)
+			}
+		}
+	})
+	b.Run("Collect", func(b *testing.B) {
+		for range b.N {
+			Collect(slices.Values(vs))
+		}
+	})
+}
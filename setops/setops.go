@@ -0,0 +1,179 @@
+// Package setops implements set algebra over iterators, so that any
+// container that can produce an iter.Seq, such as a list, a set, or a
+// sorted map's keys, can be combined with any other.
+//
+// The functions with an Into suffix write their result into a destination
+// container, given as the function that adds one element to it, such as
+// func(v T) { s.Add(v) }. The functions without one, which require sorted
+// inputs, merge them lazily and return an iterator. Either way, every
+// distinct element is produced at most once. To combine two set.Reader
+// values lazily, use set.UnionSeq and its relatives.
+package setops
+
+import (
+	"cmp"
+	"iter"
+)
+
+// UnionInto calls add with each distinct element of seqs, in order of
+// first appearance.
+func UnionInto[T comparable](add func(T), seqs ...iter.Seq[T]) {
+	seen := make(map[T]struct{})
+	for _, seq := range seqs {
+		for v := range seq {
+			if _, ok := seen[v]; !ok {
+				seen[v] = struct{}{}
+				add(v)
+			}
+		}
+	}
+}
+
+// IntersectInto calls add with each distinct element of a that is also in
+// b, in the order it appears in a. b is read in full first.
+func IntersectInto[T comparable](add func(T), a, b iter.Seq[T]) {
+	in := make(map[T]bool) // true once added
+	for v := range b {
+		in[v] = false
+	}
+	for v := range a {
+		if done, ok := in[v]; ok && !done {
+			in[v] = true
+			add(v)
+		}
+	}
+}
+
+// DifferenceInto calls add with each distinct element of a that is not in
+// b, in the order it appears in a. b is read in full first.
+func DifferenceInto[T comparable](add func(T), a, b iter.Seq[T]) {
+	skip := make(map[T]struct{})
+	for v := range b {
+		skip[v] = struct{}{}
+	}
+	for v := range a {
+		if _, ok := skip[v]; !ok {
+			skip[v] = struct{}{}
+			add(v)
+		}
+	}
+}
+
+// Which inputs a merge needs to continue.
+const (
+	needEither = iota
+	needA
+	needBoth
+)
+
+// merge walks the ascending sequences a and b in step, skipping
+// duplicates within each, and calls emit with each distinct value and
+// whether it occurs in a and in b. It stops when emit returns false or
+// when the inputs that need says are required are exhausted.
+func merge[T cmp.Ordered](a, b iter.Seq[T], need int, emit func(v T, inA, inB bool) bool) {
+	nextA, stopA := iter.Pull(a)
+	defer stopA()
+	nextB, stopB := iter.Pull(b)
+	defer stopB()
+
+	// advance returns the next value from next that is greater than prev.
+	advance := func(next func() (T, bool), prev T) (T, bool) {
+		for {
+			v, ok := next()
+			if !ok || v > prev {
+				return v, ok
+			}
+		}
+	}
+	va, okA := nextA()
+	vb, okB := nextB()
+	for {
+		switch need {
+		case needEither:
+			if !okA && !okB {
+				return
+			}
+		case needA:
+			if !okA {
+				return
+			}
+		case needBoth:
+			if !okA || !okB {
+				return
+			}
+		}
+		switch {
+		case !okB || okA && va < vb:
+			if !emit(va, true, false) {
+				return
+			}
+			va, okA = advance(nextA, va)
+		case !okA || vb < va:
+			if !emit(vb, false, true) {
+				return
+			}
+			vb, okB = advance(nextB, vb)
+		default:
+			if !emit(va, true, true) {
+				return
+			}
+			va, okA = advance(nextA, va)
+			vb, okB = advance(nextB, vb)
+		}
+	}
+}
+
+// UnionSorted returns an iterator over the distinct elements of the
+// ascending sequences a and b, in ascending order. It merges the inputs
+// in a single pass without building a set.
+func UnionSorted[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		merge(a, b, needEither, func(v T, _, _ bool) bool { return yield(v) })
+	}
+}
+
+// IntersectSorted returns an iterator over the distinct elements common
+// to the ascending sequences a and b, in ascending order.
+func IntersectSorted[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		merge(a, b, needBoth, func(v T, inA, inB bool) bool {
+			return !(inA && inB) || yield(v)
+		})
+	}
+}
+
+// DifferenceSorted returns an iterator over the distinct elements of the
+// ascending sequence a that are not in the ascending sequence b, in
+// ascending order.
+func DifferenceSorted[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		merge(a, b, needA, func(v T, inA, inB bool) bool {
+			return !inA || inB || yield(v)
+		})
+	}
+}
+
+// UnionSortedInto calls add with each distinct element of the ascending
+// sequences a and b, in ascending order.
+func UnionSortedInto[T cmp.Ordered](add func(T), a, b iter.Seq[T]) {
+	for v := range UnionSorted(a, b) {
+		add(v)
+	}
+}
+
+// IntersectSortedInto calls add with each distinct element common to the
+// ascending sequences a and b, in ascending order.
+func IntersectSortedInto[T cmp.Ordered](add func(T), a, b iter.Seq[T]) {
+	for v := range IntersectSorted(a, b) {
+		add(v)
+	}
+}
+
+// DifferenceSortedInto calls add with each distinct element of the
+// ascending sequence a that is not in the ascending sequence b, in
+// ascending order.
+func DifferenceSortedInto[T cmp.Ordered](add func(T), a, b iter.Seq[T]) {
+	for v := range DifferenceSorted(a, b) {
+		add(v)
+	}
+}
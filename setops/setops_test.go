@@ -0,0 +1,115 @@
+package setops
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/nishanths/typedcontainer/list"
+	"github.com/nishanths/typedcontainer/set"
+)
+
+func TestHashOps(t *testing.T) {
+	l := list.FromSlice([]int{4, 1, 2, 2, 3})
+	s := set.NewLinked[int]()
+	for _, v := range []int{3, 5, 4, 6} {
+		s.Add(v)
+	}
+	collect := func(f func(add func(int))) []int {
+		var out []int
+		f(func(v int) { out = append(out, v) })
+		return out
+	}
+	tests := []struct {
+		name string
+		got  []int
+		want []int
+	}{
+		{"UnionInto", collect(func(add func(int)) { UnionInto(add, l.All(), s.All()) }), []int{4, 1, 2, 3, 5, 6}},
+		{"UnionInto none", collect(func(add func(int)) { UnionInto(add) }), nil},
+		{"IntersectInto", collect(func(add func(int)) { IntersectInto(add, l.All(), s.All()) }), []int{4, 3}},
+		{"IntersectInto dup", collect(func(add func(int)) {
+			IntersectInto(add, slices.Values([]int{1, 1, 2}), slices.Values([]int{1}))
+		}), []int{1}},
+		{"DifferenceInto", collect(func(add func(int)) { DifferenceInto(add, l.All(), s.All()) }), []int{1, 2}},
+		{"DifferenceInto reversed", collect(func(add func(int)) { DifferenceInto(add, s.All(), l.All()) }), []int{5, 6}},
+	}
+	for _, tt := range tests {
+		if !slices.Equal(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// The destination can be any container.
+	dst := set.New[int]()
+	UnionInto(func(v int) { dst.Add(v) }, l.All(), s.All())
+	if dst.Len() != 6 {
+		t.Errorf("UnionInto set: Len() = %d, want 6", dst.Len())
+	}
+	into := list.New[int]()
+	DifferenceInto(func(v int) { into.PushBack(v) }, s.All(), l.All())
+	if got := into.ToSlice(); !slices.Equal(got, []int{5, 6}) {
+		t.Errorf("DifferenceInto list = %v, want [5 6]", got)
+	}
+}
+
+func TestSortedOps(t *testing.T) {
+	vals := func(vs ...int) iter.Seq[int] { return slices.Values(vs) }
+	tests := []struct {
+		name string
+		seq  iter.Seq[int]
+		want []int
+	}{
+		{"UnionSorted", UnionSorted(vals(1, 3, 3, 5), vals(2, 3, 6)), []int{1, 2, 3, 5, 6}},
+		{"UnionSorted empty", UnionSorted(vals(), vals(1, 1)), []int{1}},
+		{"IntersectSorted", IntersectSorted(vals(1, 2, 2, 4, 6), vals(2, 2, 3, 4, 5)), []int{2, 4}},
+		{"IntersectSorted disjoint", IntersectSorted(vals(1, 3), vals(2, 4)), nil},
+		{"DifferenceSorted", DifferenceSorted(vals(1, 2, 2, 4, 6), vals(2, 3, 4)), []int{1, 6}},
+		{"DifferenceSorted empty b", DifferenceSorted(vals(1, 1, 2), vals()), []int{1, 2}},
+	}
+	for _, tt := range tests {
+		if got := slices.Collect(tt.seq); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// DifferenceSorted stops reading b once a is exhausted.
+	read := 0
+	b := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			read++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	if got := slices.Collect(DifferenceSorted(vals(1, 3), b)); got != nil {
+		t.Errorf("DifferenceSorted against naturals = %v, want none", got)
+	}
+	if read > 5 {
+		t.Errorf("DifferenceSorted read %d elements of b, want it to stop early", read)
+	}
+
+	var got []int
+	for v := range UnionSorted(vals(1, 2, 3), vals(4)) {
+		got = append(got, v)
+		if v == 2 {
+			break
+		}
+	}
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("UnionSorted with break = %v, want [1 2]", got)
+	}
+}
+
+func TestSortedInto(t *testing.T) {
+	vals := func(vs ...int) iter.Seq[int] { return slices.Values(vs) }
+	var got []int
+	add := func(v int) { got = append(got, v) }
+	UnionSortedInto(add, vals(1, 3), vals(2, 3))
+	IntersectSortedInto(add, vals(1, 3), vals(2, 3))
+	DifferenceSortedInto(add, vals(1, 3), vals(2, 3))
+	if want := []int{1, 2, 3, 3, 1}; !slices.Equal(got, want) {
+		t.Errorf("sorted Into results = %v, want %v", got, want)
+	}
+}
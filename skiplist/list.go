@@ -0,0 +1,240 @@
+// Package skiplist implements a sorted map as a skip list.
+package skiplist
+
+import (
+	"cmp"
+	"iter"
+	"math/bits"
+	"math/rand/v2"
+)
+
+// maxLevel bounds the height of the list. With a promotion probability of
+// 1/4 it supports about 4^maxLevel entries before searches degrade.
+const maxLevel = 24
+
+type node[K, V any] struct {
+	key   K
+	value V
+	prev  *node[K, V]   // at level 0; nil for the first entry
+	next  []*node[K, V] // next[i] is the successor at level i
+}
+
+// List is a sorted map from K to V, stored as a skip list: a linked list
+// of entries in key order, with additional levels of links that skip over
+// exponentially more entries, so that Get, Set, and Delete take expected
+// O(log n) time. Min, Max, and stepping through entries in either
+// direction are O(1).
+//
+// A List is not safe for concurrent use.
+//
+// The zero value for List is not usable; use New or NewFunc.
+type List[K, V any] struct {
+	head  node[K, V] // sentinel; head.next[i] is the first entry at level i
+	tail  *node[K, V]
+	level int // number of levels in use
+	len   int
+	cmp   func(a, b K) int
+}
+
+// New returns an empty List for an ordered key type.
+func New[K cmp.Ordered, V any]() *List[K, V] {
+	return NewFunc[K, V](cmp.Compare[K])
+}
+
+// NewFunc returns an empty List ordered by cmp, which returns a negative
+// number, zero, or a positive number as a is less than, equal to, or
+// greater than b.
+func NewFunc[K, V any](cmp func(a, b K) int) *List[K, V] {
+	l := &List[K, V]{cmp: cmp, level: 1}
+	l.head.next = make([]*node[K, V], maxLevel)
+	return l
+}
+
+// Len returns the number of entries in l.
+func (l *List[K, V]) Len() int {
+	return l.len
+}
+
+// search returns the first node with key >= k, or nil. If update is not
+// nil, update[i] is set to the last node before that position at level i.
+func (l *List[K, V]) search(k K, update *[maxLevel]*node[K, V]) *node[K, V] {
+	x := &l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && l.cmp(x.next[i].key, k) < 0 {
+			x = x.next[i]
+		}
+		if update != nil {
+			update[i] = x
+		}
+	}
+	return x.next[0]
+}
+
+// find returns the node with key k, or nil.
+func (l *List[K, V]) find(k K) *node[K, V] {
+	if n := l.search(k, nil); n != nil && l.cmp(n.key, k) == 0 {
+		return n
+	}
+	return nil
+}
+
+// Get returns the value for k. The boolean is false if k is not in l.
+func (l *List[K, V]) Get(k K) (V, bool) {
+	if n := l.find(k); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// GetOr returns the value for k, or def if k is not in l.
+func (l *List[K, V]) GetOr(k K, def V) V {
+	if v, ok := l.Get(k); ok {
+		return v
+	}
+	return def
+}
+
+// MustGet returns the value for k. It panics if k is not in l.
+func (l *List[K, V]) MustGet(k K) V {
+	v, ok := l.Get(k)
+	if !ok {
+		panic("skiplist: MustGet of missing key")
+	}
+	return v
+}
+
+// Contains reports whether k is in l.
+func (l *List[K, V]) Contains(k K) bool {
+	return l.find(k) != nil
+}
+
+// randomLevel returns the height of a new node: 1 with probability 3/4, 2
+// with probability 3/16, and so on.
+func randomLevel() int {
+	return min(1+bits.TrailingZeros64(rand.Uint64())/2, maxLevel)
+}
+
+// Set sets the value for k. It reports whether k was added, rather than
+// already in l.
+func (l *List[K, V]) Set(k K, v V) bool {
+	var update [maxLevel]*node[K, V]
+	if n := l.search(k, &update); n != nil && l.cmp(n.key, k) == 0 {
+		n.value = v
+		return false
+	}
+	lvl := randomLevel()
+	for ; l.level < lvl; l.level++ {
+		update[l.level] = &l.head
+	}
+	n := &node[K, V]{key: k, value: v, next: make([]*node[K, V], lvl)}
+	for i := range lvl {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+	if update[0] != &l.head {
+		n.prev = update[0]
+	}
+	if n.next[0] != nil {
+		n.next[0].prev = n
+	} else {
+		l.tail = n
+	}
+	l.len++
+	return true
+}
+
+// Delete removes k from l. It reports whether k was in l.
+func (l *List[K, V]) Delete(k K) bool {
+	var update [maxLevel]*node[K, V]
+	n := l.search(k, &update)
+	if n == nil || l.cmp(n.key, k) != 0 {
+		return false
+	}
+	for i := range n.next {
+		update[i].next[i] = n.next[i]
+	}
+	if n.next[0] != nil {
+		n.next[0].prev = n.prev
+	} else {
+		l.tail = n.prev
+	}
+	for l.level > 1 && l.head.next[l.level-1] == nil {
+		l.level--
+	}
+	l.len--
+	return true
+}
+
+// Clear removes all entries from l.
+func (l *List[K, V]) Clear() {
+	clear(l.head.next)
+	l.tail = nil
+	l.level = 1
+	l.len = 0
+}
+
+func unpack[K, V any](n *node[K, V]) (K, V, bool) {
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return n.key, n.value, true
+}
+
+// Min returns the entry with the least key. The boolean is false if l is
+// empty.
+func (l *List[K, V]) Min() (K, V, bool) {
+	return unpack(l.head.next[0])
+}
+
+// Max returns the entry with the greatest key. The boolean is false if l
+// is empty.
+func (l *List[K, V]) Max() (K, V, bool) {
+	return unpack(l.tail)
+}
+
+// All returns an iterator over the entries of l in increasing key order.
+// It is safe to delete the current key during iteration.
+func (l *List[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		l.ascend(l.head.next[0], nil, yield)
+	}
+}
+
+// Backward returns an iterator over the entries of l in decreasing key
+// order. It is safe to delete the current key during iteration.
+func (l *List[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := l.tail; n != nil; {
+			prev := n.prev
+			if !yield(n.key, n.value) {
+				return
+			}
+			n = prev
+		}
+	}
+}
+
+// AscendRange returns an iterator over the entries of l with keys in
+// [lo, hi), in increasing key order. Finding the start is expected
+// O(log n); each further entry is O(1). It is safe to delete the current
+// key during iteration.
+func (l *List[K, V]) AscendRange(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		l.ascend(l.search(lo, nil), &hi, yield)
+	}
+}
+
+// ascend calls yield with the entries from n onward with keys less than
+// *hi, or all of them if hi is nil, until yield returns false.
+func (l *List[K, V]) ascend(n *node[K, V], hi *K, yield func(K, V) bool) {
+	for n != nil && (hi == nil || l.cmp(n.key, *hi) < 0) {
+		next := n.next[0]
+		if !yield(n.key, n.value) {
+			return
+		}
+		n = next
+	}
+}
@@ -0,0 +1,139 @@
+package skiplist
+
+import (
+	"iter"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func keys[K, V any](seq iter.Seq2[K, V]) []K {
+	var out []K
+	for k := range seq {
+		out = append(out, k)
+	}
+	return out
+}
+
+func TestList(t *testing.T) {
+	l := New[int, string]()
+	if _, _, ok := l.Min(); ok {
+		t.Errorf("Min on empty list reported true")
+	}
+	if _, _, ok := l.Max(); ok {
+		t.Errorf("Max on empty list reported true")
+	}
+	for _, k := range []int{5, 1, 9, 3, 7} {
+		if !l.Set(k, "v") {
+			t.Errorf("Set(%d) of new key reported false", k)
+		}
+	}
+	if l.Set(3, "three") {
+		t.Errorf("Set of existing key reported true")
+	}
+	if v := l.MustGet(3); v != "three" {
+		t.Errorf("MustGet(3) = %q, want three", v)
+	}
+	if l.GetOr(4, "none") != "none" || l.Contains(4) {
+		t.Errorf("missing key 4 found")
+	}
+	if got := keys(l.All()); !slices.Equal(got, []int{1, 3, 5, 7, 9}) {
+		t.Errorf("All() = %v", got)
+	}
+	if got := keys(l.Backward()); !slices.Equal(got, []int{9, 7, 5, 3, 1}) {
+		t.Errorf("Backward() = %v", got)
+	}
+	if got := keys(l.AscendRange(2, 7)); !slices.Equal(got, []int{3, 5}) {
+		t.Errorf("AscendRange(2, 7) = %v, want [3 5]", got)
+	}
+	if got := keys(l.AscendRange(9, 100)); !slices.Equal(got, []int{9}) {
+		t.Errorf("AscendRange(9, 100) = %v, want [9]", got)
+	}
+	if k, _, _ := l.Max(); k != 9 {
+		t.Errorf("Max() = %d, want 9", k)
+	}
+	if !l.Delete(9) || l.Delete(9) {
+		t.Errorf("Delete(9) did not report removal exactly once")
+	}
+	if k, _, _ := l.Max(); k != 7 {
+		t.Errorf("Max() after Delete = %d, want 7", k)
+	}
+	for k := range l.All() {
+		l.Delete(k)
+	}
+	if l.Len() != 0 || keys(l.Backward()) != nil {
+		t.Errorf("list not empty after deleting during iteration")
+	}
+	l.Set(2, "")
+	l.Clear()
+	if l.Len() != 0 || l.Contains(2) {
+		t.Errorf("Clear left entries")
+	}
+}
+
+func TestListFunc(t *testing.T) {
+	l := NewFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	l.Set("b", 1)
+	l.Set("A", 2)
+	l.Set("B", 3)
+	if got := keys(l.All()); !slices.Equal(got, []string{"A", "b"}) {
+		t.Errorf("All() = %v, want [A b]", got)
+	}
+	if v, _ := l.Get("a"); v != 2 {
+		t.Errorf("Get(a) = %d, want 2", v)
+	}
+}
+
+func TestListRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	l := New[int, int]()
+	ref := make(map[int]int)
+	for i := 0; i < 20000; i++ {
+		k := r.Intn(1000)
+		switch r.Intn(3) {
+		case 0, 1:
+			_, had := ref[k]
+			if added := l.Set(k, i); added == had {
+				t.Fatalf("Set(%d) = %v, want %v", k, added, !had)
+			}
+			ref[k] = i
+		case 2:
+			_, had := ref[k]
+			if l.Delete(k) != had {
+				t.Fatalf("Delete(%d) = %v, want %v", k, !had, had)
+			}
+			delete(ref, k)
+		}
+		if l.Len() != len(ref) {
+			t.Fatalf("Len() = %d, want %d", l.Len(), len(ref))
+		}
+	}
+	want := slices.Sorted(maps.Keys(ref))
+	if got := keys(l.All()); !slices.Equal(got, want) {
+		t.Fatalf("All() keys differ from reference")
+	}
+	for k, v := range l.All() {
+		if ref[k] != v {
+			t.Fatalf("value for %d = %d, want %d", k, v, ref[k])
+		}
+	}
+	slices.Reverse(want)
+	if got := keys(l.Backward()); !slices.Equal(got, want) {
+		t.Fatalf("Backward() keys differ from reference")
+	}
+	lo, hi := 250, 750
+	var inRange []int
+	for _, k := range want {
+		if k >= lo && k < hi {
+			inRange = append(inRange, k)
+		}
+	}
+	slices.Reverse(inRange)
+	if got := keys(l.AscendRange(lo, hi)); !slices.Equal(got, inRange) {
+		t.Fatalf("AscendRange(%d, %d) differs from reference", lo, hi)
+	}
+}
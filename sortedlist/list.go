@@ -0,0 +1,187 @@
+// Package sortedlist implements a doubly-linked list kept in sorted order.
+package sortedlist
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/nishanths/typedcontainer/list"
+)
+
+// Element is an element of a List. Its value is read-only, so that it
+// cannot be changed in a way that breaks the order; to change a value,
+// Remove the element and Insert the new value.
+type Element[T any] struct {
+	value T
+	e     *list.Element[*Element[T]]
+}
+
+// Value returns the value stored in e.
+func (e *Element[T]) Value() T {
+	return e.value
+}
+
+// Next returns the next element, the least one not less than e, or nil.
+func (e *Element[T]) Next() *Element[T] {
+	if n := e.e.Next(); n != nil {
+		return n.Value
+	}
+	return nil
+}
+
+// Prev returns the previous element or nil.
+func (e *Element[T]) Prev() *Element[T] {
+	if p := e.e.Prev(); p != nil {
+		return p.Value
+	}
+	return nil
+}
+
+// List is a list whose elements are always sorted according to a less
+// function. Values can only be added through Insert, which places them in
+// order; equal values keep their insertion order.
+//
+// Insert, Find, and Contains scan the list and are O(n), which suits
+// small sorted indexes; for large ones use a balanced tree such as
+// psortedmap. Elements returned by List's methods can be used to walk the
+// list or to remove values in O(1).
+//
+// The zero value for List is not usable; use New or NewOrdered.
+type List[T any] struct {
+	l        list.List[*Element[T]]
+	less     func(a, b T) bool
+	lessElem func(a, b *Element[T]) bool
+}
+
+// New returns an empty List ordered by less.
+func New[T any](less func(a, b T) bool) *List[T] {
+	return &List[T]{
+		less:     less,
+		lessElem: func(a, b *Element[T]) bool { return less(a.value, b.value) },
+	}
+}
+
+// NewOrdered returns an empty List in ascending order.
+func NewOrdered[T cmp.Ordered]() *List[T] {
+	return New(cmp.Less[T])
+}
+
+// Len returns the number of elements in l.
+func (l *List[T]) Len() int {
+	return l.l.Len()
+}
+
+// elem returns the Element stored in e, or nil if e is nil.
+func elem[T any](e *list.Element[*Element[T]]) *Element[T] {
+	if e == nil {
+		return nil
+	}
+	return e.Value
+}
+
+// Front returns the least element of l, or nil if l is empty.
+func (l *List[T]) Front() *Element[T] {
+	return elem(l.l.Front())
+}
+
+// Back returns the greatest element of l, or nil if l is empty.
+func (l *List[T]) Back() *Element[T] {
+	return elem(l.l.Back())
+}
+
+// Insert adds v to l in order, after any elements equal to it, and
+// returns the new element.
+func (l *List[T]) Insert(v T) *Element[T] {
+	x := &Element[T]{value: v}
+	x.e = l.l.InsertOrdered(x, l.lessElem)
+	return x
+}
+
+// Find returns the first element of l equal to v, meaning neither is less
+// than the other, or nil if there is none. The scan stops at the first
+// element greater than v.
+func (l *List[T]) Find(v T) *Element[T] {
+	for e := l.l.Front(); e != nil; e = e.Next() {
+		if l.less(e.Value.value, v) {
+			continue
+		}
+		if l.less(v, e.Value.value) {
+			return nil
+		}
+		return e.Value
+	}
+	return nil
+}
+
+// Contains reports whether l has an element equal to v.
+func (l *List[T]) Contains(v T) bool {
+	return l.Find(v) != nil
+}
+
+// Remove removes e from l if e is an element of l, and returns its value.
+func (l *List[T]) Remove(e *Element[T]) T {
+	l.l.Remove(e.e)
+	return e.value
+}
+
+// Delete removes the first element of l equal to v. It reports whether
+// there was one.
+func (l *List[T]) Delete(v T) bool {
+	e := l.Find(v)
+	if e == nil {
+		return false
+	}
+	l.l.Remove(e.e)
+	return true
+}
+
+// PopFront removes and returns the least value in l. The boolean is false
+// if l is empty.
+func (l *List[T]) PopFront() (T, bool) {
+	e := l.l.Front()
+	if e == nil {
+		var zero T
+		return zero, false
+	}
+	return l.l.Remove(e).value, true
+}
+
+// PopBack removes and returns the greatest value in l. The boolean is
+// false if l is empty.
+func (l *List[T]) PopBack() (T, bool) {
+	e := l.l.Back()
+	if e == nil {
+		var zero T
+		return zero, false
+	}
+	return l.l.Remove(e).value, true
+}
+
+// Clear removes all elements from l.
+func (l *List[T]) Clear() {
+	l.l.Init()
+}
+
+// All returns an iterator over the values of l in ascending order. It is
+// safe to remove the current element during iteration.
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for x := range l.l.All() {
+			if !yield(x.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values of l in descending order.
+// It is safe to remove the current element during iteration.
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for x := range l.l.Backward() {
+			if !yield(x.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package sortedlist
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func checkList(t *testing.T, l *List[int], want []int) {
+	t.Helper()
+	if l.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", l.Len(), len(want))
+	}
+	if got := slices.Collect(l.All()); !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	slices.Reverse(want)
+	if got := slices.Collect(l.Backward()); !slices.Equal(got, want) {
+		t.Errorf("Backward() = %v, want %v", got, want)
+	}
+	slices.Reverse(want)
+}
+
+func TestList(t *testing.T) {
+	l := NewOrdered[int]()
+	if _, ok := l.PopFront(); ok {
+		t.Errorf("PopFront on empty list reported true")
+	}
+	for _, v := range []int{5, 1, 4, 1, 3} {
+		l.Insert(v)
+	}
+	checkList(t, l, []int{1, 1, 3, 4, 5})
+	if l.Front().Value() != 1 || l.Back().Value() != 5 {
+		t.Errorf("Front, Back = %d, %d; want 1, 5", l.Front().Value(), l.Back().Value())
+	}
+	if e := l.Find(3); e == nil || e.Value() != 3 {
+		t.Errorf("Find(3) = %v, want element with 3", e)
+	} else if e.Next().Value() != 4 || e.Prev().Value() != 1 {
+		t.Errorf("Find(3) neighbors = %d, %d; want 1, 4", e.Prev().Value(), e.Next().Value())
+	}
+	if l.Front().Prev() != nil || l.Back().Next() != nil {
+		t.Errorf("Front().Prev() or Back().Next() is not nil")
+	}
+	if l.Contains(2) || l.Contains(6) || l.Contains(0) {
+		t.Errorf("Contains reported a missing value")
+	}
+	if !l.Delete(1) || l.Delete(2) {
+		t.Errorf("Delete(1), Delete(2) = false, true; want true, false")
+	}
+	checkList(t, l, []int{1, 3, 4, 5})
+	if v, _ := l.PopBack(); v != 5 {
+		t.Errorf("PopBack() = %d, want 5", v)
+	}
+	if v, _ := l.PopFront(); v != 1 {
+		t.Errorf("PopFront() = %d, want 1", v)
+	}
+	l.Remove(l.Find(4))
+	checkList(t, l, []int{3})
+	l.Clear()
+	checkList(t, l, nil)
+}
+
+func TestListStable(t *testing.T) {
+	type pair struct{ key, seq int }
+	l := New(func(a, b pair) bool { return a.key > b.key }) // descending
+	r := rand.New(rand.NewSource(1))
+	var ref []pair
+	for i := 0; i < 300; i++ {
+		p := pair{r.Intn(30), i}
+		l.Insert(p)
+		ref = append(ref, p)
+		if r.Intn(4) == 0 {
+			q := pair{key: r.Intn(30)}
+			e := l.Find(q)
+			j := slices.IndexFunc(ref, func(p pair) bool { return p.key == q.key })
+			if (e == nil) != (j < 0) {
+				t.Fatalf("Find(%d) = %v, reference index %d", q.key, e, j)
+			}
+			if e != nil {
+				l.Remove(e)
+				ref = slices.Delete(ref, j, j+1)
+			}
+		}
+	}
+	slices.SortStableFunc(ref, func(a, b pair) int { return b.key - a.key })
+	if got := slices.Collect(l.All()); !slices.Equal(got, ref) {
+		t.Errorf("All() = %v, want %v", got, ref)
+	}
+}
@@ -0,0 +1,62 @@
+// Package sqlx provides adapters for storing containers in database/sql
+// columns and reading them from text-based configuration.
+package sqlx
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONColumn wraps a value that is stored as JSON. It implements
+// sql.Scanner and driver.Valuer, so it can be used with JSON or JSONB
+// columns, and encoding.TextMarshaler and encoding.TextUnmarshaler, so it
+// can be parsed from flags and text configuration formats.
+//
+// T must be marshalable with encoding/json: a plain Go value, or a
+// container type that implements json.Marshaler and json.Unmarshaler.
+type JSONColumn[T any] struct {
+	V T
+}
+
+// Value implements driver.Valuer by encoding c.V as JSON.
+func (c JSONColumn[T]) Value() (driver.Value, error) {
+	b, err := json.Marshal(c.V)
+	if err != nil {
+		return nil, fmt.Errorf("sqlx: encoding JSON column: %w", err)
+	}
+	return b, nil
+}
+
+// Scan implements sql.Scanner. It decodes a JSON []byte or string into
+// c.V. A NULL column sets c.V to its zero value.
+func (c *JSONColumn[T]) Scan(src any) error {
+	var b []byte
+	switch src := src.(type) {
+	case nil:
+		var zero T
+		c.V = zero
+		return nil
+	case []byte:
+		b = src
+	case string:
+		b = []byte(src)
+	default:
+		return fmt.Errorf("sqlx: cannot scan %T into JSON column", src)
+	}
+	if err := json.Unmarshal(b, &c.V); err != nil {
+		return fmt.Errorf("sqlx: decoding JSON column: %w", err)
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler by encoding c.V as JSON.
+func (c JSONColumn[T]) MarshalText() ([]byte, error) {
+	return json.Marshal(c.V)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by decoding JSON
+// text into c.V.
+func (c *JSONColumn[T]) UnmarshalText(text []byte) error {
+	return json.Unmarshal(text, &c.V)
+}
@@ -0,0 +1,71 @@
+package sqlx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"reflect"
+	"testing"
+)
+
+var (
+	_ sql.Scanner              = (*JSONColumn[int])(nil)
+	_ driver.Valuer            = JSONColumn[int]{}
+	_ encoding.TextMarshaler   = JSONColumn[int]{}
+	_ encoding.TextUnmarshaler = (*JSONColumn[int])(nil)
+)
+
+func TestJSONColumnRoundTrip(t *testing.T) {
+	in := JSONColumn[map[string][]int]{V: map[string][]int{"a": {1, 2}, "b": nil}}
+	v, err := in.Value()
+	if err != nil {
+		t.Fatalf("Value() error: %v", err)
+	}
+	if got := string(v.([]byte)); got != `{"a":[1,2],"b":null}` {
+		t.Errorf("Value() = %s", got)
+	}
+
+	for _, src := range []any{v, string(v.([]byte))} {
+		var out JSONColumn[map[string][]int]
+		if err := out.Scan(src); err != nil {
+			t.Fatalf("Scan(%T) error: %v", src, err)
+		}
+		if !reflect.DeepEqual(out.V, in.V) {
+			t.Errorf("Scan(%T) = %v, want %v", src, out.V, in.V)
+		}
+	}
+}
+
+func TestJSONColumnScanNull(t *testing.T) {
+	c := JSONColumn[[]string]{V: []string{"x"}}
+	if err := c.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error: %v", err)
+	}
+	if c.V != nil {
+		t.Errorf("Scan(nil) left V = %v, want nil", c.V)
+	}
+}
+
+func TestJSONColumnScanErrors(t *testing.T) {
+	var c JSONColumn[[]int]
+	if err := c.Scan(42); err == nil {
+		t.Errorf("Scan(int) returned nil error")
+	}
+	if err := c.Scan([]byte("{")); err == nil {
+		t.Errorf("Scan of malformed JSON returned nil error")
+	}
+}
+
+func TestJSONColumnText(t *testing.T) {
+	var c JSONColumn[[]string]
+	if err := c.UnmarshalText([]byte(`["a","b"]`)); err != nil {
+		t.Fatalf("UnmarshalText error: %v", err)
+	}
+	if !reflect.DeepEqual(c.V, []string{"a", "b"}) {
+		t.Errorf("UnmarshalText = %v", c.V)
+	}
+	b, err := c.MarshalText()
+	if err != nil || string(b) != `["a","b"]` {
+		t.Errorf("MarshalText() = %s, %v", b, err)
+	}
+}
@@ -0,0 +1,76 @@
+// Package stack implements a LIFO stack.
+package stack
+
+import "github.com/nishanths/typedcontainer/list"
+
+// Stack is a last-in, first-out stack. By default it is backed by a slice;
+// NewLinked returns a Stack backed by a list.List instead, which never
+// copies elements when it grows but allocates for every Push. All
+// operations are O(1), amortized for the slice backing.
+//
+// The zero value for Stack is an empty, slice-backed stack ready to use.
+type Stack[T any] struct {
+	s []T
+	l *list.List[T] // non-nil if list-backed
+}
+
+// New returns an empty slice-backed Stack.
+func New[T any]() *Stack[T] {
+	return new(Stack[T])
+}
+
+// NewLinked returns an empty Stack backed by a list.List.
+func NewLinked[T any]() *Stack[T] {
+	return &Stack[T]{l: list.New[T]()}
+}
+
+// Len returns the number of elements in s.
+func (s *Stack[T]) Len() int {
+	if s.l != nil {
+		return s.l.Len()
+	}
+	return len(s.s)
+}
+
+// Push pushes v onto s.
+func (s *Stack[T]) Push(v T) {
+	if s.l != nil {
+		s.l.PushBack(v)
+		return
+	}
+	s.s = append(s.s, v)
+}
+
+// Peek returns the top element of s without removing it. The boolean is
+// false if s is empty.
+func (s *Stack[T]) Peek() (T, bool) {
+	if s.l != nil {
+		if e := s.l.Back(); e != nil {
+			return e.Value, true
+		}
+	} else if n := len(s.s); n > 0 {
+		return s.s[n-1], true
+	}
+	var zero T
+	return zero, false
+}
+
+// Pop removes and returns the top element of s. The boolean is false if s
+// is empty.
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if s.l != nil {
+		if e := s.l.Back(); e != nil {
+			return s.l.Remove(e), true
+		}
+		return zero, false
+	}
+	n := len(s.s)
+	if n == 0 {
+		return zero, false
+	}
+	v := s.s[n-1]
+	s.s[n-1] = zero // drop the reference for the GC
+	s.s = s.s[:n-1]
+	return v, true
+}
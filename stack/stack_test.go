@@ -0,0 +1,32 @@
+package stack
+
+import "testing"
+
+func TestStack(t *testing.T) {
+	for name, s := range map[string]*Stack[int]{
+		"slice":  new(Stack[int]),
+		"New":    New[int](),
+		"linked": NewLinked[int](),
+	} {
+		if _, ok := s.Pop(); ok {
+			t.Errorf("%s: Pop on empty stack reported true", name)
+		}
+		if _, ok := s.Peek(); ok {
+			t.Errorf("%s: Peek on empty stack reported true", name)
+		}
+		for i := 1; i <= 100; i++ {
+			s.Push(i)
+		}
+		if v, _ := s.Peek(); v != 100 || s.Len() != 100 {
+			t.Errorf("%s: Peek, Len = %d, %d; want 100, 100", name, v, s.Len())
+		}
+		for want := 100; want >= 1; want-- {
+			if v, ok := s.Pop(); !ok || v != want {
+				t.Fatalf("%s: Pop() = %d, %v; want %d, true", name, v, ok, want)
+			}
+		}
+		if s.Len() != 0 {
+			t.Errorf("%s: Len() after draining = %d", name, s.Len())
+		}
+	}
+}
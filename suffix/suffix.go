@@ -0,0 +1,188 @@
+// Package suffix implements a suffix array with a longest-common-prefix
+// table for substring queries over a byte string.
+package suffix
+
+import (
+	"bytes"
+	"slices"
+	"sort"
+)
+
+// Array is a suffix array over a byte string. Building an Array is
+// O(n log² n); Contains and Count are O(m log n) for a pattern of length m.
+// An Array must not be modified after creation.
+type Array struct {
+	data []byte
+	sa   []int // suffix start offsets in lexicographic order
+	lcp  []int // lcp[i] is the common prefix length of sa[i-1] and sa[i]
+}
+
+// New builds an Array over data. The Array refers to data, which must not
+// be modified while the Array is in use.
+func New(data []byte) *Array {
+	s := make([]int, len(data))
+	for i, b := range data {
+		s[i] = int(b)
+	}
+	sa := build(s)
+	return &Array{data: data, sa: sa, lcp: kasai(s, sa)}
+}
+
+// Len returns the length of the indexed data.
+func (a *Array) Len() int {
+	return len(a.data)
+}
+
+// Contains reports whether sub occurs in the indexed data.
+func (a *Array) Contains(sub []byte) bool {
+	lo, hi := a.bounds(sub)
+	return lo < hi
+}
+
+// Count returns the number of possibly overlapping occurrences of sub in
+// the indexed data. By convention the empty string occurs Len()+1 times.
+func (a *Array) Count(sub []byte) int {
+	if len(sub) == 0 {
+		return len(a.data) + 1
+	}
+	lo, hi := a.bounds(sub)
+	return hi - lo
+}
+
+// Lookup returns the offsets of the occurrences of sub in the indexed data,
+// in increasing order. It returns nil if sub is empty or does not occur.
+func (a *Array) Lookup(sub []byte) []int {
+	if len(sub) == 0 {
+		return nil
+	}
+	lo, hi := a.bounds(sub)
+	if lo == hi {
+		return nil
+	}
+	offs := slices.Clone(a.sa[lo:hi])
+	slices.Sort(offs)
+	return offs
+}
+
+// LongestRepeated returns the longest substring that occurs at least twice
+// in the indexed data. It returns nil if there is none.
+func (a *Array) LongestRepeated() []byte {
+	best, at := 0, 0
+	for i := 1; i < len(a.lcp); i++ {
+		if a.lcp[i] > best {
+			best, at = a.lcp[i], a.sa[i]
+		}
+	}
+	if best == 0 {
+		return nil
+	}
+	return a.data[at : at+best]
+}
+
+// bounds returns the range [lo, hi) of a.sa whose suffixes begin with sub.
+func (a *Array) bounds(sub []byte) (lo, hi int) {
+	lo = sort.Search(len(a.sa), func(i int) bool {
+		return bytes.Compare(a.data[a.sa[i]:], sub) >= 0
+	})
+	hi = lo + sort.Search(len(a.sa)-lo, func(i int) bool {
+		return !bytes.HasPrefix(a.data[a.sa[lo+i]:], sub)
+	})
+	return lo, hi
+}
+
+// LongestCommonSubstring returns the longest substring common to a and b.
+// It returns nil if there is none.
+func LongestCommonSubstring(a, b []byte) []byte {
+	// Index a and b joined by a separator outside the byte range, so that
+	// no common prefix extends across the boundary.
+	const sep = 256
+	s := make([]int, 0, len(a)+1+len(b))
+	for _, c := range a {
+		s = append(s, int(c))
+	}
+	s = append(s, sep)
+	for _, c := range b {
+		s = append(s, int(c))
+	}
+	sa := build(s)
+	lcp := kasai(s, sa)
+
+	best, at := 0, 0
+	for i := 1; i < len(sa); i++ {
+		inA, prevInA := sa[i] < len(a), sa[i-1] < len(a)
+		if inA != prevInA && lcp[i] > best {
+			best, at = lcp[i], min(sa[i], sa[i-1])
+		}
+	}
+	if best == 0 {
+		return nil
+	}
+	return a[at : at+best]
+}
+
+// build returns the suffix array of s using prefix doubling.
+func build(s []int) []int {
+	n := len(s)
+	sa := make([]int, n)
+	rank := make([]int, n)
+	tmp := make([]int, n)
+	for i := range sa {
+		sa[i] = i
+		rank[i] = s[i]
+	}
+	for k := 1; ; k <<= 1 {
+		key := func(i int) int {
+			if i+k < n {
+				return rank[i+k]
+			}
+			return -1
+		}
+		less := func(i, j int) bool {
+			if rank[i] != rank[j] {
+				return rank[i] < rank[j]
+			}
+			return key(i) < key(j)
+		}
+		sort.Slice(sa, func(x, y int) bool { return less(sa[x], sa[y]) })
+		if n == 0 {
+			return sa
+		}
+		tmp[sa[0]] = 0
+		for i := 1; i < n; i++ {
+			tmp[sa[i]] = tmp[sa[i-1]]
+			if less(sa[i-1], sa[i]) {
+				tmp[sa[i]]++
+			}
+		}
+		copy(rank, tmp)
+		if rank[sa[n-1]] == n-1 {
+			return sa
+		}
+	}
+}
+
+// kasai returns the LCP table for s and its suffix array sa.
+func kasai(s, sa []int) []int {
+	n := len(s)
+	lcp := make([]int, n)
+	rank := make([]int, n)
+	for i, p := range sa {
+		rank[p] = i
+	}
+	h := 0
+	for i := 0; i < n; i++ {
+		if rank[i] == 0 {
+			h = 0
+			continue
+		}
+		j := sa[rank[i]-1]
+		for i+h < n && j+h < n && s[i+h] == s[j+h] {
+			h++
+		}
+		lcp[rank[i]] = h
+		if h > 0 {
+			h--
+		}
+	}
+	return lcp
+}
@@ -0,0 +1,122 @@
+package suffix
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func naiveSuffixArray(data []byte) []int {
+	sa := make([]int, len(data))
+	for i := range sa {
+		sa[i] = i
+	}
+	sort.Slice(sa, func(i, j int) bool { return bytes.Compare(data[sa[i]:], data[sa[j]:]) < 0 })
+	return sa
+}
+
+func naiveLookup(data, sub []byte) []int {
+	var offs []int
+	for i := 0; i+len(sub) <= len(data); i++ {
+		if bytes.Equal(data[i:i+len(sub)], sub) {
+			offs = append(offs, i)
+		}
+	}
+	return offs
+}
+
+func randomBytes(r *rand.Rand, n int, alphabet string) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return b
+}
+
+func TestBuild(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 10, 100, 500} {
+		data := randomBytes(r, n, "ab")
+		a := New(data)
+		if want := naiveSuffixArray(data); !slices.Equal(a.sa, want) {
+			t.Errorf("suffix array of %q = %v, want %v", data, a.sa, want)
+		}
+		for i := 1; i < len(a.sa); i++ {
+			x, y := data[a.sa[i-1]:], data[a.sa[i]:]
+			h := 0
+			for h < len(x) && h < len(y) && x[h] == y[h] {
+				h++
+			}
+			if a.lcp[i] != h {
+				t.Fatalf("lcp[%d] = %d, want %d", i, a.lcp[i], h)
+			}
+		}
+	}
+}
+
+func TestQueries(t *testing.T) {
+	data := []byte("mississippi")
+	a := New(data)
+	if a.Len() != len(data) {
+		t.Errorf("Len() = %d, want %d", a.Len(), len(data))
+	}
+	tests := []struct {
+		sub   string
+		count int
+	}{
+		{"i", 4},
+		{"ss", 2},
+		{"issi", 2},
+		{"mississippi", 1},
+		{"ppi", 1},
+		{"x", 0},
+		{"mississippix", 0},
+		{"", len(data) + 1},
+	}
+	for _, tt := range tests {
+		if got := a.Count([]byte(tt.sub)); got != tt.count {
+			t.Errorf("Count(%q) = %d, want %d", tt.sub, got, tt.count)
+		}
+		if got := a.Contains([]byte(tt.sub)); got != (tt.count > 0) {
+			t.Errorf("Contains(%q) = %v", tt.sub, got)
+		}
+	}
+	if got, want := a.Lookup([]byte("ssi")), []int{2, 5}; !slices.Equal(got, want) {
+		t.Errorf("Lookup(ssi) = %v, want %v", got, want)
+	}
+	if got := a.LongestRepeated(); string(got) != "issi" {
+		t.Errorf("LongestRepeated() = %q, want issi", got)
+	}
+}
+
+func TestLookupRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	data := randomBytes(r, 300, "abc")
+	a := New(data)
+	for i := 0; i < 100; i++ {
+		sub := randomBytes(r, 1+r.Intn(4), "abc")
+		if got, want := a.Lookup(sub), naiveLookup(data, sub); !slices.Equal(got, want) {
+			t.Errorf("Lookup(%q) = %v, want %v", sub, got, want)
+		}
+	}
+}
+
+func TestLongestCommonSubstring(t *testing.T) {
+	tests := []struct {
+		a, b, want string
+	}{
+		{"", "", ""},
+		{"abc", "", ""},
+		{"abc", "xyz", ""},
+		{"xabcdy", "zzabcdzz", "abcd"},
+		{"banana", "ananas", "anana"},
+		{"\xff\x00\x01", "\x00\x01\xff", "\x00\x01"},
+	}
+	for _, tt := range tests {
+		if got := LongestCommonSubstring([]byte(tt.a), []byte(tt.b)); string(got) != tt.want {
+			t.Errorf("LongestCommonSubstring(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,387 @@
+// Package synclist implements a doubly-linked list that is safe for
+// concurrent use.
+package synclist
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/nishanths/typedcontainer/list"
+)
+
+// List is a list.List guarded by a mutex. Each method holds the lock for
+// its duration, so every method is atomic with respect to the others. Use
+// Do to run a compound operation, such as a traversal followed by an
+// update, under a single lock.
+//
+// Methods without their own documentation behave like the list.List
+// method of the same name. Every method of list.List has a counterpart
+// here, as do the functions SortOrdered, Contains, and IndexOf; other
+// package-level functions of list, such as Equal and Map, can be run with
+// Do. Walking elements with Element.Next or Element.Prev reads the list's
+// links without the lock and must be done inside Do.
+//
+// The zero value for List is an empty list ready to use.
+type List[T any] struct {
+	mu sync.Mutex
+	l  list.List[T]
+}
+
+// New returns an empty List.
+func New[T any]() *List[T] {
+	return new(List[T])
+}
+
+// Do calls f with the underlying list while holding the lock. f must not
+// call methods of l, and must not retain the list after it returns.
+func (l *List[T]) Do(f func(*list.List[T])) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f(&l.l)
+}
+
+func (l *List[T]) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Len()
+}
+
+func (l *List[T]) Init() *List[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.Init()
+	return l
+}
+
+func (l *List[T]) Front() *list.Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Front()
+}
+
+func (l *List[T]) Back() *list.Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Back()
+}
+
+func (l *List[T]) PushFront(v T) *list.Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.PushFront(v)
+}
+
+func (l *List[T]) PushBack(v T) *list.Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.PushBack(v)
+}
+
+func (l *List[T]) InsertBefore(v T, mark *list.Element[T]) *list.Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.InsertBefore(v, mark)
+}
+
+func (l *List[T]) InsertAfter(v T, mark *list.Element[T]) *list.Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.InsertAfter(v, mark)
+}
+
+func (l *List[T]) Remove(e *list.Element[T]) T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Remove(e)
+}
+
+func (l *List[T]) MoveToFront(e *list.Element[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.MoveToFront(e)
+}
+
+func (l *List[T]) MoveToBack(e *list.Element[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.MoveToBack(e)
+}
+
+func (l *List[T]) MoveBefore(e, mark *list.Element[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.MoveBefore(e, mark)
+}
+
+func (l *List[T]) MoveAfter(e, mark *list.Element[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.MoveAfter(e, mark)
+}
+
+// PushBackList inserts a copy of other at the back of l. other must not be
+// modified concurrently; to copy from another List, pass it from within
+// that List's Do.
+func (l *List[T]) PushBackList(other *list.List[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.PushBackList(other)
+}
+
+// PushFrontList inserts a copy of other at the front of l, with the same
+// restrictions as PushBackList.
+func (l *List[T]) PushFrontList(other *list.List[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.PushFrontList(other)
+}
+
+// PopFront removes and returns the first element value of l. The boolean
+// is false if l is empty.
+func (l *List[T]) PopFront() (T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e := l.l.Front()
+	if e == nil {
+		var zero T
+		return zero, false
+	}
+	return l.l.Remove(e), true
+}
+
+// PopBack removes and returns the last element value of l. The boolean is
+// false if l is empty.
+func (l *List[T]) PopBack() (T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e := l.l.Back()
+	if e == nil {
+		var zero T
+		return zero, false
+	}
+	return l.l.Remove(e), true
+}
+
+// ToSlice returns a snapshot of the element values of l, front to back.
+func (l *List[T]) ToSlice() []T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.ToSlice()
+}
+
+// All returns an iterator over a snapshot of the element values of l,
+// taken when iteration starts. The lock is not held while yielding, so the
+// loop body may call methods of l.
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range l.ToSlice() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over a snapshot of the element values of
+// l, back to front, with the same behavior as All.
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s := l.ToSlice()
+		for i := len(s) - 1; i >= 0; i-- {
+			if !yield(s[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Elements returns an iterator over a snapshot of the elements of l,
+// front to back, taken when iteration starts. The lock is not held while
+// yielding, so the loop body may call methods of l, such as Remove; an
+// element removed by another goroutine in the meantime is still yielded.
+func (l *List[T]) Elements() iter.Seq[*list.Element[T]] {
+	return func(yield func(*list.Element[T]) bool) {
+		l.mu.Lock()
+		es := slices.Collect(l.l.Elements())
+		l.mu.Unlock()
+		for _, e := range es {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// AppendTo appends a snapshot of the element values of l, front to back,
+// to dst and returns the extended slice.
+func (l *List[T]) AppendTo(dst []T) []T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.AppendTo(dst)
+}
+
+// Clone returns a new List holding a copy of l's element values.
+func (l *List[T]) Clone() *List[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c := New[T]()
+	c.l.PushBackList(&l.l)
+	return c
+}
+
+func (l *List[T]) Find(pred func(T) bool) *list.Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Find(pred)
+}
+
+func (l *List[T]) FindLast(pred func(T) bool) *list.Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.FindLast(pred)
+}
+
+// Contains reports whether v is an element value of l.
+func Contains[T comparable](l *List[T], v T) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return list.Contains(&l.l, v)
+}
+
+// IndexOf returns the position of the first element of l whose value is
+// v, or -1 if there is none.
+func IndexOf[T comparable](l *List[T], v T) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return list.IndexOf(&l.l, v)
+}
+
+func (l *List[T]) RemoveIf(pred func(T) bool) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.RemoveIf(pred)
+}
+
+// Sort sorts l by less while holding the lock. less must not call
+// methods of l.
+func (l *List[T]) Sort(less func(a, b T) bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.Sort(less)
+}
+
+// SortOrdered sorts l in ascending order.
+func SortOrdered[T cmp.Ordered](l *List[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	list.SortOrdered(&l.l)
+}
+
+func (l *List[T]) InsertOrdered(v T, less func(a, b T) bool) *list.Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.InsertOrdered(v, less)
+}
+
+// MoveToIndex is like list.List.MoveToIndex. The returned undo function
+// also holds the lock.
+func (l *List[T]) MoveToIndex(e *list.Element[T], i int) (undo func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	u := l.l.MoveToIndex(e, i)
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		u()
+	}
+}
+
+// SpliceBack moves the elements of other to the back of l, with the same
+// restrictions as PushBackList.
+func (l *List[T]) SpliceBack(other *list.List[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.SpliceBack(other)
+}
+
+// SpliceAfter moves the elements of other to just after mark, with the
+// same restrictions as PushBackList.
+func (l *List[T]) SpliceAfter(other *list.List[T], mark *list.Element[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.SpliceAfter(other, mark)
+}
+
+// Freeze makes l read-only and returns the underlying list as a Reader.
+// Since a frozen list cannot change, the Reader may be used without the
+// lock.
+func (l *List[T]) Freeze() list.Reader[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Freeze()
+}
+
+func (l *List[T]) Frozen() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Frozen()
+}
+
+func (l *List[T]) SetStrict(strict bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.SetStrict(strict)
+}
+
+func (l *List[T]) Strict() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Strict()
+}
+
+// SetArena is like list.List.SetArena. An Arena is not safe for
+// concurrent use, so a must not be shared with another list used from a
+// different goroutine.
+func (l *List[T]) SetArena(a *list.Arena[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.SetArena(a)
+}
+
+func (l *List[T]) MarshalJSON() ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.MarshalJSON()
+}
+
+func (l *List[T]) UnmarshalJSON(data []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.UnmarshalJSON(data)
+}
+
+func (l *List[T]) GobEncode() ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.GobEncode()
+}
+
+func (l *List[T]) GobDecode(data []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.GobDecode(data)
+}
+
+func (l *List[T]) MarshalBinary() ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.MarshalBinary()
+}
+
+func (l *List[T]) UnmarshalBinary(data []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.UnmarshalBinary(data)
+}
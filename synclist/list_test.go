@@ -0,0 +1,143 @@
+package synclist
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/nishanths/typedcontainer/list"
+)
+
+func TestList(t *testing.T) {
+	var l List[int]
+	if _, ok := l.PopFront(); ok {
+		t.Errorf("PopFront on empty list reported true")
+	}
+	e2 := l.PushBack(2)
+	l.PushFront(1)
+	e4 := l.PushBack(4)
+	l.InsertBefore(3, e4)
+	l.InsertAfter(5, e4)
+	if got := l.ToSlice(); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("ToSlice() = %v, want [1 2 3 4 5]", got)
+	}
+	l.MoveToBack(e2)
+	l.MoveAfter(e4, l.Front())
+	if got := slices.Collect(l.All()); !slices.Equal(got, []int{1, 4, 3, 5, 2}) {
+		t.Errorf("All() = %v, want [1 4 3 5 2]", got)
+	}
+	if v, _ := l.PopBack(); v != 2 {
+		t.Errorf("PopBack() = %d, want 2", v)
+	}
+	if l.Remove(e4) != 4 || l.Len() != 3 {
+		t.Errorf("Remove(e4) left Len() = %d", l.Len())
+	}
+
+	for v := range l.All() {
+		l.PushBack(v * 10) // the snapshot lets the body call l
+	}
+	if got := l.ToSlice(); !slices.Equal(got, []int{1, 3, 5, 10, 30, 50}) {
+		t.Errorf("ToSlice() = %v", got)
+	}
+
+	other := list.FromSlice([]int{0})
+	l.PushFrontList(other)
+	if v, _ := l.PopFront(); v != 0 {
+		t.Errorf("PopFront() after PushFrontList = %d, want 0", v)
+	}
+	l.Init()
+	if l.Len() != 0 {
+		t.Errorf("Len() after Init = %d", l.Len())
+	}
+}
+
+func TestListConcurrent(t *testing.T) {
+	l := New[int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				l.PushBack(i)
+				if i%2 == 0 {
+					l.PopFront()
+				}
+				// Move the front element to the back as one operation.
+				l.Do(func(l *list.List[int]) {
+					if e := l.Front(); e != nil {
+						l.MoveToBack(e)
+					}
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	if l.Len() != 8*500 {
+		t.Errorf("Len() = %d, want %d", l.Len(), 8*500)
+	}
+	n := 0
+	l.Do(func(l *list.List[int]) {
+		for range l.All() {
+			n++
+		}
+	})
+	if n != l.Len() {
+		t.Errorf("traversal found %d elements, want %d", n, l.Len())
+	}
+}
+
+// TestMethodSet checks that every method of list.List has a wrapper.
+func TestMethodSet(t *testing.T) {
+	want := reflect.TypeFor[*list.List[int]]()
+	got := reflect.TypeFor[*List[int]]()
+	for i := range want.NumMethod() {
+		name := want.Method(i).Name
+		if _, ok := got.MethodByName(name); !ok {
+			t.Errorf("List has no method %s", name)
+		}
+	}
+}
+
+func TestListExtended(t *testing.T) {
+	l := New[int]()
+	for _, v := range []int{3, 1, 4, 1, 5} {
+		l.PushBack(v)
+	}
+	if got := slices.Collect(l.Backward()); !slices.Equal(got, []int{5, 1, 4, 1, 3}) {
+		t.Errorf("Backward() = %v", got)
+	}
+	for e := range l.Elements() {
+		if e.Value == 1 {
+			l.Remove(e)
+		}
+	}
+	SortOrdered(l)
+	l.InsertOrdered(2, func(a, b int) bool { return a < b })
+	if got := l.AppendTo(nil); !slices.Equal(got, []int{2, 3, 4, 5}) {
+		t.Errorf("AppendTo(nil) = %v, want [2 3 4 5]", got)
+	}
+	undo := l.MoveToIndex(l.Find(func(v int) bool { return v == 5 }), 0)
+	if i := IndexOf(l, 5); i != 0 {
+		t.Errorf("IndexOf(5) after MoveToIndex = %d, want 0", i)
+	}
+	undo()
+	if !Contains(l, 5) || IndexOf(l, 5) != 3 {
+		t.Errorf("IndexOf(5) after undo = %d, want 3", IndexOf(l, 5))
+	}
+
+	data, err := json.Marshal(l)
+	if err != nil || string(data) != "[2,3,4,5]" {
+		t.Fatalf("json.Marshal = %s, %v", data, err)
+	}
+	c := l.Clone()
+	c.PushBack(6)
+	if l.Len() != 4 {
+		t.Errorf("Len() after changing clone = %d, want 4", l.Len())
+	}
+	if r := l.Freeze(); !l.Frozen() || r.Len() != 4 {
+		t.Errorf("Freeze() did not freeze")
+	}
+}
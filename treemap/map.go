@@ -0,0 +1,378 @@
+// Package treemap implements a sorted map as a balanced binary search
+// tree.
+package treemap
+
+import (
+	"cmp"
+	"iter"
+)
+
+// node is a node of an AVL tree. Each node records the height and number
+// of entries of its subtree; the sizes make rank queries O(log n).
+type node[K, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+	height      int
+	size        int
+}
+
+func (n *node[K, V]) heightOf() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *node[K, V]) sizeOf() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// update recomputes n's height and size from its children.
+func (n *node[K, V]) update() {
+	n.height = 1 + max(n.left.heightOf(), n.right.heightOf())
+	n.size = 1 + n.left.sizeOf() + n.right.sizeOf()
+}
+
+func rotateRight[K, V any](n *node[K, V]) *node[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+func rotateLeft[K, V any](n *node[K, V]) *node[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+// rebalance restores the AVL invariant at n, whose subtrees are balanced
+// and differ in height by at most 2, and returns the new subtree root.
+func rebalance[K, V any](n *node[K, V]) *node[K, V] {
+	n.update()
+	switch bf := n.left.heightOf() - n.right.heightOf(); {
+	case bf > 1:
+		if n.left.right.heightOf() > n.left.left.heightOf() {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case bf < -1:
+		if n.right.left.heightOf() > n.right.right.heightOf() {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+// Map is a sorted map from K to V, stored as an AVL tree. Get, Set,
+// Delete, Floor, Ceiling, Rank, and KthSmallest are O(log n).
+//
+// A Map is not safe for concurrent use, and must not be modified during
+// iteration.
+//
+// The zero value for Map is not usable; use New or NewFunc.
+type Map[K, V any] struct {
+	cmp  func(a, b K) int
+	root *node[K, V]
+}
+
+// New returns an empty Map for an ordered key type.
+func New[K cmp.Ordered, V any]() *Map[K, V] {
+	return NewFunc[K, V](cmp.Compare[K])
+}
+
+// NewFunc returns an empty Map ordered by cmp, which returns a negative
+// number when a < b, a positive number when a > b, and zero when a == b.
+func NewFunc[K, V any](cmp func(a, b K) int) *Map[K, V] {
+	return &Map[K, V]{cmp: cmp}
+}
+
+// Len returns the number of entries in m.
+func (m *Map[K, V]) Len() int {
+	return m.root.sizeOf()
+}
+
+func (m *Map[K, V]) find(k K) *node[K, V] {
+	for n := m.root; n != nil; {
+		switch c := m.cmp(k, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// Get returns the value for k. The boolean is false if k is not in m.
+func (m *Map[K, V]) Get(k K) (V, bool) {
+	if n := m.find(k); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// GetOr returns the value for k, or def if k is not in m.
+func (m *Map[K, V]) GetOr(k K, def V) V {
+	if v, ok := m.Get(k); ok {
+		return v
+	}
+	return def
+}
+
+// MustGet returns the value for k. It panics if k is not in m.
+func (m *Map[K, V]) MustGet(k K) V {
+	v, ok := m.Get(k)
+	if !ok {
+		panic("treemap: MustGet of missing key")
+	}
+	return v
+}
+
+// Contains reports whether k is in m.
+func (m *Map[K, V]) Contains(k K) bool {
+	return m.find(k) != nil
+}
+
+// Set sets the value for k. It reports whether k was added, rather than
+// already in m.
+func (m *Map[K, V]) Set(k K, v V) bool {
+	var added bool
+	m.root, added = m.set(m.root, k, v)
+	return added
+}
+
+func (m *Map[K, V]) set(n *node[K, V], k K, v V) (*node[K, V], bool) {
+	if n == nil {
+		return &node[K, V]{key: k, value: v, height: 1, size: 1}, true
+	}
+	var added bool
+	switch c := m.cmp(k, n.key); {
+	case c < 0:
+		n.left, added = m.set(n.left, k, v)
+	case c > 0:
+		n.right, added = m.set(n.right, k, v)
+	default:
+		n.value = v
+		return n, false
+	}
+	return rebalance(n), added
+}
+
+// Delete removes k from m. It reports whether k was in m.
+func (m *Map[K, V]) Delete(k K) bool {
+	var deleted bool
+	m.root, deleted = m.delete(m.root, k)
+	return deleted
+}
+
+func (m *Map[K, V]) delete(n *node[K, V], k K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var deleted bool
+	switch c := m.cmp(k, n.key); {
+	case c < 0:
+		n.left, deleted = m.delete(n.left, k)
+	case c > 0:
+		n.right, deleted = m.delete(n.right, k)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// Replace n with its successor.
+		var succ *node[K, V]
+		n.right, succ = deleteMin(n.right)
+		succ.left, succ.right = n.left, n.right
+		return rebalance(succ), true
+	}
+	return rebalance(n), deleted
+}
+
+// deleteMin removes the least node of the non-empty subtree n and returns
+// the new subtree root and the removed node.
+func deleteMin[K, V any](n *node[K, V]) (*node[K, V], *node[K, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var least *node[K, V]
+	n.left, least = deleteMin(n.left)
+	return rebalance(n), least
+}
+
+// Clear removes all entries from m.
+func (m *Map[K, V]) Clear() {
+	m.root = nil
+}
+
+func unpack[K, V any](n *node[K, V]) (K, V, bool) {
+	if n == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	return n.key, n.value, true
+}
+
+// Min returns the entry with the least key. The boolean is false if m is
+// empty.
+func (m *Map[K, V]) Min() (K, V, bool) {
+	n := m.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return unpack(n)
+}
+
+// Max returns the entry with the greatest key. The boolean is false if m
+// is empty.
+func (m *Map[K, V]) Max() (K, V, bool) {
+	n := m.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return unpack(n)
+}
+
+// Floor returns the entry with the greatest key less than or equal to k.
+// The boolean is false if there is none.
+func (m *Map[K, V]) Floor(k K) (K, V, bool) {
+	var best *node[K, V]
+	for n := m.root; n != nil; {
+		switch c := m.cmp(k, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			best = n
+			n = n.right
+		default:
+			return unpack(n)
+		}
+	}
+	return unpack(best)
+}
+
+// Ceiling returns the entry with the least key greater than or equal to
+// k. The boolean is false if there is none.
+func (m *Map[K, V]) Ceiling(k K) (K, V, bool) {
+	var best *node[K, V]
+	for n := m.root; n != nil; {
+		switch c := m.cmp(k, n.key); {
+		case c < 0:
+			best = n
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return unpack(n)
+		}
+	}
+	return unpack(best)
+}
+
+// Rank returns the number of keys in m that are less than k. If k is in
+// m, Rank(k) is its zero-based position in key order.
+func (m *Map[K, V]) Rank(k K) int {
+	r := 0
+	for n := m.root; n != nil; {
+		switch c := m.cmp(k, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			r += n.left.sizeOf() + 1
+			n = n.right
+		default:
+			return r + n.left.sizeOf()
+		}
+	}
+	return r
+}
+
+// KthSmallest returns the entry at zero-based position i in key order.
+// The boolean is false if i is not in [0, m.Len()).
+func (m *Map[K, V]) KthSmallest(i int) (K, V, bool) {
+	if i < 0 || i >= m.Len() {
+		return unpack[K, V](nil)
+	}
+	n := m.root
+	for {
+		l := n.left.sizeOf()
+		switch {
+		case i < l:
+			n = n.left
+		case i > l:
+			i -= l + 1
+			n = n.right
+		default:
+			return unpack(n)
+		}
+	}
+}
+
+// All returns an iterator over the entries of m in increasing key order.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.ascend(m.root, nil, nil, yield)
+	}
+}
+
+// Backward returns an iterator over the entries of m in decreasing key
+// order.
+func (m *Map[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		descend(m.root, yield)
+	}
+}
+
+// Range returns an iterator over the entries of m with keys in [lo, hi),
+// in increasing key order.
+func (m *Map[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.ascend(m.root, &lo, &hi, yield)
+	}
+}
+
+// ascend yields the entries of n with keys in [lo, hi) in order; nil bounds
+// are unbounded. It reports whether iteration should continue.
+func (m *Map[K, V]) ascend(n *node[K, V], lo, hi *K, yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	aboveLo := lo == nil || m.cmp(n.key, *lo) >= 0
+	belowHi := hi == nil || m.cmp(n.key, *hi) < 0
+	if aboveLo && !m.ascend(n.left, lo, hi, yield) {
+		return false
+	}
+	if aboveLo && belowHi && !yield(n.key, n.value) {
+		return false
+	}
+	if belowHi {
+		return m.ascend(n.right, lo, hi, yield)
+	}
+	return true
+}
+
+// descend yields the entries of n in reverse order. It reports whether
+// iteration should continue.
+func descend[K, V any](n *node[K, V], yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return descend(n.right, yield) && yield(n.key, n.value) && descend(n.left, yield)
+}
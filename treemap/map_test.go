@@ -0,0 +1,150 @@
+package treemap
+
+import (
+	"iter"
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func keys[K, V any](seq iter.Seq2[K, V]) []K {
+	var out []K
+	for k := range seq {
+		out = append(out, k)
+	}
+	return out
+}
+
+// check verifies the AVL invariants and cached sizes of the subtree n and
+// returns its height.
+func check[K, V any](t *testing.T, m *Map[K, V], n *node[K, V]) int {
+	t.Helper()
+	if n == nil {
+		return 0
+	}
+	if n.left != nil && m.cmp(n.left.key, n.key) >= 0 || n.right != nil && m.cmp(n.right.key, n.key) <= 0 {
+		t.Fatalf("keys out of order at %v", n.key)
+	}
+	lh, rh := check(t, m, n.left), check(t, m, n.right)
+	if lh-rh > 1 || rh-lh > 1 {
+		t.Fatalf("unbalanced at %v: heights %d, %d", n.key, lh, rh)
+	}
+	if n.height != 1+max(lh, rh) || n.size != 1+n.left.sizeOf()+n.right.sizeOf() {
+		t.Fatalf("stale height or size at %v", n.key)
+	}
+	return n.height
+}
+
+func TestMap(t *testing.T) {
+	m := New[int, string]()
+	if _, _, ok := m.Min(); ok {
+		t.Errorf("Min on empty map reported true")
+	}
+	if _, _, ok := m.Floor(0); ok {
+		t.Errorf("Floor on empty map reported true")
+	}
+	for _, k := range []int{50, 20, 80, 10, 30, 70, 90} {
+		m.Set(k, "")
+	}
+	if m.Set(30, "thirty") || m.MustGet(30) != "thirty" {
+		t.Errorf("Set of existing key")
+	}
+	tests := []struct {
+		k               int
+		floor, ceiling  int
+		floorOK, ceilOK bool
+		rank            int
+	}{
+		{5, 0, 10, false, true, 0},
+		{10, 10, 10, true, true, 0},
+		{25, 20, 30, true, true, 2},
+		{50, 50, 50, true, true, 3},
+		{95, 90, 0, true, false, 7},
+	}
+	for _, tt := range tests {
+		if k, _, ok := m.Floor(tt.k); ok != tt.floorOK || ok && k != tt.floor {
+			t.Errorf("Floor(%d) = %d, %v; want %d, %v", tt.k, k, ok, tt.floor, tt.floorOK)
+		}
+		if k, _, ok := m.Ceiling(tt.k); ok != tt.ceilOK || ok && k != tt.ceiling {
+			t.Errorf("Ceiling(%d) = %d, %v; want %d, %v", tt.k, k, ok, tt.ceiling, tt.ceilOK)
+		}
+		if r := m.Rank(tt.k); r != tt.rank {
+			t.Errorf("Rank(%d) = %d, want %d", tt.k, r, tt.rank)
+		}
+	}
+	if got := keys(m.Range(20, 70)); !slices.Equal(got, []int{20, 30, 50}) {
+		t.Errorf("Range(20, 70) = %v, want [20 30 50]", got)
+	}
+	if got := keys(m.Backward()); !slices.Equal(got, []int{90, 80, 70, 50, 30, 20, 10}) {
+		t.Errorf("Backward() = %v", got)
+	}
+	if k, _, _ := m.KthSmallest(3); k != 50 {
+		t.Errorf("KthSmallest(3) = %d, want 50", k)
+	}
+	if _, _, ok := m.KthSmallest(7); ok {
+		t.Errorf("KthSmallest(Len()) reported true")
+	}
+	if k, _, _ := m.Min(); k != 10 {
+		t.Errorf("Min() = %d, want 10", k)
+	}
+	if k, _, _ := m.Max(); k != 90 {
+		t.Errorf("Max() = %d, want 90", k)
+	}
+	m.Clear()
+	if m.Len() != 0 || m.Contains(50) {
+		t.Errorf("Clear left entries")
+	}
+}
+
+func TestMapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := New[int, int]()
+	ref := make(map[int]int)
+	for i := 0; i < 20000; i++ {
+		k := r.Intn(2000)
+		if r.Intn(3) < 2 {
+			_, had := ref[k]
+			if added := m.Set(k, i); added == had {
+				t.Fatalf("Set(%d) = %v, want %v", k, added, !had)
+			}
+			ref[k] = i
+		} else {
+			_, had := ref[k]
+			if m.Delete(k) != had {
+				t.Fatalf("Delete(%d) = %v, want %v", k, !had, had)
+			}
+			delete(ref, k)
+		}
+		if i%500 == 0 {
+			check(t, m, m.root)
+		}
+	}
+	check(t, m, m.root)
+	want := slices.Sorted(maps.Keys(ref))
+	if got := keys(m.All()); !slices.Equal(got, want) {
+		t.Fatalf("All() keys differ from reference")
+	}
+	for i, k := range want {
+		if m.Rank(k) != i {
+			t.Fatalf("Rank(%d) = %d, want %d", k, m.Rank(k), i)
+		}
+		if got, v, _ := m.KthSmallest(i); got != k || v != ref[k] {
+			t.Fatalf("KthSmallest(%d) = %d, %d; want %d, %d", i, got, v, k, ref[k])
+		}
+	}
+	for q := -1; q <= 2001; q += 7 {
+		j, found := slices.BinarySearch(want, q)
+		k, _, ok := m.Ceiling(q)
+		if ok != (j < len(want)) || ok && k != want[j] {
+			t.Fatalf("Ceiling(%d) = %d, %v", q, k, ok)
+		}
+		if !found {
+			j--
+		}
+		k, _, ok = m.Floor(q)
+		if ok != (j >= 0) || ok && k != want[j] {
+			t.Fatalf("Floor(%d) = %d, %v", q, k, ok)
+		}
+	}
+}